@@ -21,8 +21,11 @@
 package aip
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/epkgs/query"
 	"github.com/epkgs/query/clause"
@@ -31,10 +34,54 @@ import (
 	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
 )
 
+// options 为 FromFilter 的转换选项
+type options struct {
+	resolvers []PathResolver
+	functions Functions
+}
+
+// Option 设置 FromFilter 的转换选项
+type Option func(*options)
+
+// WithPathResolver 设置字段路径解析器，多个解析器按顺序尝试，
+// 均未处理的路径原样作为列名（如 "author.address.city"）。
+func WithPathResolver(resolvers ...PathResolver) Option {
+	return func(o *options) {
+		o.resolvers = append(o.resolvers, resolvers...)
+	}
+}
+
+// WithFunctions 注册自定义过滤函数，同名函数后注册的覆盖先注册的。
+// 内置的运算符和标准函数不能被覆盖。
+func WithFunctions(functions Functions) Option {
+	return func(o *options) {
+		if o.functions == nil {
+			o.functions = make(Functions, len(functions))
+		}
+		for name, fn := range functions {
+			o.functions[name] = fn
+		}
+	}
+}
+
+// WithFunction 注册单个自定义过滤函数，作用同 WithFunctions
+func WithFunction(name string, fn Function) Option {
+	return WithFunctions(Functions{name: fn})
+}
+
 // FromFilter 将 AIP 标准的 filtering.Filter 转换为 clause.Where。
 // 支持 AIP 过滤语法中的所有常见运算符：=, !=, >, >=, <, <=, HAS(:), IN, NOT, AND, OR。
+// 值可以是常量，或标准函数 timestamp("...") 与 duration("...")，分别转换为 time.Time 与 time.Duration。
 // 当 Filter.CheckedExpr 为空时（即未传递过滤参数），返回空的 clause.Where。
-func FromFilter(filter filtering.Filter) (clause.Where, error) {
+//
+// 嵌套字段（如 "author.address.city"）保留完整路径，可通过 WithPathResolver
+// 将其映射为 JOIN 表的列或 JSON 列中的路径。
+func FromFilter(filter filtering.Filter, opts ...Option) (clause.Where, error) {
+	opt := &options{}
+	for _, o := range opts {
+		o(opt)
+	}
+
 	// 创建 Query 对象
 	q := query.Table("")
 
@@ -44,7 +91,7 @@ func FromFilter(filter filtering.Filter) (clause.Where, error) {
 		exp := checkedExpr.GetExpr()
 		if exp != nil {
 			// 解析表达式
-			exprs, err := parseExpr(exp)
+			exprs, err := parseExpr(exp, opt)
 			if err != nil {
 				return clause.Where{}, err
 			}
@@ -73,21 +120,28 @@ func FromFilter(filter filtering.Filter) (clause.Where, error) {
 		}
 	}
 
+	if len(opt.resolvers) > 0 {
+		return resolvePaths(q.WhereExpr(), opt.resolvers)
+	}
+
 	return q.WhereExpr(), nil
 }
 
 // parseExpr 将 *exprpb.Expr 转换为 []clause.Expression
-func parseExpr(exp *exprpb.Expr) ([]clause.Expression, error) {
+func parseExpr(exp *exprpb.Expr, opt *options) ([]clause.Expression, error) {
 	if exp == nil {
 		return nil, fmt.Errorf("nil expression")
 	}
 
 	switch kind := exp.ExprKind.(type) {
 	case *exprpb.Expr_CallExpr:
-		return parseCallExpr(kind.CallExpr)
-	case *exprpb.Expr_IdentExpr:
-		// 处理标识符表达式（字段名）
-		fieldName := kind.IdentExpr.Name
+		return parseCallExpr(kind.CallExpr, opt)
+	case *exprpb.Expr_IdentExpr, *exprpb.Expr_SelectExpr:
+		// 处理标识符表达式（字段名或嵌套字段路径）
+		fieldName, err := parseField(exp)
+		if err != nil {
+			return nil, err
+		}
 		return []clause.Expression{clause.Eq{Col: fieldName, Val: true}}, nil
 	default:
 		// TODO: 支持更多类型
@@ -96,7 +150,7 @@ func parseExpr(exp *exprpb.Expr) ([]clause.Expression, error) {
 }
 
 // parseCallExpr 解析函数调用表达式
-func parseCallExpr(call *exprpb.Expr_Call) ([]clause.Expression, error) {
+func parseCallExpr(call *exprpb.Expr_Call, opt *options) ([]clause.Expression, error) {
 	if call == nil {
 		return nil, fmt.Errorf("nil call expression")
 	}
@@ -108,9 +162,9 @@ func parseCallExpr(call *exprpb.Expr_Call) ([]clause.Expression, error) {
 	// 在 AIP filtering 中，运算符被建模为函数调用
 	switch funcName {
 	case "AND":
-		return parseAndExpr(call.Args)
+		return parseAndExpr(call.Args, opt)
 	case "OR":
-		return parseOrExpr(call.Args)
+		return parseOrExpr(call.Args, opt)
 	case "EQUALS", "=":
 		return parseEqualsExpr(call.Args)
 	case "NOT_EQUALS", "!=":
@@ -124,25 +178,28 @@ func parseCallExpr(call *exprpb.Expr_Call) ([]clause.Expression, error) {
 	case "LESS_EQUALS", "<=":
 		return parseLessEqualsExpr(call.Args)
 	case "NOT":
-		return parseNotExpr(call.Args)
+		return parseNotExpr(call.Args, opt)
 	case "HAS", ":":
 		return parseHasExpr(call.Args)
 	case "IN", "_in_":
 		return parseInExpr(call.Args)
 	default:
+		if fn, ok := opt.functions[funcName]; ok {
+			return parseCustomFunction(fn, call, opt)
+		}
 		return nil, fmt.Errorf("unsupported function: %s", funcName)
 	}
 }
 
 // parseAndExpr 解析 AND 函数
-func parseAndExpr(args []*exprpb.Expr) ([]clause.Expression, error) {
+func parseAndExpr(args []*exprpb.Expr, opt *options) ([]clause.Expression, error) {
 	if len(args) == 0 {
 		return nil, nil
 	}
 
 	var exprs []clause.Expression
 	for _, arg := range args {
-		parsed, err := parseExpr(arg)
+		parsed, err := parseExpr(arg, opt)
 		if err != nil {
 			return nil, err
 		}
@@ -157,14 +214,14 @@ func parseAndExpr(args []*exprpb.Expr) ([]clause.Expression, error) {
 }
 
 // parseOrExpr 解析 OR 函数
-func parseOrExpr(args []*exprpb.Expr) ([]clause.Expression, error) {
+func parseOrExpr(args []*exprpb.Expr, opt *options) ([]clause.Expression, error) {
 	if len(args) == 0 {
 		return nil, nil
 	}
 
 	var exprs []clause.Expression
 	for _, arg := range args {
-		parsed, err := parseExpr(arg)
+		parsed, err := parseExpr(arg, opt)
 		if err != nil {
 			return nil, err
 		}
@@ -178,7 +235,7 @@ func parseOrExpr(args []*exprpb.Expr) ([]clause.Expression, error) {
 	return []clause.Expression{clause.Or(exprs...)}, nil
 }
 
-// parseField 解析字段表达式
+// parseField 解析字段表达式，嵌套字段返回以 "." 连接的完整路径（如 "author.address.city"）
 func parseField(exp *exprpb.Expr) (string, error) {
 	if exp == nil {
 		return "", fmt.Errorf("nil expression")
@@ -189,9 +246,13 @@ func parseField(exp *exprpb.Expr) (string, error) {
 		return ident.Name, nil
 	}
 
-	// 检查是否是选择表达式
+	// 检查是否是选择表达式，递归解析其操作数作为路径前缀
 	if selectExpr := exp.GetSelectExpr(); selectExpr != nil {
-		return selectExpr.Field, nil
+		operand, err := parseField(selectExpr.Operand)
+		if err != nil {
+			return "", err
+		}
+		return operand + "." + selectExpr.Field, nil
 	}
 
 	return "", fmt.Errorf("unsupported field expression: %T", exp.ExprKind)
@@ -224,9 +285,51 @@ func parseValue(exp *exprpb.Expr) (interface{}, error) {
 		}
 	}
 
+	// 检查是否是 AIP-160 标准函数调用：timestamp("...") 与 duration("...")
+	if call := exp.GetCallExpr(); call != nil {
+		return parseFunctionValue(call)
+	}
+
 	return nil, fmt.Errorf("unsupported value expression: %T", exp.ExprKind)
 }
 
+// parseFunctionValue 解析作为值的标准函数调用：
+//   - timestamp("2024-01-01T00:00:00Z") 转换为 time.Time（RFC3339 格式）
+//   - duration("3600s") 转换为 time.Duration
+func parseFunctionValue(call *exprpb.Expr_Call) (interface{}, error) {
+	switch call.Function {
+	case filtering.FunctionTimestamp, filtering.FunctionDuration:
+	default:
+		return nil, fmt.Errorf("unsupported function: %s", call.Function)
+	}
+
+	if len(call.Args) != 1 {
+		return nil, fmt.Errorf("%s expects exactly 1 argument, got %d", call.Function, len(call.Args))
+	}
+	arg, err := parseValue(call.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	str, ok := arg.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s expects a string argument, got %T", call.Function, arg)
+	}
+
+	if call.Function == filtering.FunctionTimestamp {
+		t, err := time.Parse(time.RFC3339Nano, str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp %q: %w", str, err)
+		}
+		return t, nil
+	}
+
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration %q: %w", str, err)
+	}
+	return d, nil
+}
+
 // parseEqualsExpr 解析 EQUALS 函数
 func parseEqualsExpr(args []*exprpb.Expr) ([]clause.Expression, error) {
 	if len(args) != 2 {
@@ -354,12 +457,12 @@ func parseLessEqualsExpr(args []*exprpb.Expr) ([]clause.Expression, error) {
 }
 
 // parseNotExpr 解析 NOT 函数
-func parseNotExpr(args []*exprpb.Expr) ([]clause.Expression, error) {
+func parseNotExpr(args []*exprpb.Expr, opt *options) ([]clause.Expression, error) {
 	if len(args) != 1 {
 		return nil, fmt.Errorf("NOT expects exactly 1 argument, got %d", len(args))
 	}
 
-	parsed, err := parseExpr(args[0])
+	parsed, err := parseExpr(args[0], opt)
 	if err != nil {
 		return nil, err
 	}
@@ -465,3 +568,289 @@ func parseValues(exp *exprpb.Expr) ([]interface{}, error) {
 	}
 	return []interface{}{v}, nil
 }
+
+// ErrUnsupportedExpr 表示表达式无法用 AIP-160 过滤语法表示，
+// 如原生 SQL、子查询、列与列的比较、ILIKE 等。
+var ErrUnsupportedExpr = errors.New("expression unsupported by aip filter")
+
+// ToFilter 将 clause.Where 转换为 AIP-160 标准的过滤字符串，是 FromFilter 的逆向转换，
+// 可用于将流畅 API 构建的查询转发给下游符合 AIP 标准的服务。
+//
+// 转换规则：
+//   - 比较运算符直接对应：=, !=, >, >=, <, <=
+//   - IS NULL / IS NOT NULL（含值为 nil 的 = / !=）转换为 NOT field:* / field:*
+//   - LIKE 与 StartsWith/EndsWith/Contains 转换为 HAS 通配符：field:"v*"、field:"*v"、field:"v"
+//   - IN / NOT IN 转换为 OR / AND 组合，BETWEEN 转换为 >= 与 <= 的组合
+//   - 条件之间的连接方式与构建的 SQL 一致，如流畅 API 中 Or 追加的条件以 OR 连接
+//   - 嵌套的 AND/OR/NOT 总是加括号，不依赖 AIP 中 OR 优先于 AND 的规则
+//
+// 字符串值以双引号包裹，time.Time 与 time.Duration 分别转换为标准函数 timestamp("...") 与 duration("1.5s")。
+// 无法表示的表达式返回 ErrUnsupportedExpr。
+//
+// 示例:
+//
+//	where := query.Eq("status", "active").Gte("age", 18).StartsWith("name", "Jo").WhereExpr()
+//	filter, _ := aip.ToFilter(where)
+//	// status = "active" AND age >= 18 AND name:"Jo*"
+func ToFilter(where clause.Where) (string, error) {
+	var b strings.Builder
+	if err := writeFilterExprs(&b, where.Exprs, " AND ", false); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// writeFilterExprs 写入以 sep 连接的多个表达式，nested 为 true 且多于一个表达式时加括号
+func writeFilterExprs(b *strings.Builder, exprs []clause.Expression, sep string, nested bool) error {
+	if len(exprs) == 0 {
+		return nil
+	}
+
+	if sep == " AND " {
+		if groups := splitOrGroups(exprs); len(groups) > 1 {
+			if nested {
+				b.WriteByte('(')
+			}
+			for idx, group := range groups {
+				if idx > 0 {
+					b.WriteString(" OR ")
+				}
+				if err := writeFilterExprs(b, group, " AND ", true); err != nil {
+					return err
+				}
+			}
+			if nested {
+				b.WriteByte(')')
+			}
+			return nil
+		}
+	}
+
+	if len(exprs) == 1 {
+		return writeFilterExpr(b, exprs[0], nested)
+	}
+
+	if nested {
+		b.WriteByte('(')
+	}
+	for idx, expr := range exprs {
+		if idx > 0 {
+			b.WriteString(sep)
+		}
+		if err := writeFilterExpr(b, expr, true); err != nil {
+			return err
+		}
+	}
+	if nested {
+		b.WriteByte(')')
+	}
+	return nil
+}
+
+// splitOrGroups 按构建 SQL 时的连接方式拆分 AND 列表：非首个的单元素 OrExpr（如流畅 API 的 Or 追加的条件）
+// 与前面的条件以 OR 连接，返回以 OR 连接的各组，组内以 AND 连接
+func splitOrGroups(exprs []clause.Expression) [][]clause.Expression {
+	var groups [][]clause.Expression
+	start := 0
+	for idx, expr := range exprs {
+		if logical, ok := expr.(clause.LogicalExpression); ok && idx > 0 && logical.Operator() == clause.LogicOr && len(logical.SubExprs()) == 1 {
+			groups = append(groups, exprs[start:idx])
+			start = idx
+		}
+	}
+	return append(groups, exprs[start:])
+}
+
+// writeFilterExpr 写入单个表达式
+func writeFilterExpr(b *strings.Builder, expr clause.Expression, nested bool) error {
+	switch e := expr.(type) {
+	case clause.LogicalExpression:
+		switch e.Operator() {
+		case clause.LogicAnd:
+			return writeFilterExprs(b, e.SubExprs(), " AND ", nested)
+		case clause.LogicOr:
+			return writeFilterExprs(b, e.SubExprs(), " OR ", nested)
+		case clause.LogicNot:
+			b.WriteString("NOT ")
+			return writeFilterExprs(b, e.SubExprs(), " AND ", true)
+		}
+	case clause.ComparisonExpression:
+		return writeComparison(b, e, nested)
+	}
+	return fmt.Errorf("%w: %T", ErrUnsupportedExpr, expr)
+}
+
+// writeComparison 写入比较表达式
+func writeComparison(b *strings.Builder, e clause.ComparisonExpression, nested bool) error {
+	field := e.Column()
+	if _, ok := e.Value().(clause.Column); ok {
+		return fmt.Errorf("%w: column comparison on %s", ErrUnsupportedExpr, field)
+	}
+
+	switch e.Operator() {
+	case clause.OpEQ:
+		if e.Value() == nil {
+			return writeFilterExpr(b, clause.IsNull{Col: field}, nested)
+		}
+		return writeRestriction(b, field, "=", e.Value())
+	case clause.OpNEQ:
+		if e.Value() == nil {
+			return writeFilterExpr(b, clause.IsNotNull{Col: field}, nested)
+		}
+		return writeRestriction(b, field, "!=", e.Value())
+	case clause.OpGT:
+		return writeRestriction(b, field, ">", e.Value())
+	case clause.OpGTE:
+		return writeRestriction(b, field, ">=", e.Value())
+	case clause.OpLT:
+		return writeRestriction(b, field, "<", e.Value())
+	case clause.OpLTE:
+		return writeRestriction(b, field, "<=", e.Value())
+	case clause.OpISNULL:
+		b.WriteString("NOT ")
+		b.WriteString(field)
+		b.WriteString(":*")
+		return nil
+	case clause.OpISNOTNULL:
+		b.WriteString(field)
+		b.WriteString(":*")
+		return nil
+	case clause.OpLIKE:
+		pattern, ok := e.Value().(string)
+		if !ok {
+			return fmt.Errorf("%w: like value must be string", ErrUnsupportedExpr)
+		}
+		return writeHas(b, field, pattern)
+	case clause.OpNOTLIKE:
+		pattern, ok := e.Value().(string)
+		if !ok {
+			return fmt.Errorf("%w: like value must be string", ErrUnsupportedExpr)
+		}
+		b.WriteString("NOT ")
+		return writeHas(b, field, pattern)
+	case clause.OpSTARTSWITH, clause.OpENDSWITH, clause.OpCONTAINS:
+		value := fmt.Sprint(e.Value())
+		if strings.ContainsAny(value, "*%_") {
+			return fmt.Errorf("%w: wildcard in %s value", ErrUnsupportedExpr, e.Operator())
+		}
+		switch e.Operator() {
+		case clause.OpSTARTSWITH:
+			value += "%"
+		case clause.OpENDSWITH:
+			value = "%" + value
+		default:
+			value = "%" + value + "%"
+		}
+		return writeHas(b, field, value)
+	case clause.OpIN, clause.OpNOTIN:
+		values, _ := e.Value().([]any)
+		if len(values) == 0 {
+			return fmt.Errorf("%w: empty %s", ErrUnsupportedExpr, e.Operator())
+		}
+		exprs := make([]clause.Expression, len(values))
+		for idx, v := range values {
+			if e.Operator() == clause.OpIN {
+				exprs[idx] = clause.Eq{Col: field, Val: v}
+			} else {
+				exprs[idx] = clause.Neq{Col: field, Val: v}
+			}
+		}
+		if e.Operator() == clause.OpIN {
+			return writeFilterExprs(b, exprs, " OR ", nested)
+		}
+		return writeFilterExprs(b, exprs, " AND ", nested)
+	case clause.OpBETWEEN:
+		bounds := e.Value().([]any)
+		return writeFilterExprs(b, []clause.Expression{
+			clause.Gte{Col: field, Val: bounds[0]},
+			clause.Lte{Col: field, Val: bounds[1]},
+		}, " AND ", nested)
+	case clause.OpNOTBETWEEN:
+		bounds := e.Value().([]any)
+		return writeFilterExprs(b, []clause.Expression{
+			clause.Lt{Col: field, Val: bounds[0]},
+			clause.Gt{Col: field, Val: bounds[1]},
+		}, " OR ", nested)
+	}
+	return fmt.Errorf("%w: operator %s", ErrUnsupportedExpr, e.Operator())
+}
+
+// writeRestriction 写入 field op value 形式的限制条件
+func writeRestriction(b *strings.Builder, field, op string, value any) error {
+	b.WriteString(field)
+	b.WriteByte(' ')
+	b.WriteString(op)
+	b.WriteByte(' ')
+	return writeValue(b, value)
+}
+
+// writeHas 将 LIKE 模式转换为 HAS 通配符，是 hasLikeExpr 的逆向转换：
+// "%v%" 转换为 field:"v"，"v%" 转换为 field:"v*"，"%v" 转换为 field:"*v"
+func writeHas(b *strings.Builder, field, pattern string) error {
+	if strings.ContainsAny(pattern, "*_") {
+		return fmt.Errorf("%w: like pattern %q", ErrUnsupportedExpr, pattern)
+	}
+
+	if len(pattern) > 2 && strings.HasPrefix(pattern, "%") && strings.HasSuffix(pattern, "%") &&
+		!strings.Contains(pattern[1:len(pattern)-1], "%") {
+		pattern = pattern[1 : len(pattern)-1]
+	} else if !strings.Contains(pattern, "%") {
+		return fmt.Errorf("%w: like pattern without wildcard %q", ErrUnsupportedExpr, pattern)
+	} else {
+		pattern = strings.ReplaceAll(pattern, "%", "*")
+	}
+
+	b.WriteString(field)
+	b.WriteByte(':')
+	writeString(b, pattern)
+	return nil
+}
+
+// writeValue 写入 AIP 字面量
+func writeValue(b *strings.Builder, value any) error {
+	switch v := value.(type) {
+	case string:
+		writeString(b, v)
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+	case time.Time:
+		b.WriteString(filtering.FunctionTimestamp + "(")
+		writeString(b, v.Format(time.RFC3339Nano))
+		b.WriteByte(')')
+	case time.Duration:
+		b.WriteString(filtering.FunctionDuration + "(")
+		writeString(b, strconv.FormatFloat(v.Seconds(), 'f', -1, 64)+"s")
+		b.WriteByte(')')
+	case float32:
+		writeFloat(b, float64(v), 32)
+	case float64:
+		writeFloat(b, v, 64)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		fmt.Fprint(b, v)
+	default:
+		return fmt.Errorf("%w: value type %T", ErrUnsupportedExpr, value)
+	}
+	return nil
+}
+
+// writeFloat 写入浮点数，整数值补充 ".0" 以免被解析为整数
+func writeFloat(b *strings.Builder, f float64, bitSize int) {
+	s := strconv.FormatFloat(f, 'g', -1, bitSize)
+	if !strings.ContainsAny(s, ".eEnN") {
+		s += ".0"
+	}
+	b.WriteString(s)
+}
+
+// writeString 写入双引号包裹的字符串，转义反斜杠和双引号
+func writeString(b *strings.Builder, s string) {
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\', '"':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+}
@@ -1,10 +1,13 @@
 package aip
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/epkgs/query"
 	"github.com/epkgs/query/clause"
 	filtering "go.einride.tech/aip/filtering"
 	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
@@ -270,3 +273,205 @@ func TestParseInExpr(t *testing.T) {
 		t.Errorf("expected 2 values, got %d", len(in.Vals))
 	}
 }
+
+// TestToFilter 测试 clause.Where 转换为 AIP 过滤字符串
+func TestToFilter(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		where    clause.Where
+		expected string
+	}{
+		{
+			name:     "And",
+			where:    query.Eq("name", "John").Gt("age", 18).WhereExpr(),
+			expected: `name = "John" AND age > 18`,
+		},
+		{
+			name:     "Or with nested and",
+			where:    query.Where(clause.Or(clause.And(clause.Eq{Col: "name", Val: "John"}, clause.Gt{Col: "age", Val: 18}), clause.Eq{Col: "status", Val: "active"})).WhereExpr(),
+			expected: `(name = "John" AND age > 18) OR status = "active"`,
+		},
+		{
+			name:     "Not",
+			where:    clause.Where{Exprs: []clause.Expression{clause.Not(clause.And(clause.Eq{Col: "a", Val: 1}, clause.Eq{Col: "b", Val: 2}))}},
+			expected: `NOT (a = 1 AND b = 2)`,
+		},
+		{
+			name:     "Fluent Or",
+			where:    query.Eq("id", 1).Or(query.Eq("id", 2)).WhereExpr(),
+			expected: `id = 1 OR id = 2`,
+		},
+		{
+			name:     "Fluent Or after And",
+			where:    query.Eq("status", "active").Gt("age", 18).Or(query.Eq("role", "admin").Eq("verified", true)).WhereExpr(),
+			expected: `(status = "active" AND age > 18) OR (role = "admin" AND verified = true)`,
+		},
+		{
+			name:     "Fluent Or in group",
+			where:    query.Eq("deleted", false).Where(clause.And(clause.Eq{Col: "a", Val: 1}, clause.Or(clause.Eq{Col: "b", Val: 2}))).WhereExpr(),
+			expected: `deleted = false AND (a = 1 OR b = 2)`,
+		},
+		{
+			name:     "Null checks",
+			where:    clause.Where{Exprs: []clause.Expression{clause.IsNull{Col: "deleted_at"}, clause.Neq{Col: "email", Val: nil}}},
+			expected: `NOT deleted_at:* AND email:*`,
+		},
+		{
+			name: "Has wildcards",
+			where: clause.Where{Exprs: []clause.Expression{
+				clause.StartsWith{Col: "name", Val: "Jo"},
+				clause.EndsWith{Col: "email", Val: "@x.com"},
+				clause.Like{Col: "title", Val: "%go%"},
+			}},
+			expected: `name:"Jo*" AND email:"*@x.com" AND title:"go"`,
+		},
+		{
+			name:     "In and between",
+			where:    clause.Where{Exprs: []clause.Expression{clause.IN{Col: "status", Vals: []any{"a", "b"}}, clause.Between{Col: "age", From: 18, To: 30}}},
+			expected: `(status = "a" OR status = "b") AND (age >= 18 AND age <= 30)`,
+		},
+		{
+			name:     "Literals",
+			where:    clause.Where{Exprs: []clause.Expression{clause.Eq{Col: "score", Val: 2.0}, clause.Eq{Col: "active", Val: true}, clause.Gt{Col: "create_time", Val: ts}, clause.Lt{Col: "ttl", Val: 90 * time.Second}, clause.Eq{Col: "note", Val: `say "hi"`}}},
+			expected: `score = 2.0 AND active = true AND create_time > timestamp("2024-01-02T03:04:05Z") AND ttl < duration("90s") AND note = "say \"hi\""`,
+		},
+		{
+			name:     "Empty",
+			where:    clause.Where{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToFilter(tt.where)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected filter: %s, got: %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestToFilter_RoundTrip 测试 ToFilter 的结果经 FromFilter 解析后生成相同的 SQL
+func TestToFilter_RoundTrip(t *testing.T) {
+	where := query.Where(clause.Or(
+		clause.And(clause.Eq{Col: "name", Val: "John"}, clause.Gt{Col: "age", Val: 18}),
+		clause.And(clause.StartsWith{Col: "email", Val: "jo"}, clause.Lt{Col: "age", Val: 60}),
+	)).Neq("status", "deleted").WhereExpr()
+
+	filter, err := ToFilter(where)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	builder := &mockBuilder{}
+	where.Build(builder)
+	expected := strings.TrimPrefix(builder.String(), " WHERE ")
+	// FromFilter 生成的 LIKE 不带 ESCAPE 子句
+	expected = strings.ReplaceAll(expected, " ESCAPE '!'", "")
+
+	testFilterConversion(t, filter, expected, len(builder.vars))
+}
+
+// TestToFilter_Unsupported 测试无法表示为 AIP 过滤的表达式
+func TestToFilter_Unsupported(t *testing.T) {
+	tests := []clause.Expression{
+		clause.Raw("LENGTH(name) > ?", 3),
+		clause.Eq{Col: "a", Val: clause.Column{Name: "b"}},
+		clause.ILike{Col: "name", Val: "jo%"},
+		clause.Like{Col: "name", Val: "J_hn"},
+		clause.IN{Col: "id", Vals: []any{}},
+		clause.Eq{Col: "tags", Val: []string{"a"}},
+	}
+
+	for _, expr := range tests {
+		if _, err := ToFilter(clause.Where{Exprs: []clause.Expression{expr}}); !errors.Is(err, ErrUnsupportedExpr) {
+			t.Errorf("Expected ErrUnsupportedExpr for %#v, got %v", expr, err)
+		}
+	}
+}
+
+// parseTimeFilter 使用包含时间类型字段的声明解析过滤字符串
+func parseTimeFilter(t *testing.T, filter string) (clause.Where, error) {
+	declarations, err := filtering.NewDeclarations(
+		filtering.DeclareIdent("create_time", filtering.TypeTimestamp),
+		filtering.DeclareIdent("ttl", filtering.TypeDuration),
+		filtering.DeclareStandardFunctions(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create declarations: %v", err)
+	}
+
+	parsed, err := filtering.ParseFilter(mockFilterRequest{filter: filter}, declarations)
+	if err != nil {
+		t.Fatalf("Failed to parse filter: %v", err)
+	}
+	return FromFilter(parsed)
+}
+
+// TestFromFilter_TimestampAndDuration 测试 timestamp() 与 duration() 转换为 time.Time 与 time.Duration
+func TestFromFilter_TimestampAndDuration(t *testing.T) {
+	where, err := parseTimeFilter(t, `create_time > timestamp("2024-01-01T08:00:00+08:00") AND ttl <= duration("1.5s")`)
+	if err != nil {
+		t.Fatalf("Failed to convert filter: %v", err)
+	}
+
+	if len(where.Exprs) != 2 {
+		t.Fatalf("Expected 2 expressions, got %d", len(where.Exprs))
+	}
+
+	gt, ok := where.Exprs[0].(clause.Gt)
+	expectedTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if ts, isTime := gt.Val.(time.Time); !ok || !isTime || !ts.Equal(expectedTime) {
+		t.Errorf("Expected create_time > %v, got %#v", expectedTime, where.Exprs[0])
+	}
+
+	lte, ok := where.Exprs[1].(clause.Lte)
+	if !ok || lte.Val != 1500*time.Millisecond {
+		t.Errorf("Expected ttl <= 1.5s, got %#v", where.Exprs[1])
+	}
+
+	// 往返转换
+	filter, err := ToFilter(where)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if filter != `create_time > timestamp("2024-01-01T08:00:00+08:00") AND ttl <= duration("1.5s")` {
+		t.Errorf("Unexpected filter: %s", filter)
+	}
+}
+
+// TestParseValue_InvalidTimeFunction 测试无效的 timestamp()/duration() 参数
+func TestParseValue_InvalidTimeFunction(t *testing.T) {
+	call := func(function string, arg *exprpb.Expr) *exprpb.Expr {
+		return &exprpb.Expr{ExprKind: &exprpb.Expr_CallExpr{CallExpr: &exprpb.Expr_Call{
+			Function: function,
+			Args:     []*exprpb.Expr{arg},
+		}}}
+	}
+	str := func(s string) *exprpb.Expr {
+		return &exprpb.Expr{ExprKind: &exprpb.Expr_ConstExpr{ConstExpr: &exprpb.Constant{
+			ConstantKind: &exprpb.Constant_StringValue{StringValue: s},
+		}}}
+	}
+
+	tests := []*exprpb.Expr{
+		call("timestamp", str("2024-01-01")),
+		call("duration", str("ten seconds")),
+		call("duration", &exprpb.Expr{ExprKind: &exprpb.Expr_ConstExpr{ConstExpr: &exprpb.Constant{
+			ConstantKind: &exprpb.Constant_Int64Value{Int64Value: 10},
+		}}}),
+		call("lower", str("A")),
+	}
+
+	for _, exp := range tests {
+		if _, err := parseValue(exp); err == nil {
+			t.Errorf("Expected error for %v", exp)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package aip
+
+import (
+	"fmt"
+
+	"github.com/epkgs/query/clause"
+
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+)
+
+// Function 处理过滤中的自定义函数调用，返回对应的查询条件。
+//
+// 函数需要同时在 einride 的 filtering.Declarations 中声明（filtering.DeclareFunction），
+// 否则 filtering.ParseFilter 会在类型检查阶段报错。
+type Function func(call Call) (clause.Expression, error)
+
+// Functions 是自定义函数注册表，键为完整的函数名，
+// 成员函数调用包含接收者路径，如 labels.has("env") 的函数名为 "labels.has"。
+//
+// 示例:
+//
+//	functions := aip.Functions{}
+//	functions.Register("fuzzy", func(call aip.Call) (clause.Expression, error) {
+//	    field, err := call.Field(0)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    value, err := call.Value(1)
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return clause.Raw("? % ?", clause.Column{Name: field}, value), nil
+//	})
+//	where, err := aip.FromFilter(filter, aip.WithFunctions(functions))
+type Functions map[string]Function
+
+// Register 注册自定义函数，同名函数会被覆盖
+func (f Functions) Register(name string, fn Function) Functions {
+	f[name] = fn
+	return f
+}
+
+// Call 表示过滤中的一次函数调用
+type Call struct {
+	// Function 为完整的函数名
+	Function string
+	// Args 为原始参数表达式
+	Args []*exprpb.Expr
+
+	opt *options
+}
+
+// Len 返回参数个数
+func (c Call) Len() int {
+	return len(c.Args)
+}
+
+// Field 将第 i 个参数解析为字段路径（如 "location" 或 "author.name"）
+func (c Call) Field(i int) (string, error) {
+	arg, err := c.arg(i)
+	if err != nil {
+		return "", err
+	}
+	return parseField(arg)
+}
+
+// Value 将第 i 个参数解析为常量值，支持 timestamp() 与 duration()
+func (c Call) Value(i int) (interface{}, error) {
+	arg, err := c.arg(i)
+	if err != nil {
+		return nil, err
+	}
+	return parseValue(arg)
+}
+
+// Expr 将第 i 个参数解析为查询条件，参数中的自定义函数同样可用
+func (c Call) Expr(i int) (clause.Expression, error) {
+	arg, err := c.arg(i)
+	if err != nil {
+		return nil, err
+	}
+	exprs, err := parseExpr(arg, c.opt)
+	if err != nil {
+		return nil, err
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return clause.And(exprs...), nil
+}
+
+func (c Call) arg(i int) (*exprpb.Expr, error) {
+	if i < 0 || i >= len(c.Args) {
+		return nil, fmt.Errorf("function %s: missing argument %d", c.Function, i)
+	}
+	return c.Args[i], nil
+}
+
+// parseCustomFunction 调用注册的自定义函数
+func parseCustomFunction(fn Function, call *exprpb.Expr_Call, opt *options) ([]clause.Expression, error) {
+	c := Call{Function: call.Function, Args: call.Args, opt: opt}
+	expr, err := fn(c)
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", call.Function, err)
+	}
+	if expr == nil {
+		return nil, fmt.Errorf("function %s returned no expression", call.Function)
+	}
+	return []clause.Expression{expr}, nil
+}
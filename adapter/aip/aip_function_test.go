@@ -0,0 +1,113 @@
+package aip
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/epkgs/query/clause"
+	"github.com/epkgs/query/dialect"
+	filtering "go.einride.tech/aip/filtering"
+)
+
+// parseFunctionFilter 使用声明了自定义函数的声明解析过滤字符串并转换为 clause.Where
+func parseFunctionFilter(t *testing.T, filter string, opts ...Option) (clause.Where, error) {
+	declarations, err := filtering.NewDeclarations(
+		filtering.DeclareIdent("name", filtering.TypeString),
+		filtering.DeclareIdent("location", filtering.TypeString),
+		filtering.DeclareFunction("within_radius",
+			filtering.NewFunctionOverload("within_radius_string_int", filtering.TypeBool, filtering.TypeString, filtering.TypeInt)),
+		filtering.DeclareFunction("fuzzy",
+			filtering.NewFunctionOverload("fuzzy_string_string", filtering.TypeBool, filtering.TypeString, filtering.TypeString)),
+		filtering.DeclareFunction("labels.has",
+			filtering.NewFunctionOverload("labels_has_string", filtering.TypeBool, filtering.TypeString)),
+		filtering.DeclareStandardFunctions(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create declarations: %v", err)
+	}
+
+	parsed, err := filtering.ParseFilter(mockFilterRequest{filter: filter}, declarations)
+	if err != nil {
+		t.Fatalf("Failed to parse filter: %v", err)
+	}
+
+	return FromFilter(parsed, opts...)
+}
+
+// testOrigin 测试用的距离计算原点
+const testOrigin = "POINT(121.47 31.23)"
+
+// testFunctions 测试用的自定义函数
+func testFunctions() Functions {
+	return Functions{}.
+		Register("within_radius", func(call Call) (clause.Expression, error) {
+			field, err := call.Field(0)
+			if err != nil {
+				return nil, err
+			}
+			radius, err := call.Value(1)
+			if err != nil {
+				return nil, err
+			}
+			return clause.Raw("ST_DWithin(?, ST_GeomFromText(?), ?)", clause.Column{Name: field}, testOrigin, radius), nil
+		}).
+		Register("fuzzy", func(call Call) (clause.Expression, error) {
+			field, err := call.Field(0)
+			if err != nil {
+				return nil, err
+			}
+			value, err := call.Value(1)
+			if err != nil {
+				return nil, err
+			}
+			return clause.Raw("? % ?", clause.Column{Name: field}, value), nil
+		}).
+		Register("labels.has", func(call Call) (clause.Expression, error) {
+			key, err := call.Value(0)
+			if err != nil {
+				return nil, err
+			}
+			return clause.Raw("jsonb_exists(labels, ?)", key), nil
+		})
+}
+
+// TestFromFilter_CustomFunctions 测试自定义函数与内置运算符组合使用
+func TestFromFilter_CustomFunctions(t *testing.T) {
+	where, err := parseFunctionFilter(t,
+		`within_radius(location, 10) AND (fuzzy(name, "jon") OR labels.has("env"))`,
+		WithFunctions(testFunctions()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sql, args, err := dialect.Build(dialect.PostgreSQL, where)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := ` WHERE ST_DWithin("location", ST_GeomFromText($1), $2) AND ("name" % $3 OR jsonb_exists(labels, $4))`
+	if sql != expected {
+		t.Errorf("Expected SQL: %s, got: %s", expected, sql)
+	}
+	if len(args) != 4 || args[0] != testOrigin || args[1] != int64(10) || args[2] != "jon" || args[3] != "env" {
+		t.Errorf("Unexpected args: %v", args)
+	}
+}
+
+// TestFromFilter_UnregisteredFunction 测试未注册的函数及函数返回错误
+func TestFromFilter_UnregisteredFunction(t *testing.T) {
+	if _, err := parseFunctionFilter(t, `fuzzy(name, "jon")`); err == nil ||
+		!strings.Contains(err.Error(), "unsupported function: fuzzy") {
+		t.Errorf("Expected unsupported function error, got %v", err)
+	}
+
+	errRadius := errors.New("radius too large")
+	_, err := parseFunctionFilter(t, `within_radius(location, 10)`,
+		WithFunction("within_radius", func(call Call) (clause.Expression, error) {
+			return nil, errRadius
+		}))
+	if !errors.Is(err, errRadius) {
+		t.Errorf("Expected handler error, got %v", err)
+	}
+}
@@ -1,6 +1,9 @@
 package aip
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/epkgs/query/clause"
 	ordering "go.einride.tech/aip/ordering"
 )
@@ -30,3 +33,29 @@ func FromOrderBy(orderBy ordering.OrderBy) clause.OrderBys {
 
 	return orderBys
 }
+
+// ToOrderBy 将 clause.OrderBys 转换为 AIP-132 标准的 order_by 字符串，是 FromOrderBy 的逆向转换。
+// 升序字段只输出字段名，降序字段追加 " desc"，多个字段以 ", " 分隔。
+// 按表达式排序（OrderBy.Expr）无法表示，返回 ErrUnsupportedExpr。
+//
+// 示例：
+//
+//	orderBy, _ := ToOrderBy(query.Desc("create_time").Asc("name").OrderByExpr())
+//	// orderBy == "create_time desc, name"
+func ToOrderBy(orders clause.OrderBys) (string, error) {
+	fields := make([]string, 0, len(orders))
+	for _, order := range orders {
+		if order == nil {
+			continue
+		}
+		if order.Expr != nil || order.Column == "" {
+			return "", fmt.Errorf("%w: order by expression", ErrUnsupportedExpr)
+		}
+		if order.Desc {
+			fields = append(fields, order.Column+" desc")
+		} else {
+			fields = append(fields, order.Column)
+		}
+	}
+	return strings.Join(fields, ", "), nil
+}
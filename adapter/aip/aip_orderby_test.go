@@ -1,8 +1,11 @@
 package aip
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/epkgs/query"
+	"github.com/epkgs/query/clause"
 	ordering "go.einride.tech/aip/ordering"
 )
 
@@ -168,3 +171,35 @@ func TestFromOrderBy_ComplexPath(t *testing.T) {
 		t.Errorf("Expected orderby 1: Column 'user.age', Desc true, got Column '%s', Desc %v", orderby2.Column, orderby2.Desc)
 	}
 }
+
+// TestToOrderBy 测试 clause.OrderBys 转换为 AIP 排序字符串
+func TestToOrderBy(t *testing.T) {
+	orderBy, err := ToOrderBy(query.Desc("create_time").Asc("name").OrderByExpr())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if orderBy != "create_time desc, name" {
+		t.Errorf("Expected 'create_time desc, name', got '%s'", orderBy)
+	}
+
+	// 往返转换
+	parsed, err := ordering.ParseOrderBy(mockOrderByRequest{orderBy: orderBy})
+	if err != nil {
+		t.Fatalf("Failed to parse order by: %v", err)
+	}
+	if back := FromOrderBy(parsed); len(back) != 2 || back[0].Column != "create_time" || !back[0].Desc || back[1].Desc {
+		t.Errorf("Unexpected round trip result: %v", back)
+	}
+
+	if _, err := ToOrderBy(clause.OrderBys{{Expr: clause.Raw("LENGTH(name)")}}); !errors.Is(err, ErrUnsupportedExpr) {
+		t.Errorf("Expected ErrUnsupportedExpr, got %v", err)
+	}
+}
+
+// mockOrderByRequest 实现 ordering.Request 接口
+type mockOrderByRequest struct {
+	orderBy string
+}
+
+func (r mockOrderByRequest) GetOrderBy() string { return r.orderBy }
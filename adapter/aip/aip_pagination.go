@@ -0,0 +1,209 @@
+package aip
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+
+	"github.com/epkgs/query/clause"
+)
+
+var (
+	// ErrInvalidPageToken 表示 page_token 无法解析、签名不正确，或与当前请求的 filter/order_by 不一致。
+	ErrInvalidPageToken = errors.New("invalid page token")
+	// ErrEmptyPageTokenKey 表示未提供签名 page_token 的密钥。
+	ErrEmptyPageTokenKey = errors.New("empty page token key")
+	// ErrInvalidPageSize 表示 page_size 为负数。
+	ErrInvalidPageSize = errors.New("invalid page size")
+)
+
+// PageRequest 表示 AIP-158 分页请求，通常由 gRPC 生成的请求消息实现。
+// 如果请求同时实现了 GetFilter() string 或 GetOrderBy() string，
+// 其值会参与 page_token 的校验和计算。
+type PageRequest interface {
+	GetPageSize() int32
+	GetPageToken() string
+}
+
+type filterRequest interface {
+	GetFilter() string
+}
+
+type orderByRequest interface {
+	GetOrderBy() string
+}
+
+// PageToken 表示 AIP-158 的分页令牌，对客户端不透明。
+//
+// 偏移分页使用 Offset；键集分页使用 After，即上一页最后一行的排序字段值，两者不会同时设置。
+// Checksum 为 filter 和 order_by 的校验和，用于防止令牌被用于不同条件的查询。
+// 编码后的令牌以服务端的密钥做 HMAC-SHA256 签名，客户端无法伪造或修改其中的偏移量、游标和校验和；
+// 令牌内容只经过 Base64 编码，并未加密，不应包含敏感数据。
+//
+// 典型用法：
+//
+//	token, err := aip.ParsePageToken(req, key)
+//	pageSize, err := aip.PageSize(req, 20, 100)
+//	db.Scopes(gormadapter.QueryScope(where, orderBys, token.Pagination(pageSize))).Find(&users)
+//
+//	if len(users) == pageSize {
+//	    resp.NextPageToken, err = token.Next(pageSize).Encode()
+//	    // 键集分页：token.NextAfter(last.CreatedAt, last.ID).Encode()
+//	}
+type PageToken struct {
+	Offset   int
+	After    []any
+	Checksum uint32
+
+	// key 为签名令牌的密钥，由 ParsePageToken 设置
+	key []byte
+}
+
+// pageToken 是 PageToken 的编码形式
+type pageToken struct {
+	Offset   int    `json:"o,omitempty"`
+	After    string `json:"a,omitempty"`
+	Checksum uint32 `json:"c"`
+}
+
+// ParsePageToken 解析请求中的 page_token，key 为签名令牌的密钥，应由服务端保存且不能为空，
+// 为空时返回 ErrEmptyPageTokenKey。返回的令牌使用同一密钥编码下一页的令牌。
+//
+// page_token 为空时返回第一页的令牌；令牌无法解析、签名不正确、同时设置了偏移量和游标，
+// 或校验和与请求的 filter/order_by 不一致时返回 ErrInvalidPageToken。
+func ParsePageToken(req PageRequest, key []byte) (PageToken, error) {
+	if len(key) == 0 {
+		return PageToken{}, ErrEmptyPageTokenKey
+	}
+	checksum := requestChecksum(req)
+
+	raw := req.GetPageToken()
+	if raw == "" {
+		return PageToken{Checksum: checksum, key: key}, nil
+	}
+
+	payload, signature, ok := strings.Cut(raw, ".")
+	if !ok {
+		return PageToken{}, fmt.Errorf("%w: missing signature", ErrInvalidPageToken)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return PageToken{}, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return PageToken{}, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+	if !hmac.Equal(mac, sign(key, data)) {
+		return PageToken{}, fmt.Errorf("%w: signature mismatch", ErrInvalidPageToken)
+	}
+
+	var encoded pageToken
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return PageToken{}, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+
+	if encoded.Checksum != checksum {
+		return PageToken{}, fmt.Errorf("%w: filter or order_by changed", ErrInvalidPageToken)
+	}
+
+	if encoded.Offset < 0 {
+		return PageToken{}, fmt.Errorf("%w: negative offset", ErrInvalidPageToken)
+	}
+	if encoded.Offset > 0 && encoded.After != "" {
+		return PageToken{}, fmt.Errorf("%w: both offset and cursor set", ErrInvalidPageToken)
+	}
+
+	token := PageToken{Offset: encoded.Offset, Checksum: encoded.Checksum, key: key}
+	if encoded.After != "" {
+		token.After, err = clause.DecodeCursor(encoded.After)
+		if err != nil {
+			return PageToken{}, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+		}
+	}
+	return token, nil
+}
+
+// PageSize 返回请求的 page_size。
+// 为 0 时使用 defaultSize；超过 maxSize 时截断为 maxSize（maxSize 为 0 表示不限制）；
+// 为负数时返回 ErrInvalidPageSize。
+func PageSize(req PageRequest, defaultSize, maxSize int) (int, error) {
+	size := int(req.GetPageSize())
+	switch {
+	case size < 0:
+		return 0, fmt.Errorf("%w: %d", ErrInvalidPageSize, size)
+	case size == 0:
+		size = defaultSize
+	}
+	if maxSize > 0 && size > maxSize {
+		size = maxSize
+	}
+	return size, nil
+}
+
+// Pagination 将令牌转换为 clause.Pagination，pageSize 为每页条数。
+// 键集分页的 After 需要配合排序字段使用，GORM 的 QueryScope 和 Ent 的 Query 会将其转换为 WHERE 条件。
+func (t PageToken) Pagination(pageSize int) clause.Pagination {
+	p := clause.Pagination{Offset: t.Offset, After: t.After}
+	if pageSize > 0 {
+		p.Limit = &pageSize
+	}
+	return p
+}
+
+// Next 返回偏移分页的下一页令牌
+func (t PageToken) Next(pageSize int) PageToken {
+	return PageToken{Offset: t.Offset + pageSize, Checksum: t.Checksum, key: t.key}
+}
+
+// NextAfter 返回键集分页的下一页令牌，values 为当前页最后一行的排序字段值，顺序与 order_by 一致
+func (t PageToken) NextAfter(values ...any) PageToken {
+	return PageToken{After: values, Checksum: t.Checksum, key: t.key}
+}
+
+// Encode 将令牌编码为 next_page_token 使用的字符串：URL 安全的 Base64 编码内容与 HMAC-SHA256 签名，以 "." 分隔。
+// 令牌须由 ParsePageToken 返回（或由其 Next/NextAfter 派生），以便使用相同的密钥签名，否则返回 ErrEmptyPageTokenKey。
+func (t PageToken) Encode() (string, error) {
+	if len(t.key) == 0 {
+		return "", ErrEmptyPageTokenKey
+	}
+
+	encoded := pageToken{Offset: t.Offset, Checksum: t.Checksum}
+	if len(t.After) > 0 {
+		cursor, err := clause.EncodeCursor(t.After)
+		if err != nil {
+			return "", err
+		}
+		encoded.After = cursor
+	}
+
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data) + "." + base64.RawURLEncoding.EncodeToString(sign(t.key, data)), nil
+}
+
+// sign 计算令牌内容的 HMAC-SHA256 签名
+func sign(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// requestChecksum 计算请求中 filter 和 order_by 的校验和
+func requestChecksum(req PageRequest) uint32 {
+	var filter, orderBy string
+	if r, ok := req.(filterRequest); ok {
+		filter = r.GetFilter()
+	}
+	if r, ok := req.(orderByRequest); ok {
+		orderBy = r.GetOrderBy()
+	}
+	return crc32.ChecksumIEEE([]byte(filter + "\x00" + orderBy))
+}
@@ -0,0 +1,148 @@
+package aip
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testKey 测试用的令牌签名密钥
+var testKey = []byte("test-secret")
+
+// pageRequest 测试用的分页请求
+type pageRequest struct {
+	filter    string
+	orderBy   string
+	pageSize  int32
+	pageToken string
+}
+
+func (r pageRequest) GetFilter() string    { return r.filter }
+func (r pageRequest) GetOrderBy() string   { return r.orderBy }
+func (r pageRequest) GetPageSize() int32   { return r.pageSize }
+func (r pageRequest) GetPageToken() string { return r.pageToken }
+
+// TestParsePageToken_Offset 测试偏移分页令牌的往返
+func TestParsePageToken_Offset(t *testing.T) {
+	req := pageRequest{filter: "age > 18", orderBy: "name", pageSize: 10}
+
+	first, err := ParsePageToken(req, testKey)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	p := first.Pagination(10)
+	if p.Offset != 0 || p.Limit == nil || *p.Limit != 10 {
+		t.Errorf("Unexpected first page pagination: %+v", p)
+	}
+
+	next, err := first.Next(10).Encode()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	req.pageToken = next
+	second, err := ParsePageToken(req, testKey)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if p := second.Pagination(10); p.Offset != 10 {
+		t.Errorf("Expected offset 10, got %d", p.Offset)
+	}
+}
+
+// TestParsePageToken_Keyset 测试键集分页令牌的往返
+func TestParsePageToken_Keyset(t *testing.T) {
+	req := pageRequest{orderBy: "age desc, id"}
+
+	first, err := ParsePageToken(req, testKey)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	next, err := first.NextAfter(18, "u-100").Encode()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	req.pageToken = next
+	token, err := ParsePageToken(req, testKey)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	p := token.Pagination(20)
+	expected := []any{int64(18), "u-100"}
+	if !reflect.DeepEqual(p.After, expected) || p.Offset != 0 {
+		t.Errorf("Expected after %v, got %+v", expected, p)
+	}
+}
+
+// TestParsePageToken_Invalid 测试无效令牌及 filter/order_by 变化后的令牌
+func TestParsePageToken_Invalid(t *testing.T) {
+	req := pageRequest{filter: "age > 18", orderBy: "name"}
+	first, _ := ParsePageToken(req, testKey)
+	next, _ := first.Next(10).Encode()
+
+	tests := []pageRequest{
+		{filter: "age > 20", orderBy: "name", pageToken: next},
+		{filter: "age > 18", orderBy: "name desc", pageToken: next},
+		{filter: "age > 18", orderBy: "name", pageToken: "not a token"},
+		{filter: "age > 18", orderBy: "name", pageToken: "bm90LWpzb24"},
+	}
+
+	for _, tt := range tests {
+		if _, err := ParsePageToken(tt, testKey); !errors.Is(err, ErrInvalidPageToken) {
+			t.Errorf("Expected ErrInvalidPageToken for %+v, got %v", tt, err)
+		}
+	}
+}
+
+// TestParsePageToken_Tampered 测试客户端伪造或修改的令牌
+func TestParsePageToken_Tampered(t *testing.T) {
+	req := pageRequest{filter: "age > 18", orderBy: "name"}
+	first, _ := ParsePageToken(req, testKey)
+	next, _ := first.Next(10).Encode()
+
+	// 客户端按新的 filter 重新计算校验和并修改偏移量，签名不再匹配
+	other := pageRequest{filter: "role = \"admin\"", orderBy: "name"}
+	data, _ := json.Marshal(pageToken{Offset: 1000, Checksum: requestChecksum(other)})
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	_, signature, _ := strings.Cut(next, ".")
+
+	forged := []string{
+		payload,
+		payload + "." + signature,
+		payload + "." + base64.RawURLEncoding.EncodeToString(sign([]byte("guessed"), data)),
+	}
+	for _, token := range forged {
+		other.pageToken = token
+		if _, err := ParsePageToken(other, testKey); !errors.Is(err, ErrInvalidPageToken) {
+			t.Errorf("Expected ErrInvalidPageToken for %q, got %v", token, err)
+		}
+	}
+
+	// 其他密钥签名的令牌
+	req.pageToken = next
+	if _, err := ParsePageToken(req, []byte("another-secret")); !errors.Is(err, ErrInvalidPageToken) {
+		t.Errorf("Expected ErrInvalidPageToken for another key, got %v", err)
+	}
+
+	// 同时设置偏移量和游标
+	data, _ = json.Marshal(pageToken{Offset: 10, After: "WzFd", Checksum: requestChecksum(req)})
+	req.pageToken = base64.RawURLEncoding.EncodeToString(data) + "." + base64.RawURLEncoding.EncodeToString(sign(testKey, data))
+	if _, err := ParsePageToken(req, testKey); !errors.Is(err, ErrInvalidPageToken) {
+		t.Errorf("Expected ErrInvalidPageToken for offset with cursor, got %v", err)
+	}
+
+	if _, err := ParsePageToken(req, nil); !errors.Is(err, ErrEmptyPageTokenKey) {
+		t.Errorf("Expected ErrEmptyPageTokenKey, got %v", err)
+	}
+	if _, err := (PageToken{Offset: 10}).Encode(); !errors.Is(err, ErrEmptyPageTokenKey) {
+		t.Errorf("Expected ErrEmptyPageTokenKey, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package aip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/epkgs/query/clause"
+)
+
+// PathResolver 将 AIP 过滤中的字段路径（如 "author.address.city"）解析为比较条件的左侧。
+//
+// 返回 clause.Column 时替换比较条件的列名；返回其他表达式（如 JSON 提取的 clause.Raw）时，
+// 比较条件会转换为以该表达式为左侧的原生 SQL 条件。返回 nil 表示不处理该路径。
+type PathResolver func(path string) (clause.Expression, error)
+
+// TablePaths 返回将嵌套路径映射为 JOIN 表列的 PathResolver。
+// tables 的键为路径前缀，值为对应的表名（或别名），按最长前缀匹配，
+// 前缀之后只剩一段时解析为 "表名.列名"。
+//
+// 示例:
+//
+//	aip.TablePaths(map[string]string{"author": "authors", "author.address": "addresses"})
+//	// author.name         -> authors.name
+//	// author.address.city -> addresses.city
+func TablePaths(tables map[string]string) PathResolver {
+	prefixes := make([]string, 0, len(tables))
+	for prefix := range tables {
+		prefixes = append(prefixes, prefix)
+	}
+	// 按长度降序，优先匹配最长前缀
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+
+	return func(path string) (clause.Expression, error) {
+		for _, prefix := range prefixes {
+			if !strings.HasPrefix(path, prefix+".") {
+				continue
+			}
+			column := path[len(prefix)+1:]
+			if strings.Contains(column, ".") {
+				return nil, nil
+			}
+			return clause.Column{Name: tables[prefix] + "." + column}, nil
+		}
+		return nil, nil
+	}
+}
+
+// JSONPaths 返回将 JSON 列中的嵌套路径解析为 JSON 提取表达式的 PathResolver。
+// 路径的第一段为 columns 中的列名时，其余各段作为 JSON 路径交由 extract 生成表达式。
+// JSON 提取语法因数据库而异，由 extract 决定。
+//
+// 示例:
+//
+//	// MySQL: metadata.labels.env -> JSON_UNQUOTE(JSON_EXTRACT(`metadata`, '$.labels.env'))
+//	aip.JSONPaths(func(column string, path []string) clause.Expression {
+//	    return clause.Raw("JSON_UNQUOTE(JSON_EXTRACT(?, ?))", clause.Column{Name: column}, "$."+strings.Join(path, "."))
+//	}, "metadata")
+//
+//	// PostgreSQL: metadata.labels.env -> "metadata" #>> '{labels,env}'
+//	aip.JSONPaths(func(column string, path []string) clause.Expression {
+//	    return clause.Raw("? #>> ?", clause.Column{Name: column}, "{"+strings.Join(path, ",")+"}")
+//	}, "metadata")
+func JSONPaths(extract func(column string, path []string) clause.Expression, columns ...string) PathResolver {
+	jsonColumns := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		jsonColumns[column] = true
+	}
+
+	return func(path string) (clause.Expression, error) {
+		segments := strings.Split(path, ".")
+		if len(segments) < 2 || !jsonColumns[segments[0]] {
+			return nil, nil
+		}
+		return extract(segments[0], segments[1:]), nil
+	}
+}
+
+// resolvePaths 使用 PathResolver 替换 WHERE 条件中比较表达式的字段
+func resolvePaths(where clause.Where, resolvers []PathResolver) (clause.Where, error) {
+	var err error
+	resolved := where.Map(func(e clause.Expression) clause.Expression {
+		cmp, ok := e.(clause.ComparisonExpression)
+		if !ok || err != nil {
+			return e
+		}
+
+		for _, resolver := range resolvers {
+			var lhs clause.Expression
+			lhs, err = resolver(cmp.Column())
+			if err != nil {
+				return e
+			}
+			switch lhs := lhs.(type) {
+			case nil:
+				continue
+			case clause.Column:
+				return clause.WithColumn(cmp, lhs.Name)
+			default:
+				var expr clause.Expression
+				expr, err = withExpression(cmp, lhs)
+				return expr
+			}
+		}
+		return e
+	})
+	if err != nil {
+		return clause.Where{}, err
+	}
+	return resolved, nil
+}
+
+// withExpression 将比较条件转换为以 lhs 为左侧的原生 SQL 条件，
+// 条件的 SQL 由比较表达式自身构建，列替换为 lhs，值作为参数，
+// 以便 GORM/Ent 等适配器按原生 SQL 表达式处理。
+func withExpression(cmp clause.ComparisonExpression, lhs clause.Expression) (clause.Expression, error) {
+	b := &rawBuilder{column: cmp.Column(), lhs: lhs}
+	cmp.Build(b)
+	if b.err != nil {
+		return nil, b.err
+	}
+	return clause.Raw(b.String(), b.vars...), nil
+}
+
+// rawBuilder 将比较表达式构建为带 "?" 占位符的原生 SQL
+type rawBuilder struct {
+	strings.Builder
+	column string
+	lhs    clause.Expression
+	vars   []any
+	err    error
+}
+
+func (b *rawBuilder) WriteQuoted(field interface{}) {
+	if name, ok := field.(string); ok && name == b.column {
+		b.WriteByte('?')
+		b.vars = append(b.vars, b.lhs)
+		return
+	}
+	b.WriteByte('?')
+	b.vars = append(b.vars, clause.Column{Name: fmt.Sprint(field)})
+}
+
+func (b *rawBuilder) AddVar(writer clause.Writer, vars ...interface{}) {
+	for idx, v := range vars {
+		if idx > 0 {
+			writer.WriteByte(',')
+		}
+		writer.WriteByte('?')
+		b.vars = append(b.vars, v)
+	}
+}
+
+func (b *rawBuilder) AddError(err error) error {
+	if b.err == nil {
+		b.err = err
+	}
+	return err
+}
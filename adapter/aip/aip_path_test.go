@@ -0,0 +1,91 @@
+package aip
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epkgs/query/clause"
+	"github.com/epkgs/query/dialect"
+	filtering "go.einride.tech/aip/filtering"
+)
+
+// parseNestedFilter 使用包含嵌套字段的声明解析过滤字符串并转换为 clause.Where
+func parseNestedFilter(t *testing.T, filter string, opts ...Option) clause.Where {
+	declarations, err := filtering.NewDeclarations(
+		filtering.DeclareIdent("author.name", filtering.TypeString),
+		filtering.DeclareIdent("author.address.city", filtering.TypeString),
+		filtering.DeclareIdent("metadata.labels.env", filtering.TypeString),
+		filtering.DeclareIdent("metadata.priority", filtering.TypeInt),
+		filtering.DeclareStandardFunctions(),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create declarations: %v", err)
+	}
+
+	parsed, err := filtering.ParseFilter(mockFilterRequest{filter: filter}, declarations)
+	if err != nil {
+		t.Fatalf("Failed to parse filter: %v", err)
+	}
+
+	where, err := FromFilter(parsed, opts...)
+	if err != nil {
+		t.Fatalf("Failed to convert filter: %v", err)
+	}
+	return where
+}
+
+// TestFromFilter_NestedPath 测试嵌套字段保留完整路径
+func TestFromFilter_NestedPath(t *testing.T) {
+	where := parseNestedFilter(t, "author.address.city = 'Paris'")
+
+	if len(where.Exprs) != 1 {
+		t.Fatalf("Expected 1 expression, got %d", len(where.Exprs))
+	}
+
+	eq, ok := where.Exprs[0].(clause.Eq)
+	if !ok || eq.Col != "author.address.city" {
+		t.Errorf("Expected column 'author.address.city', got %#v", where.Exprs[0])
+	}
+}
+
+// TestFromFilter_TablePaths 测试将嵌套字段映射为 JOIN 表的列
+func TestFromFilter_TablePaths(t *testing.T) {
+	where := parseNestedFilter(t, "author.address.city = 'Paris' AND author.name:'Jo*'",
+		WithPathResolver(TablePaths(map[string]string{"author": "a", "author.address": "addr"})))
+
+	sql, args, err := dialect.Build(dialect.MySQL, where)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := " WHERE `addr`.`city` = ? AND `a`.`name` LIKE ?"
+	if sql != expected {
+		t.Errorf("Expected SQL: %s, got: %s", expected, sql)
+	}
+	if len(args) != 2 || args[1] != "Jo%" {
+		t.Errorf("Unexpected args: %v", args)
+	}
+}
+
+// TestFromFilter_JSONPaths 测试将嵌套字段映射为 JSON 列中的路径
+func TestFromFilter_JSONPaths(t *testing.T) {
+	extract := func(column string, path []string) clause.Expression {
+		return clause.Raw("? #>> ?", clause.Column{Name: column}, "{"+strings.Join(path, ",")+"}")
+	}
+
+	where := parseNestedFilter(t, "metadata.labels.env = 'prod' AND NOT metadata.priority > 3",
+		WithPathResolver(TablePaths(map[string]string{"author": "a"}), JSONPaths(extract, "metadata")))
+
+	sql, args, err := dialect.Build(dialect.PostgreSQL, where)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := ` WHERE "metadata" #>> $1 = $2 AND NOT "metadata" #>> $3 > $4`
+	if sql != expected {
+		t.Errorf("Expected SQL: %s, got: %s", expected, sql)
+	}
+	if len(args) != 4 || args[0] != "{labels,env}" || args[1] != "prod" || args[2] != "{priority}" {
+		t.Errorf("Unexpected args: %v", args)
+	}
+}
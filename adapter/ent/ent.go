@@ -1,6 +1,6 @@
 // Package ent 提供了将 query/clause 查询组件转换为 Ent ORM 查询条件的适配器。
 //
-// 该适配器将 clause.Where、clause.OrderBys、clause.Pagination 转换为
+// 该适配器将 clause.Where、clause.OrderBys、clause.Pagination 等查询组件转换为
 // Ent 的 sql.Selector 修改函数（func(*sql.Selector)），
 // 可直接用于 ent/client 的 Modify 方法或 sql.Selector 操作。
 //
@@ -33,6 +33,8 @@ package ent
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"entgo.io/ent/dialect/sql"
 	"github.com/epkgs/query/clause"
@@ -148,6 +150,129 @@ func sqlOr(pred1, pred2 *sql.Predicate) *sql.Predicate {
 	}
 }
 
+// columnOps 列与列比较时 clause 操作符到 ent 操作符的映射
+var columnOps = map[clause.Operator]sql.Op{
+	clause.OpEQ:  sql.OpEQ,
+	clause.OpNEQ: sql.OpNEQ,
+	clause.OpGT:  sql.OpGT,
+	clause.OpGTE: sql.OpGTE,
+	clause.OpLT:  sql.OpLT,
+	clause.OpLTE: sql.OpLTE,
+}
+
+// column 将带表名限定的列名（如 "users.id"）转换为 ent 可识别的标识符，
+// 不带限定的列名和函数表达式（如 "COUNT(*)"）原样返回。
+func column(name string) string {
+	if strings.ContainsRune(name, '(') {
+		return name
+	}
+	if i := strings.LastIndexByte(name, '.'); i > 0 {
+		return sql.Table(name[:i]).C(name[i+1:])
+	}
+	return name
+}
+
+// value 将比较值转换为 ent 可识别的参数，clause.SubQuery 会被转换为 sql.Querier
+func value(v any) any {
+	if sub, ok := v.(clause.SubQuery); ok {
+		return &querier{expr: sub}
+	}
+	return v
+}
+
+// between 生成 BETWEEN / NOT BETWEEN 条件
+func between(col string, op clause.Operator, from, to any) *sql.Predicate {
+	return sql.P(func(b *sql.Builder) {
+		b.Ident(col).Pad().WriteString(string(op)).Pad().Arg(value(from)).WriteString(" AND ").Arg(value(to))
+	})
+}
+
+// rawP 将原生 SQL 表达式或键集分页条件直接构建为 ent 的条件，作用同 sql.ExprP，
+// 但 "?" 与 "@name" 占位符通过 Builder.Arg 按方言生成（如 PostgreSQL 的 $n）
+func rawP(e clause.Expression) *sql.Predicate {
+	return sql.P(func(b *sql.Builder) {
+		b.Join(&querier{expr: e})
+	})
+}
+
+// querier 将 clause.Expression 包装为 ent 的 sql.Querier。
+// 作为参数嵌入外层查询时，ent 会通过 SetDialect/SetTotal 传入外层的方言与参数计数，
+// 以保证子查询的引用方式和占位符编号与外层查询一致。
+type querier struct {
+	expr    clause.Expression
+	dialect string
+	total   int
+	err     error
+}
+
+func (q *querier) Dialect() string           { return q.dialect }
+func (q *querier) SetDialect(dialect string) { q.dialect = dialect }
+func (q *querier) Total() int                { return q.total }
+func (q *querier) SetTotal(total int)        { q.total = total }
+func (q *querier) Err() error                { return q.err }
+
+func (q *querier) Query() (string, []any) {
+	b := &sql.Builder{}
+	b.SetDialect(q.dialect)
+	b.SetTotal(q.total)
+	q.expr.Build(&builder{b})
+	q.err = b.Err()
+	return b.Query()
+}
+
+// builder 将 ent 的 sql.Builder 适配为 clause.Builder
+type builder struct {
+	b *sql.Builder
+}
+
+func (b *builder) WriteByte(c byte) error {
+	b.b.WriteByte(c)
+	return nil
+}
+
+func (b *builder) WriteString(s string) (int, error) {
+	b.b.WriteString(s)
+	return len(s), nil
+}
+
+func (b *builder) WriteQuoted(field interface{}) {
+	if name, ok := field.(string); ok {
+		b.b.Ident(column(name))
+		return
+	}
+	b.b.Ident(fmt.Sprint(field))
+}
+
+func (b *builder) AddVar(writer clause.Writer, vars ...interface{}) {
+	for idx, v := range vars {
+		if idx > 0 {
+			writer.WriteByte(',')
+		}
+
+		switch v := v.(type) {
+		case clause.Expression:
+			v.Build(b)
+		case []interface{}:
+			if len(v) == 0 {
+				writer.WriteString("(NULL)")
+				continue
+			}
+			writer.WriteByte('(')
+			b.AddVar(writer, v...)
+			writer.WriteByte(')')
+		default:
+			b.b.Arg(v)
+		}
+	}
+}
+
+func (b *builder) AddError(err error) error {
+	if err != nil {
+		b.b.AddError(err)
+	}
+	return err
+}
+
 // convertToEntPredicate 将 query/clause.Expression 转换为 *sql.Predicate
 func convertToEntPredicate(pre *sql.Predicate, expr clause.Expression, opt *options) (*sql.Predicate, error) {
 
@@ -159,27 +284,84 @@ func convertToEntPredicate(pre *sql.Predicate, expr clause.Expression, opt *opti
 		return pre, nil
 	}
 
+	// 列与列之间的比较
+	if e, ok := expr.(clause.ComparisonExpression); ok {
+		if col, ok := e.Value().(clause.Column); ok {
+			op, ok := columnOps[e.Operator()]
+			if !ok {
+				return nil, fmt.Errorf("unsupported column comparison operator: %s", e.Operator())
+			}
+			return sqlAnd(pre, sql.ColumnsOp(column(e.Column()), column(col.Name), op)), nil
+		}
+	}
+
 	switch e := expr.(type) {
 	case clause.Eq:
-		return sqlAnd(pre, sql.EQ(e.Col, e.Val)), nil
+		return sqlAnd(pre, sql.EQ(column(e.Col), value(e.Val))), nil
 	case clause.Neq:
-		return sqlAnd(pre, sql.NEQ(e.Col, e.Val)), nil
+		return sqlAnd(pre, sql.NEQ(column(e.Col), value(e.Val))), nil
 	case clause.Gt:
-		return sqlAnd(pre, sql.GT(e.Col, e.Val)), nil
+		return sqlAnd(pre, sql.GT(column(e.Col), value(e.Val))), nil
 	case clause.Gte:
-		return sqlAnd(pre, sql.GTE(e.Col, e.Val)), nil
+		return sqlAnd(pre, sql.GTE(column(e.Col), value(e.Val))), nil
 	case clause.Lt:
-		return sqlAnd(pre, sql.LT(e.Col, e.Val)), nil
+		return sqlAnd(pre, sql.LT(column(e.Col), value(e.Val))), nil
 	case clause.Lte:
-		return sqlAnd(pre, sql.LTE(e.Col, e.Val)), nil
+		return sqlAnd(pre, sql.LTE(column(e.Col), value(e.Val))), nil
 	case clause.Like:
 		// 将 interface{} 转换为 string
 		if likeValue, ok := e.Val.(string); ok {
-			return sqlAnd(pre, sql.Like(e.Col, likeValue)), nil
+			return sqlAnd(pre, sql.Like(column(e.Col), likeValue)), nil
 		}
 		return nil, errors.New("like value must be string")
 	case clause.IN:
-		return sqlAnd(pre, sql.In(e.Col, e.Vals...)), nil
+		if len(e.Vals) == 1 {
+			if sub, ok := e.Vals[0].(clause.SubQuery); ok {
+				// sql.In 已添加括号，这里只传入子查询本身
+				return sqlAnd(pre, sql.In(column(e.Col), &querier{expr: sub.Expr})), nil
+			}
+		}
+		return sqlAnd(pre, sql.In(column(e.Col), e.Vals...)), nil
+	case clause.NotIn:
+		if len(e.Vals) == 1 {
+			if sub, ok := e.Vals[0].(clause.SubQuery); ok {
+				return sqlAnd(pre, sql.NotIn(column(e.Col), &querier{expr: sub.Expr})), nil
+			}
+		}
+		return sqlAnd(pre, sql.NotIn(column(e.Col), e.Vals...)), nil
+	case clause.Between:
+		return sqlAnd(pre, between(column(e.Col), clause.OpBETWEEN, e.From, e.To)), nil
+	case clause.NotBetween:
+		return sqlAnd(pre, between(column(e.Col), clause.OpNOTBETWEEN, e.From, e.To)), nil
+	case clause.IsNull:
+		return sqlAnd(pre, sql.IsNull(column(e.Col))), nil
+	case clause.IsNotNull:
+		return sqlAnd(pre, sql.NotNull(column(e.Col))), nil
+	case clause.NotLike:
+		if likeValue, ok := e.Val.(string); ok {
+			return sqlAnd(pre, sql.Not(sql.Like(column(e.Col), likeValue))), nil
+		}
+		return nil, errors.New("like value must be string")
+	case clause.ILike:
+		if likeValue, ok := e.Val.(string); ok {
+			col := column(e.Col)
+			return sqlAnd(pre, sql.P(func(b *sql.Builder) {
+				b.WriteString("LOWER(").Ident(col).WriteString(") LIKE LOWER(").Arg(likeValue).WriteByte(')')
+			})), nil
+		}
+		return nil, errors.New("like value must be string")
+	case clause.StartsWith:
+		return sqlAnd(pre, sql.HasPrefix(column(e.Col), fmt.Sprint(e.Val))), nil
+	case clause.EndsWith:
+		return sqlAnd(pre, sql.HasSuffix(column(e.Col), fmt.Sprint(e.Val))), nil
+	case clause.Contains:
+		return sqlAnd(pre, sql.Contains(column(e.Col), fmt.Sprint(e.Val))), nil
+	case clause.Exists:
+		return sqlAnd(pre, sql.Exists(&querier{expr: e.Expr})), nil
+	case clause.Expr:
+		return sqlAnd(pre, rawP(e)), nil
+	case clause.Keyset:
+		return sqlAnd(pre, rawP(e)), nil
 	case clause.LogicalExpression:
 		subExprs := e.SubExprs()
 		if len(subExprs) == 0 {
@@ -231,19 +413,245 @@ func OrderBy(orders clause.OrderBys, opts ...Option) func(s *sql.Selector) {
 				*order = opt.orderHandler(*order)
 			}
 
+			if order.Expr != nil {
+				s.OrderExpr(orderExpr(order.Expr, order.Desc))
+				continue
+			}
+
 			if order.Column == "" {
 				continue
 			}
 
 			if order.Desc {
-				s.OrderBy(sql.Desc(order.Column))
+				s.OrderBy(sql.Desc(column(order.Column)))
 			} else {
-				s.OrderBy(sql.Asc(order.Column))
+				s.OrderBy(sql.Asc(column(order.Column)))
+			}
+		}
+	}
+}
+
+// orderExpr 生成按表达式排序的 ent 排序项
+func orderExpr(expr clause.Expression, desc bool) sql.Querier {
+	return sql.ExprFunc(func(b *sql.Builder) {
+		b.Join(&querier{expr: expr})
+		if desc {
+			b.WriteString(" DESC")
+		} else {
+			b.WriteString(" ASC")
+		}
+	})
+}
+
+// Join 将 clause.Joins 转换为 Ent 的 JOIN 设置函数。
+// ON 条件的转换规则同 Where，支持通过 Option 设置 ExprHandler 进行字段映射。
+// Ent 不支持 CROSS JOIN，会被转换为 "JOIN ... ON 1 = 1"。
+func Join(joins clause.Joins, opts ...Option) func(s *sql.Selector) {
+	opt := &options{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	return func(s *sql.Selector) {
+		for _, j := range joins {
+			// ent 会为没有别名的 JOIN 表自动生成 t1、t2 等别名，
+			// 这里默认使用表名作为别名，以便 ON 条件可以按表名引用列
+			alias := j.Table.Alias
+			if alias == "" {
+				alias = j.Table.Name
+			}
+			table := sql.Table(j.Table.Name).As(alias)
+
+			switch j.Type {
+			case clause.LeftJoin:
+				s.LeftJoin(table)
+			case clause.RightJoin:
+				s.RightJoin(table)
+			default:
+				s.Join(table)
+			}
+
+			if j.Type == clause.CrossJoin {
+				s.OnP(sql.ExprP("1 = 1"))
+				continue
+			}
+
+			pred, err := convertToEntWhere(j.On, opt)
+			if err != nil {
+				s.Builder.AddError(err)
+				return
+			}
+
+			if pred != nil {
+				s.OnP(pred)
+			}
+		}
+	}
+}
+
+// Select 将查询字段表达式列表转换为 Ent 的 SELECT 设置函数。
+// 支持 clause.Column、clause.Aggregate、clause.Alias 和 clause.Expr，其他表达式会被忽略。
+// 没有可转换的字段时保持 Selector 原有的查询字段。
+func Select(fields []clause.Expression) func(s *sql.Selector) {
+	return func(s *sql.Selector) {
+		selection := make([]any, 0, len(fields))
+		for _, f := range fields {
+			if c := selectColumn(f); c != "" {
+				selection = append(selection, c)
+			} else if isRaw(f) {
+				selection = append(selection, &querier{expr: f})
+			}
+		}
+
+		if len(selection) == 0 {
+			return
+		}
+
+		s.Select()
+		for _, v := range selection {
+			switch v := v.(type) {
+			case string:
+				s.AppendSelect(v)
+			case sql.Querier:
+				s.AppendSelectExpr(v)
+			}
+		}
+	}
+}
+
+// isRaw 判断查询字段是否为原生 SQL 表达式（或其别名）
+func isRaw(expr clause.Expression) bool {
+	switch e := expr.(type) {
+	case clause.Expr:
+		return true
+	case clause.Alias:
+		return isRaw(e.Expr)
+	}
+	return false
+}
+
+// selectColumn 将查询字段表达式转换为 ent 的列或函数字符串，无法转换时返回空字符串
+func selectColumn(expr clause.Expression) string {
+	switch e := expr.(type) {
+	case clause.Column:
+		return column(e.Name)
+	case clause.Aggregate:
+		ident := "*"
+		if e.Col != "" && e.Col != "*" {
+			ident = column(e.Col)
+		}
+		if e.Distinct {
+			ident = sql.Distinct(ident)
+		}
+		switch e.Func {
+		case clause.FuncCount:
+			return sql.Count(ident)
+		case clause.FuncSum:
+			return sql.Sum(ident)
+		case clause.FuncAvg:
+			return sql.Avg(ident)
+		case clause.FuncMin:
+			return sql.Min(ident)
+		case clause.FuncMax:
+			return sql.Max(ident)
+		}
+		return fmt.Sprintf("%s(%s)", e.Func, ident)
+	case clause.Alias:
+		inner := selectColumn(e.Expr)
+		if inner == "" || e.Name == "" {
+			return inner
+		}
+		return sql.As(inner, e.Name)
+	}
+	return ""
+}
+
+// GroupBy 将 clause.GroupBy 转换为 Ent 的 GROUP BY 与 HAVING 设置函数。
+//
+// fields 为查询字段表达式，HAVING 中引用的别名会被替换为对应的聚合表达式；
+// HAVING 条件的转换规则同 Where，支持通过 Option 设置 ExprHandler 进行字段映射。
+func GroupBy(groupBy clause.GroupBy, fields []clause.Expression, opts ...Option) func(s *sql.Selector) {
+	opt := &options{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	aliases := clause.Aliases(fields)
+	havingOpt := *opt
+	havingOpt.exprHandler = func(expr clause.Expression) clause.Expression {
+		if opt.exprHandler != nil {
+			expr = opt.exprHandler(expr)
+		}
+		if c, ok := expr.(clause.ComparisonExpression); ok {
+			if alias, ok := aliases[c.Column()]; ok {
+				if col := selectColumn(alias); col != "" {
+					return clause.WithColumn(c, col)
+				}
+			}
+		}
+		return expr
+	}
+
+	return func(s *sql.Selector) {
+		if len(groupBy.Columns) > 0 {
+			columns := make([]string, len(groupBy.Columns))
+			for i, c := range groupBy.Columns {
+				columns[i] = column(c)
 			}
+			s.GroupBy(columns...)
+		}
+
+		pred, err := convertToEntWhere(groupBy.Having, &havingOpt)
+		if err != nil {
+			s.Builder.AddError(err)
+			return
+		}
+
+		if pred != nil {
+			s.Having(pred)
 		}
 	}
 }
 
+// OnConflict 将 clause.OnConflict 转换为 Ent 的冲突处理选项，用于 sql.InsertBuilder.OnConflict。
+// 未设置冲突处理动作时返回 nil。
+//
+// clause.Excluded 会被转换为 UpdateSet.SetExcluded，由 Ent 按方言生成 excluded.column 或 VALUES(column)。
+// 注意 Ent 的 MySQL 方言不支持 DoNothing，可改用 sql.ResolveWithIgnore()。
+//
+// 示例:
+//
+//	q := query.Table("users").Insert(row).OnConflict("email").DoUpdateColumns("name")
+//	sql.Insert("users").Columns("email", "name").Values(email, name).OnConflict(entadapter.OnConflict(q.OnConflictExpr())...)
+func OnConflict(c clause.OnConflict) []sql.ConflictOption {
+	if c.IsEmpty() {
+		return nil
+	}
+
+	var opts []sql.ConflictOption
+	if len(c.Columns) > 0 {
+		opts = append(opts, sql.ConflictColumns(c.Columns...))
+	}
+
+	if c.DoNothing {
+		return append(opts, sql.DoNothing())
+	}
+
+	return append(opts, sql.ResolveWith(func(u *sql.UpdateSet) {
+		for _, assignment := range c.DoUpdates {
+			if excluded, ok := assignment.Value.(clause.Excluded); ok && excluded.Column == assignment.Column {
+				u.SetExcluded(assignment.Column)
+				continue
+			}
+			if expr, ok := assignment.Value.(clause.Expression); ok {
+				u.Set(assignment.Column, &querier{expr: expr})
+				continue
+			}
+			u.Set(assignment.Column, assignment.Value)
+		}
+	}))
+}
+
 // Pagination 将 clause.Pagination 转换为 Ent 的 LIMIT/OFFSET 设置函数。
 func Pagination(pagination clause.Pagination) func(s *sql.Selector) {
 	return func(s *sql.Selector) {
@@ -261,10 +669,40 @@ func Pagination(pagination clause.Pagination) func(s *sql.Selector) {
 // Query 将 WHERE、ORDER BY 和分页三个查询组件一次性转换为 Ent sql.Selector 修改函数。
 // 这是 Where、OrderBy、Pagination 三个函数的便捷组合。
 // 支持通过 Option 设置 ExprHandler/OrderHandler 进行字段映射。
+//
+// 设置了键集分页（pagination.After）时，会根据排序字段生成 Keyset 条件并与 WHERE 条件以 AND 组合。
 func Query(where clause.Where, orders clause.OrderBys, pagination clause.Pagination, opts ...Option) func(s *sql.Selector) {
 	return func(s *sql.Selector) {
 		Where(where, opts...)(s)
+		if keyset := keysetOf(orders, pagination, opts...); keyset != nil {
+			Where(clause.Where{Exprs: []clause.Expression{keyset}}, opts...)(s)
+		}
 		OrderBy(orders, opts...)(s)
 		Pagination(pagination)(s)
 	}
 }
+
+// keysetOf 生成键集分页条件，排序字段按 OrderHandler 映射，且不修改原排序字段
+func keysetOf(orders clause.OrderBys, pagination clause.Pagination, opts ...Option) clause.Expression {
+	if len(pagination.After) == 0 {
+		return nil
+	}
+
+	opt := &options{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	mapped := make(clause.OrderBys, len(orders))
+	for idx, order := range orders {
+		if order == nil {
+			continue
+		}
+		m := *order
+		if opt.orderHandler != nil {
+			m = opt.orderHandler(m)
+		}
+		mapped[idx] = &m
+	}
+	return pagination.Keyset(mapped)
+}
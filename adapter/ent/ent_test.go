@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 	"github.com/epkgs/query"
 	"github.com/epkgs/query/clause"
@@ -759,3 +760,297 @@ func ExampleWhere() {
 	fmt.Printf("Generated SQL with WHERE clause: %v", strings.Contains(sqlStr, "WHERE"))
 	// Output: Generated SQL with WHERE clause: true
 }
+
+// 测试 JOIN 转换
+func TestJoin(t *testing.T) {
+	q := query.Table("users").
+		Join("orders o", query.On("o.user_id", "users.id"), clause.Gt{Col: "o.amount", Val: 100}).
+		LeftJoin("profiles", query.On("profiles.user_id", "users.id")).
+		CrossJoin("regions").
+		Eq("o.status", "paid").
+		Desc("o.amount")
+
+	selector := sql.Select("*").From(sql.Table("users"))
+	Join(q.JoinExpr())(selector)
+	Where(q.WhereExpr())(selector)
+	OrderBy(q.OrderByExpr())(selector)
+
+	sqlStr, args := selector.Query()
+	expectedSQL := "SELECT * FROM `users` JOIN `orders` AS `o` ON `o`.`user_id` = `users`.`id` AND `o`.`amount` > ? LEFT JOIN `profiles` AS `profiles` ON `profiles`.`user_id` = `users`.`id` JOIN `regions` AS `regions` ON 1 = 1 WHERE `o`.`status` = ? ORDER BY `o`.`amount` DESC"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+
+	if !reflect.DeepEqual(args, []any{100, "paid"}) {
+		t.Errorf("Expected args [100 paid], got %v", args)
+	}
+}
+
+// 测试聚合字段、GROUP BY 与 HAVING 转换
+func TestGroupBy(t *testing.T) {
+	q := query.Table("orders").
+		Eq("status", "paid").
+		Select("o.user_id").
+		SelectExpr(clause.As(clause.Sum("amount"), "total"), clause.CountDistinct("product_id")).
+		GroupBy("o.user_id").
+		Having("total", ">", 100)
+
+	selector := sql.Select("*").From(sql.Table("orders").As("o"))
+	Select(q.FieldsExpr())(selector)
+	Where(q.WhereExpr())(selector)
+	GroupBy(q.GroupByExpr(), q.FieldsExpr())(selector)
+
+	sqlStr, args := selector.Query()
+	expectedSQL := "SELECT `o`.`user_id`, SUM(`amount`) AS `total`, COUNT(DISTINCT `product_id`) FROM `orders` AS `o` WHERE `status` = ? GROUP BY `o`.`user_id` HAVING SUM(`amount`) > ?"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+
+	if !reflect.DeepEqual(args, []any{"paid", 100}) {
+		t.Errorf("Expected args [paid 100], got %v", args)
+	}
+}
+
+// 测试子查询与 EXISTS 转换
+func TestSubQuery(t *testing.T) {
+	orders := query.Table("orders o").Where(query.On("o.user_id", "users.id")).Gt("o.amount", 100).Select("o.id")
+	teams := query.Table("teams").Eq("region", "eu").Select("id")
+	maxAge := query.Table("users").Select().SelectExpr(clause.Max("age"))
+
+	q := query.Table("users").
+		Eq("city", "London").
+		Exists(orders).
+		In("team_id", teams).
+		Lt("age", maxAge)
+
+	tests := []struct {
+		name         string
+		dialect      string
+		expectedSQL  string
+		expectedArgs []any
+	}{
+		{
+			name:         "MySQL",
+			dialect:      dialect.MySQL,
+			expectedSQL:  "SELECT * FROM `users` WHERE ((`city` = ? AND EXISTS (SELECT `o`.`id` FROM `orders` AS `o` WHERE `o`.`user_id` = `users`.`id` AND `o`.`amount` > ?)) AND `team_id` IN (SELECT `id` FROM `teams` WHERE `region` = ?)) AND `age` < (SELECT MAX(`age`) FROM `users`)",
+			expectedArgs: []any{"London", 100, "eu"},
+		},
+		{
+			name:         "PostgreSQL",
+			dialect:      dialect.Postgres,
+			expectedSQL:  `SELECT * FROM "users" WHERE (("city" = $1 AND EXISTS (SELECT "o"."id" FROM "orders" AS "o" WHERE "o"."user_id" = "users"."id" AND "o"."amount" > $2)) AND "team_id" IN (SELECT "id" FROM "teams" WHERE "region" = $3)) AND "age" < (SELECT MAX("age") FROM "users")`,
+			expectedArgs: []any{"London", 100, "eu"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := sql.Dialect(tt.dialect).Select("*").From(sql.Table("users"))
+			Where(q.WhereExpr())(selector)
+
+			sqlStr, args := selector.Query()
+			if sqlStr != tt.expectedSQL {
+				t.Errorf("Expected SQL: %s, got: %s", tt.expectedSQL, sqlStr)
+			}
+
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("Expected args %v, got %v", tt.expectedArgs, args)
+			}
+		})
+	}
+}
+
+// 测试扩展比较操作符转换
+func TestExtendedOperators(t *testing.T) {
+	q := query.Table("users").
+		Between("age", 18, 30).
+		NotBetween("id", 100, 200).
+		IsNull("deleted_at").
+		IsNotNull("email").
+		NotIn("status", "banned", "locked").
+		NotLike("name", "test%").
+		ILike("city", "lon%").
+		StartsWith("code", "A_").
+		EndsWith("email", "@example.com").
+		Contains("bio", "go")
+
+	selector := sql.Select("*").From(sql.Table("users"))
+	Where(q.WhereExpr())(selector)
+
+	sqlStr, args := selector.Query()
+	t.Logf("SQL: %s", sqlStr)
+
+	for _, fragment := range []string{
+		"`age` BETWEEN ? AND ?",
+		"`id` NOT BETWEEN ? AND ?",
+		"`deleted_at` IS NULL",
+		"`email` IS NOT NULL",
+		"`status` NOT IN (?, ?)",
+		"NOT (`name` LIKE ?)",
+		"LOWER(`city`) LIKE LOWER(?)",
+		"`code` LIKE ?",
+		"`email` LIKE ?",
+		"`bio` LIKE ?",
+	} {
+		if !strings.Contains(sqlStr, fragment) {
+			t.Errorf("Expected SQL to contain %q, got: %s", fragment, sqlStr)
+		}
+	}
+
+	expectedArgs := []any{18, 30, 100, 200, "banned", "locked", "test%", "lon%", "A\\_%", "%@example.com", "%go%"}
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Errorf("Expected args %v, got %v", expectedArgs, args)
+	}
+}
+
+// 测试原生 SQL 表达式在查询字段、条件和排序中的转换
+func TestRawExpr(t *testing.T) {
+	q := query.Table("users").
+		Eq("city", "London").
+		Where(clause.Raw("LENGTH(name) > ?", 3)).
+		Where(clause.Raw("age BETWEEN @min AND @max", map[string]any{"min": 18, "max": 30})).
+		OrderBy(clause.Raw("CASE WHEN city = ? THEN 0 ELSE 1 END", "Paris"), "desc").
+		Asc("id").
+		Select("id").
+		SelectExpr(clause.As(clause.Raw("UPPER(name)"), "upper_name"))
+
+	tests := []struct {
+		name         string
+		dialect      string
+		expectedSQL  string
+		expectedArgs []any
+	}{
+		{
+			name:         "MySQL",
+			dialect:      dialect.MySQL,
+			expectedSQL:  "SELECT `id`, UPPER(name) AS `upper_name` FROM `users` WHERE (`city` = ? AND LENGTH(name) > ?) AND age BETWEEN ? AND ? ORDER BY CASE WHEN city = ? THEN 0 ELSE 1 END DESC, `id` ASC",
+			expectedArgs: []any{"London", 3, 18, 30, "Paris"},
+		},
+		{
+			name:         "PostgreSQL",
+			dialect:      dialect.Postgres,
+			expectedSQL:  `SELECT "id", UPPER(name) AS "upper_name" FROM "users" WHERE ("city" = $1 AND LENGTH(name) > $2) AND age BETWEEN $3 AND $4 ORDER BY CASE WHEN city = $5 THEN 0 ELSE 1 END DESC, "id" ASC`,
+			expectedArgs: []any{"London", 3, 18, 30, "Paris"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := sql.Dialect(tt.dialect).Select("*").From(sql.Table("users"))
+			Select(q.FieldsExpr())(selector)
+			Where(q.WhereExpr())(selector)
+			OrderBy(q.OrderByExpr())(selector)
+
+			sqlStr, args := selector.Query()
+			if sqlStr != tt.expectedSQL {
+				t.Errorf("Expected SQL: %s, got: %s", tt.expectedSQL, sqlStr)
+			}
+
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("Expected args %v, got %v", tt.expectedArgs, args)
+			}
+		})
+	}
+}
+
+// 测试 INSERT 冲突处理转换
+func TestOnConflict(t *testing.T) {
+	doNothing := query.Table("users").Insert("email", "a@x.com").OnConflict("email").DoNothing()
+	doUpdate := query.Table("users").Insert("email", "a@x.com").OnConflict("email").DoUpdate(map[string]any{
+		"name":  clause.Excluded{Column: "name"},
+		"score": 0,
+	})
+
+	tests := []struct {
+		name         string
+		dialect      string
+		conflict     clause.OnConflict
+		expectedSQL  string
+		expectedArgs []any
+	}{
+		{
+			name:         "PostgreSQL DoNothing",
+			dialect:      dialect.Postgres,
+			conflict:     doNothing.OnConflictExpr(),
+			expectedSQL:  `INSERT INTO "users" ("email", "name") VALUES ($1, $2) ON CONFLICT ("email") DO NOTHING`,
+			expectedArgs: []any{"a@x.com", "A"},
+		},
+		{
+			name:         "PostgreSQL DoUpdate",
+			dialect:      dialect.Postgres,
+			conflict:     doUpdate.OnConflictExpr(),
+			expectedSQL:  `INSERT INTO "users" ("email", "name") VALUES ($1, $2) ON CONFLICT ("email") DO UPDATE SET "name" = "excluded"."name", "score" = $3`,
+			expectedArgs: []any{"a@x.com", "A", 0},
+		},
+		{
+			name:         "MySQL DoUpdate",
+			dialect:      dialect.MySQL,
+			conflict:     doUpdate.OnConflictExpr(),
+			expectedSQL:  "INSERT INTO `users` (`email`, `name`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`), `score` = ?",
+			expectedArgs: []any{"a@x.com", "A", 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlStr, args := sql.Dialect(tt.dialect).
+				Insert("users").
+				Columns("email", "name").
+				Values("a@x.com", "A").
+				OnConflict(OnConflict(tt.conflict)...).
+				Query()
+			if sqlStr != tt.expectedSQL {
+				t.Errorf("Expected SQL: %s, got: %s", tt.expectedSQL, sqlStr)
+			}
+
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("Expected args %v, got %v", tt.expectedArgs, args)
+			}
+		})
+	}
+
+	if opts := OnConflict(clause.OnConflict{}); opts != nil {
+		t.Errorf("Expected nil options, got %v", opts)
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	tests := []struct {
+		name         string
+		dialect      string
+		query        *query.Query
+		expectedSQL  string
+		expectedArgs []any
+	}{
+		{
+			name:         "PostgreSQL compound",
+			dialect:      dialect.Postgres,
+			query:        query.Eq("city", "London").Asc("age").Asc("id").Limit(10).AfterValues(18, 100),
+			expectedSQL:  `SELECT * FROM "users" WHERE "city" = $1 AND ("age", "id") > ($2, $3) ORDER BY "age" ASC, "id" ASC LIMIT 10`,
+			expectedArgs: []any{"London", 18, 100},
+		},
+		{
+			name:         "MySQL mixed directions",
+			dialect:      dialect.MySQL,
+			query:        query.Desc("age").Asc("id").AfterValues(18, 100),
+			expectedSQL:  "SELECT * FROM `users` WHERE (`age` < ? OR (`age` = ? AND `id` > ?)) ORDER BY `age` DESC, `id` ASC",
+			expectedArgs: []any{18, 18, 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := sql.Dialect(tt.dialect).Select("*").From(sql.Table("users"))
+			Query(tt.query.WhereExpr(), tt.query.OrderByExpr(), tt.query.PaginationExpr())(selector)
+
+			sqlStr, args := selector.Query()
+			if sqlStr != tt.expectedSQL {
+				t.Errorf("Expected SQL: %s, got: %s", tt.expectedSQL, sqlStr)
+			}
+
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("Expected args %v, got %v", tt.expectedArgs, args)
+			}
+		})
+	}
+}
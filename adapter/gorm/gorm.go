@@ -2,7 +2,7 @@
 //
 // 该适配器提供两类转换：
 //   - 表达式级转换：WhereExpr、OrderByExpr 将单个表达式转换为 GORM Expression，可传入自定义转换器；
-//   - Scope 级转换：WhereScope、OrderByScope、PaginationScope 将查询组件转换为
+//   - Scope 级转换：SelectScope、JoinScope、WhereScope、GroupByScope、OrderByScope、PaginationScope、OnConflictScope 将查询组件转换为
 //     gorm.DB 的 Scope 函数（func(*gorm.DB) *gorm.DB），可直接用于 db.Scopes() 方法中。
 //
 // 使用方式：
@@ -42,6 +42,9 @@
 package gorm
 
 import (
+	"database/sql"
+	"strings"
+
 	"github.com/epkgs/query/clause"
 	"gorm.io/gorm"
 	gormClause "gorm.io/gorm/clause"
@@ -119,26 +122,19 @@ func convertExpr(expr clause.Expression, convs ...WhereConverter) gormClause.Exp
 			}
 		}
 
-		switch e.Operator() {
-		case clause.OpEQ:
-			return gormClause.Eq{Column: gormClause.Column{Name: e.Column()}, Value: e.Value()}
-		case clause.OpNEQ:
-			return gormClause.Neq{Column: gormClause.Column{Name: e.Column()}, Value: e.Value()}
-		case clause.OpGT:
-			return gormClause.Gt{Column: gormClause.Column{Name: e.Column()}, Value: e.Value()}
-		case clause.OpGTE:
-			return gormClause.Gte{Column: gormClause.Column{Name: e.Column()}, Value: e.Value()}
-		case clause.OpLT:
-			return gormClause.Lt{Column: gormClause.Column{Name: e.Column()}, Value: e.Value()}
-		case clause.OpLTE:
-			return gormClause.Lte{Column: gormClause.Column{Name: e.Column()}, Value: e.Value()}
-		case clause.OpLIKE:
-			return gormClause.Like{Column: gormClause.Column{Name: e.Column()}, Value: e.Value()}
-		case clause.OpIN:
-			return gormClause.IN{Column: gormClause.Column{Name: e.Column()}, Values: e.Value().([]any)}
-		}
+		return convertComparison(e, gormClause.Column{Name: e.Column()})
+	}
 
-		return nil
+	if e, ok := expr.(clause.Exists); ok {
+		return gormClause.Expr{SQL: "EXISTS ?", Vars: []any{expression{clause.SubQuery(e)}}}
+	}
+
+	if e, ok := expr.(clause.Expr); ok {
+		return rawExpr(e)
+	}
+
+	if e, ok := expr.(clause.Keyset); ok {
+		return expression{e}
 	}
 
 	if e, ok := expr.(clause.LogicalExpression); ok {
@@ -166,32 +162,350 @@ func convertExpr(expr clause.Expression, convs ...WhereConverter) gormClause.Exp
 	return nil
 }
 
+// convertComparison 将比较表达式转换为 GORM 表达式，column 为左侧的列或表达式
+func convertComparison(e clause.ComparisonExpression, column any) gormClause.Expression {
+	switch e.Operator() {
+	case clause.OpEQ:
+		return gormClause.Eq{Column: column, Value: convertValue(e.Value())}
+	case clause.OpNEQ:
+		return gormClause.Neq{Column: column, Value: convertValue(e.Value())}
+	case clause.OpGT:
+		return gormClause.Gt{Column: column, Value: convertValue(e.Value())}
+	case clause.OpGTE:
+		return gormClause.Gte{Column: column, Value: convertValue(e.Value())}
+	case clause.OpLT:
+		return gormClause.Lt{Column: column, Value: convertValue(e.Value())}
+	case clause.OpLTE:
+		return gormClause.Lte{Column: column, Value: convertValue(e.Value())}
+	case clause.OpLIKE:
+		return gormClause.Like{Column: column, Value: convertValue(e.Value())}
+	case clause.OpIN:
+		values := e.Value().([]any)
+		if len(values) == 1 {
+			if sub, ok := values[0].(clause.SubQuery); ok {
+				return gormClause.Expr{SQL: "? IN ?", Vars: []any{column, expression{sub}}}
+			}
+		}
+		return gormClause.IN{Column: column, Values: values}
+	case clause.OpNOTIN:
+		values := e.Value().([]any)
+		if len(values) == 1 {
+			if sub, ok := values[0].(clause.SubQuery); ok {
+				return gormClause.Expr{SQL: "? NOT IN ?", Vars: []any{column, expression{sub}}}
+			}
+		}
+		return gormClause.Not(gormClause.IN{Column: column, Values: values})
+	case clause.OpBETWEEN, clause.OpNOTBETWEEN:
+		bounds := e.Value().([]any)
+		return gormClause.Expr{
+			SQL:  "? " + string(e.Operator()) + " ? AND ?",
+			Vars: []any{column, convertValue(bounds[0]), convertValue(bounds[1])},
+		}
+	case clause.OpISNULL:
+		return gormClause.Eq{Column: column, Value: nil}
+	case clause.OpISNOTNULL:
+		return gormClause.Neq{Column: column, Value: nil}
+	case clause.OpNOTLIKE:
+		return gormClause.Not(gormClause.Like{Column: column, Value: convertValue(e.Value())})
+	case clause.OpILIKE:
+		return gormClause.Expr{SQL: "LOWER(?) LIKE LOWER(?)", Vars: []any{column, convertValue(e.Value())}}
+	case clause.OpSTARTSWITH:
+		return escapedLike(column, clause.LikePattern("", e.Value(), "%"))
+	case clause.OpENDSWITH:
+		return escapedLike(column, clause.LikePattern("%", e.Value(), ""))
+	case clause.OpCONTAINS:
+		return escapedLike(column, clause.LikePattern("%", e.Value(), "%"))
+	}
+
+	return nil
+}
+
+// escapedLike 生成带 ESCAPE 子句的 LIKE 表达式，pattern 需已按 clause.EscapeLike 转义
+func escapedLike(column any, pattern string) gormClause.Expression {
+	return gormClause.Expr{SQL: "? LIKE ? ESCAPE '!'", Vars: []any{column, pattern}}
+}
+
+// convertValue 将比较值转换为 GORM 可识别的值，
+// clause.Column 会被转换为 gormClause.Column，以生成列与列之间的比较；
+// clause.SubQuery 会在 GORM 的 Builder 中构建，参数与外层查询合并。
+func convertValue(value any) any {
+	switch v := value.(type) {
+	case clause.Column:
+		return gormClause.Column{Name: v.Name}
+	case clause.SubQuery:
+		return expression{v}
+	}
+	return value
+}
+
+// rawExpr 将原生 SQL 表达式转换为 GORM 的 Expr，含命名参数时转换为 NamedExpr。
+// GORM 不支持 "??" 转义，也会替换引号内的 "?"，此时改为在 GORM 的 Builder 中按 clause.Expr 的规则构建，
+// 并加括号保持优先级。
+func rawExpr(e clause.Expr) gormClause.Expression {
+	if strings.Contains(e.SQL, "??") || strings.ContainsAny(e.SQL, "'\"`") {
+		return gormClause.Expr{SQL: "(?)", Vars: []any{expression{e}}}
+	}
+
+	vars := make([]any, len(e.Vars))
+	for idx, v := range e.Vars {
+		vars[idx] = rawVar(v)
+	}
+	if e.NamedVars() != nil {
+		return gormClause.NamedExpr{SQL: e.SQL, Vars: vars}
+	}
+	return gormClause.Expr{SQL: e.SQL, Vars: vars}
+}
+
+// rawVar 转换原生 SQL 表达式的参数，其中的 clause.Expression 在 GORM 的 Builder 中构建
+func rawVar(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		named := make(map[string]any, len(v))
+		for name, val := range v {
+			named[name] = rawVar(val)
+		}
+		return named
+	case sql.NamedArg:
+		v.Value = rawVar(v.Value)
+		return v
+	case clause.Column, clause.SubQuery:
+		return convertValue(v)
+	case clause.Expression:
+		return expression{v}
+	}
+	return v
+}
+
+// expression 将 clause.Expression 包装为 GORM 的 Expression，
+// 构建时直接写入 GORM 的 Statement，使用其方言的引用与占位符
+type expression struct {
+	clause.Expression
+}
+
+func (e expression) Build(b gormClause.Builder) {
+	e.Expression.Build(builder{b})
+}
+
+// builder 将 GORM 的 Builder 适配为 clause.Builder
+type builder struct {
+	gormClause.Builder
+}
+
+// BuildKeyset 构建键集分页条件，SQL Server 不支持行值比较，展开为 OR 条件
+func (b builder) BuildKeyset(k clause.Keyset) {
+	if stmt, ok := b.Builder.(*gorm.Statement); ok && stmt.Dialector != nil && stmt.Dialector.Name() == "sqlserver" {
+		k.BuildExpanded(b)
+		return
+	}
+	if k.IsCompound() {
+		k.BuildCompound(b)
+	} else {
+		k.BuildExpanded(b)
+	}
+}
+
+func (b builder) AddVar(writer clause.Writer, vars ...interface{}) {
+	for idx, v := range vars {
+		if idx > 0 {
+			writer.WriteByte(',')
+		}
+
+		if e, ok := v.(clause.Expression); ok {
+			e.Build(b)
+			continue
+		}
+
+		b.Builder.AddVar(writer, v)
+	}
+}
+
+// JoinExprs 将 clause.Joins 转换为 GORM 的 Join 列表。
+// ON 条件的转换规则同 WhereExprs，可传入 WhereConverter 进行自定义转换。
+func JoinExprs(joins clause.Joins, convs ...WhereConverter) []gormClause.Join {
+	gormJoins := make([]gormClause.Join, 0, len(joins))
+
+	for _, j := range joins {
+		gormJoin := gormClause.Join{
+			Type:  gormClause.JoinType(j.Type),
+			Table: gormClause.Table{Name: j.Table.Name, Alias: j.Table.Alias},
+		}
+
+		if j.Type != clause.CrossJoin {
+			if exprs := WhereExprs(j.On.Exprs, convs...); len(exprs) > 0 {
+				gormJoin.ON = gormClause.Where{Exprs: exprs}
+			}
+		}
+
+		gormJoins = append(gormJoins, gormJoin)
+	}
+
+	return gormJoins
+}
+
+// JoinScope 将 clause.Joins 转换为 GORM Scope 函数，用于添加 JOIN 子句。
+// 生成的 JOIN 会追加到已有的 FROM 子句中。
+//
+// 可传入 WhereConverter 对 ON 条件中的表达式进行自定义转换。
+func JoinScope(joins clause.Joins, convs ...WhereConverter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		gormJoins := JoinExprs(joins, convs...)
+		if len(gormJoins) == 0 {
+			return db
+		}
+
+		from := gormClause.From{}
+		if c, ok := db.Statement.Clauses[from.Name()]; ok {
+			if f, ok := c.Expression.(gormClause.From); ok {
+				from = f
+			}
+		}
+		from.Joins = append(from.Joins, gormJoins...)
+
+		db.Statement.AddClause(from)
+		return db
+	}
+}
+
+// SelectExpr 将单个查询字段表达式转换为 GORM 的 Expression。
+// 支持 clause.Column、clause.Aggregate、clause.Alias 和 clause.Expr，其他表达式返回 nil。
+func SelectExpr(expr clause.Expression) gormClause.Expression {
+	switch e := expr.(type) {
+	case clause.Column:
+		return gormClause.Expr{SQL: "?", Vars: []any{gormClause.Column{Name: e.Name}}}
+	case clause.Aggregate:
+		var distinct string
+		if e.Distinct {
+			distinct = "DISTINCT "
+		}
+		if e.Col == "" || e.Col == "*" {
+			return gormClause.Expr{SQL: string(e.Func) + "(" + distinct + "*)"}
+		}
+		return gormClause.Expr{SQL: string(e.Func) + "(" + distinct + "?)", Vars: []any{gormClause.Column{Name: e.Col}}}
+	case clause.Alias:
+		inner := SelectExpr(e.Expr)
+		if inner == nil {
+			return nil
+		}
+		if e.Name == "" {
+			return inner
+		}
+		return gormClause.Expr{SQL: "? AS ?", Vars: []any{inner, gormClause.Column{Name: e.Name}}}
+	case clause.Expr:
+		return rawExpr(e)
+	}
+	return nil
+}
+
+// SelectScope 将查询字段表达式列表转换为 GORM Scope 函数，用于设置 SELECT 子句。
+// 如果没有可转换的字段，返回空操作的 Scope。
+//
+// 示例:
+//
+//	q := query.Table("orders").Select("user_id").SelectExpr(clause.As(clause.Sum("amount"), "total"))
+//	db.Scopes(gormadapter.SelectScope(q.FieldsExpr())).Find(&results)
+func SelectScope(fields []clause.Expression) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		exprs := make([]gormClause.Expression, 0, len(fields))
+		for _, f := range fields {
+			if e := SelectExpr(f); e != nil {
+				exprs = append(exprs, e)
+			}
+		}
+
+		if len(exprs) == 0 {
+			return db
+		}
+
+		db.Statement.AddClause(gormClause.Select{Expression: gormClause.CommaExpression{Exprs: exprs}})
+		return db
+	}
+}
+
+// GroupByScope 将 clause.GroupBy 转换为 GORM Scope 函数，用于设置 GROUP BY 和 HAVING 子句。
+//
+// fields 为查询字段表达式，HAVING 中引用的别名会被替换为对应的聚合表达式；
+// 可传入 WhereConverter 对 HAVING 中的表达式进行自定义转换。
+func GroupByScope(groupBy clause.GroupBy, fields []clause.Expression, convs ...WhereConverter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(groupBy.Columns) == 0 && len(groupBy.Having.Exprs) == 0 {
+			return db
+		}
+
+		gormGroupBy := gormClause.GroupBy{}
+		for _, column := range groupBy.Columns {
+			gormGroupBy.Columns = append(gormGroupBy.Columns, gormClause.Column{Name: column})
+		}
+
+		if aliases := clause.Aliases(fields); len(aliases) > 0 {
+			convs = append([]WhereConverter{aliasConverter(aliases)}, convs...)
+		}
+		gormGroupBy.Having = WhereExprs(groupBy.Having.Exprs, convs...)
+
+		db.Statement.AddClause(gormGroupBy)
+		return db
+	}
+}
+
+// aliasConverter 将引用别名的比较表达式转换为以原表达式作为左侧的比较
+func aliasConverter(aliases map[string]clause.Expression) WhereConverter {
+	return func(e clause.Expression) (gormClause.Expression, bool) {
+		c, ok := e.(clause.ComparisonExpression)
+		if !ok {
+			return nil, false
+		}
+
+		expr, ok := aliases[c.Column()]
+		if !ok {
+			return nil, false
+		}
+
+		column := SelectExpr(expr)
+		if column == nil {
+			return nil, false
+		}
+
+		return convertComparison(c, column), true
+	}
+}
+
 // OrderByExpr 将单个 clause.OrderBy 转换为 GORM 的 OrderByColumn。
 //
 // 可传入 OrderByConverter 对特定排序条件进行自定义转换；
 // 转换器按顺序执行，第一个返回 converted=true 的转换器结果即为最终结果，
 // 若所有转换器均未转换，则使用默认逻辑处理。
+//
+// 按表达式排序时，clause.Expr 的 SQL 作为原始列名输出，无法绑定参数；
+// 带参数的表达式请使用 OrderByScope。
 func OrderByExpr(order clause.OrderBy, convs ...OrderByConverter) gormClause.OrderByColumn {
-	var col gormClause.OrderByColumn
-	var converted bool
-	for _, conv := range convs {
-		col, converted = conv(order)
-		if converted {
-			break
-		}
+	if col, converted := convertOrderBy(order, convs...); converted {
+		return col
 	}
-	if !converted {
-		col = gormClause.OrderByColumn{
-			Column: gormClause.Column{Name: order.Column},
+
+	if e, ok := order.Expr.(clause.Expr); ok {
+		return gormClause.OrderByColumn{
+			Column: gormClause.Column{Name: e.SQL, Raw: true},
 			Desc:   order.Desc,
 		}
 	}
 
-	return col
+	return gormClause.OrderByColumn{
+		Column: gormClause.Column{Name: order.Column},
+		Desc:   order.Desc,
+	}
+}
+
+// convertOrderBy 依次执行自定义转换器，返回第一个转换成功的结果
+func convertOrderBy(order clause.OrderBy, convs ...OrderByConverter) (gormClause.OrderByColumn, bool) {
+	for _, conv := range convs {
+		if col, converted := conv(order); converted {
+			return col, true
+		}
+	}
+	return gormClause.OrderByColumn{}, false
 }
 
 // OrderByExprs 批量将 clause.OrderBys 转换为 GORM 的 OrderByColumn 列表。
-// 转换过程中会跳过 nil 以及列名为空的表达式。
+// 转换过程中会跳过 nil 以及列名和表达式均为空的排序条件。
 //
 // 可传入 OrderByConverter 对特定排序条件进行自定义转换，规则同 OrderByExpr。
 func OrderByExprs(orders clause.OrderBys, convs ...OrderByConverter) []gormClause.OrderByColumn {
@@ -200,7 +514,7 @@ func OrderByExprs(orders clause.OrderBys, convs ...OrderByConverter) []gormClaus
 		if order == nil {
 			continue
 		}
-		if order.Column == "" {
+		if order.Column == "" && order.Expr == nil {
 			continue
 		}
 		cols = append(cols, OrderByExpr(*order, convs...))
@@ -210,11 +524,23 @@ func OrderByExprs(orders clause.OrderBys, convs ...OrderByConverter) []gormClaus
 }
 
 // OrderByScope 将 clause.OrderBys 转换为 GORM Scope 函数，用于设置排序条件。
+// 包含表达式排序时，整个 ORDER BY 以 GORM Expression 构建，以便绑定表达式参数。
 //
 // 可传入 OrderByConverter 对特定排序条件进行自定义转换。
 func OrderByScope(orders clause.OrderBys, convs ...OrderByConverter) func(db *gorm.DB) *gorm.DB {
 
 	return func(db *gorm.DB) *gorm.DB {
+		if hasOrderByExpr(orders) {
+			exprs := make([]gormClause.Expression, 0, len(orders))
+			for _, order := range orders {
+				if order == nil || order.Column == "" && order.Expr == nil {
+					continue
+				}
+				exprs = append(exprs, orderByExpression(*order, convs...))
+			}
+			return db.Order(gormClause.OrderBy{Expression: gormClause.CommaExpression{Exprs: exprs}})
+		}
+
 		cols := OrderByExprs(orders, convs...)
 
 		if len(cols) > 0 {
@@ -225,6 +551,72 @@ func OrderByScope(orders clause.OrderBys, convs ...OrderByConverter) func(db *go
 	}
 }
 
+// hasOrderByExpr 判断排序条件中是否包含表达式排序
+func hasOrderByExpr(orders clause.OrderBys) bool {
+	for _, order := range orders {
+		if order != nil && order.Expr != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// orderByExpression 将单个排序条件转换为 GORM Expression，表达式的参数在 GORM 的 Builder 中绑定
+func orderByExpression(order clause.OrderBy, convs ...OrderByConverter) gormClause.Expression {
+	var v any
+	desc := order.Desc
+	if col, converted := convertOrderBy(order, convs...); converted {
+		v, desc = col.Column, col.Desc
+	} else if e, ok := order.Expr.(clause.Expr); ok {
+		v = rawExpr(e)
+	} else if order.Expr != nil {
+		v = expression{order.Expr}
+	} else {
+		v = gormClause.Column{Name: order.Column}
+	}
+
+	if desc {
+		return gormClause.Expr{SQL: "? DESC", Vars: []any{v}}
+	}
+	return gormClause.Expr{SQL: "?", Vars: []any{v}}
+}
+
+// OnConflictExpr 将 clause.OnConflict 转换为 GORM 的 OnConflict 子句。
+// clause.Excluded 会被转换为 excluded 表的列，由 GORM 的方言生成对应语法（如 MySQL 的 VALUES(column)）。
+func OnConflictExpr(c clause.OnConflict) gormClause.OnConflict {
+	onConflict := gormClause.OnConflict{DoNothing: c.DoNothing}
+	for _, column := range c.Columns {
+		onConflict.Columns = append(onConflict.Columns, gormClause.Column{Name: column})
+	}
+	for _, assignment := range c.DoUpdates {
+		value := rawVar(assignment.Value)
+		if excluded, ok := assignment.Value.(clause.Excluded); ok {
+			value = gormClause.Column{Table: "excluded", Name: excluded.Column}
+		}
+		onConflict.DoUpdates = append(onConflict.DoUpdates, gormClause.Assignment{
+			Column: gormClause.Column{Name: assignment.Column},
+			Value:  value,
+		})
+	}
+	return onConflict
+}
+
+// OnConflictScope 将 clause.OnConflict 转换为 GORM Scope 函数，用于 Create 时处理冲突。
+// 未设置冲突处理动作时返回空操作的 Scope。
+//
+// 示例:
+//
+//	q := query.Table("users").Insert(row).OnConflict("email").DoUpdateColumns("name")
+//	db.Table("users").Scopes(gormadapter.OnConflictScope(q.OnConflictExpr())).Create(row)
+func OnConflictScope(c clause.OnConflict) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if c.IsEmpty() {
+			return db
+		}
+		return db.Clauses(OnConflictExpr(c))
+	}
+}
+
 // PaginationScope 将 clause.Pagination 转换为 GORM Scope 函数，用于设置 LIMIT 和 OFFSET。
 func PaginationScope(pagination clause.Pagination) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
@@ -243,9 +635,14 @@ func PaginationScope(pagination clause.Pagination) func(db *gorm.DB) *gorm.DB {
 
 // QueryScope 将 WHERE、ORDER BY 和分页三个查询组件一次性转换为 GORM Scope 函数。
 // 这是 WhereScope、OrderByScope、PaginationScope 三个函数的便捷组合。
+//
+// 设置了键集分页（pagination.After）时，会根据排序字段生成 Keyset 条件并与 WHERE 条件以 AND 组合。
 func QueryScope(where clause.Where, orders clause.OrderBys, pagination clause.Pagination) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		db = WhereScope(where)(db)
+		if keyset := pagination.Keyset(orders); keyset != nil {
+			db = WhereScope(clause.Where{Exprs: []clause.Expression{keyset}})(db)
+		}
 		db = OrderByScope(orders)(db)
 		db = PaginationScope(pagination)(db)
 		return db
@@ -692,3 +692,258 @@ func ExampleWhere() {
 	fmt.Printf("SQL generated successfully")
 	// Output: SQL generated successfully
 }
+
+// 测试 JOIN 转换
+func TestJoinScope(t *testing.T) {
+	q := query.Table("users").
+		LeftJoin("orders o", query.On("o.user_id", "users.id"), clause.Gt{Col: "o.amount", Val: 100}).
+		CrossJoin("regions").
+		Eq("o.status", "paid")
+
+	db := getTestDB(t)
+	stmt := db.Model(&User{}).
+		Scopes(JoinScope(q.JoinExpr()), WhereScope(q.WhereExpr())).
+		Find(&[]User{}).Statement
+
+	sql := stmt.SQL.String()
+	t.Logf("SQL: %s", sql)
+
+	expected := "SELECT `users`.`id`,`users`.`name`,`users`.`age`,`users`.`city` FROM `users` LEFT JOIN `orders` `o` ON `o`.`user_id` = `users`.`id` AND `o`.`amount` > ? CROSS JOIN `regions` WHERE `o`.`status` = ?"
+	if sql != expected {
+		t.Errorf("Expected SQL: %s, got: %s", expected, sql)
+	}
+
+	if !reflect.DeepEqual(stmt.Vars, []any{100, "paid"}) {
+		t.Errorf("Expected vars [100 paid], got %v", stmt.Vars)
+	}
+}
+
+// 测试空 JOIN
+func TestJoinScope_Empty(t *testing.T) {
+	db := getTestDB(t)
+	stmt := db.Model(&User{}).Scopes(JoinScope(nil)).Find(&[]User{}).Statement
+
+	if strings.Contains(stmt.SQL.String(), "JOIN") {
+		t.Errorf("Expected no JOIN clause, got: %s", stmt.SQL.String())
+	}
+}
+
+// 测试聚合字段、GROUP BY 与 HAVING 转换
+func TestGroupByScope(t *testing.T) {
+	q := query.Table("users").
+		Gt("age", 18).
+		Select("city").
+		SelectExpr(clause.As(clause.Count("*"), "cnt"), clause.Max("age")).
+		GroupBy("city").
+		Having("cnt", ">", 2)
+
+	db := getTestDB(t)
+	stmt := db.Model(&User{}).
+		Scopes(
+			SelectScope(q.FieldsExpr()),
+			WhereScope(q.WhereExpr()),
+			GroupByScope(q.GroupByExpr(), q.FieldsExpr()),
+		).
+		Find(&[]map[string]any{}).Statement
+
+	sql := stmt.SQL.String()
+	t.Logf("SQL: %s", sql)
+
+	expected := "SELECT `city`, COUNT(*) AS `cnt`, MAX(`age`) FROM `users` WHERE `age` > ? GROUP BY `city` HAVING COUNT(*) > ?"
+	if sql != expected {
+		t.Errorf("Expected SQL: %s, got: %s", expected, sql)
+	}
+
+	if !reflect.DeepEqual(stmt.Vars, []any{18, 2}) {
+		t.Errorf("Expected vars [18 2], got %v", stmt.Vars)
+	}
+}
+
+// 测试空 GROUP BY 与空 SELECT
+func TestGroupByScope_Empty(t *testing.T) {
+	db := getTestDB(t)
+	stmt := db.Model(&User{}).
+		Scopes(SelectScope(nil), GroupByScope(clause.GroupBy{}, nil)).
+		Find(&[]User{}).Statement
+
+	sql := stmt.SQL.String()
+	if strings.Contains(sql, "GROUP BY") || !strings.HasPrefix(sql, "SELECT * ") {
+		t.Errorf("Expected default SELECT without GROUP BY, got: %s", sql)
+	}
+}
+
+// 测试子查询与 EXISTS 转换
+func TestSubQueryWhereScope(t *testing.T) {
+	orders := query.Table("orders o").Where(query.On("o.user_id", "users.id")).Gt("o.amount", 100).Select("o.id")
+	teams := query.Table("teams").Eq("region", "eu").Select("id")
+
+	q := query.Table("users").
+		Eq("city", "London").
+		Exists(orders).
+		In("team_id", teams).
+		Not(query.Exists(orders))
+
+	db := getTestDB(t)
+	stmt := db.Model(&User{}).Scopes(WhereScope(q.WhereExpr())).Find(&[]User{}).Statement
+
+	sql := stmt.SQL.String()
+	t.Logf("SQL: %s", sql)
+
+	expected := "SELECT * FROM `users` WHERE `city` = ? AND EXISTS (SELECT `o`.`id` FROM `orders` AS `o` WHERE `o`.`user_id` = `users`.`id` AND `o`.`amount` > ?) AND `team_id` IN (SELECT `id` FROM `teams` WHERE `region` = ?) AND NOT EXISTS (SELECT `o`.`id` FROM `orders` AS `o` WHERE `o`.`user_id` = `users`.`id` AND `o`.`amount` > ?)"
+	if sql != expected {
+		t.Errorf("Expected SQL: %s, got: %s", expected, sql)
+	}
+
+	if !reflect.DeepEqual(stmt.Vars, []any{"London", 100, "eu", 100}) {
+		t.Errorf("Expected vars [London 100 eu 100], got %v", stmt.Vars)
+	}
+}
+
+// 测试扩展比较操作符转换
+func TestExtendedOperators(t *testing.T) {
+	q := query.Table("users").
+		Between("age", 18, 30).
+		IsNull("city").
+		Not(query.IsNotNull("name")).
+		NotIn("id", 1, 2).
+		NotLike("name", "test%").
+		ILike("city", "lon%").
+		StartsWith("name", "50%").
+		Not(query.Contains("city", "x")).
+		NotBetween("id", 100, 200)
+
+	db := getTestDB(t)
+	stmt := db.Model(&User{}).Scopes(WhereScope(q.WhereExpr())).Find(&[]User{}).Statement
+
+	sql := stmt.SQL.String()
+	t.Logf("SQL: %s", sql)
+
+	expected := "SELECT * FROM `users` WHERE (`age` BETWEEN ? AND ?) AND `city` IS NULL AND `name` IS NULL AND `id` NOT IN (?,?) AND `name` NOT LIKE ? AND LOWER(`city`) LIKE LOWER(?) AND `name` LIKE ? ESCAPE '!' AND NOT `city` LIKE ? ESCAPE '!' AND (`id` NOT BETWEEN ? AND ?)"
+	if sql != expected {
+		t.Errorf("Expected SQL: %s, got: %s", expected, sql)
+	}
+
+	expectedVars := []any{18, 30, 1, 2, "test%", "lon%", "50!%%", "%x%", 100, 200}
+	if !reflect.DeepEqual(stmt.Vars, expectedVars) {
+		t.Errorf("Expected vars %v, got %v", expectedVars, stmt.Vars)
+	}
+}
+
+// 测试原生 SQL 表达式在查询字段、条件和排序中的转换
+func TestRawExprScopes(t *testing.T) {
+	q := query.Table("users").
+		Eq("city", "London").
+		Where(clause.Raw("LENGTH(name) > ?", 3)).
+		Where(clause.Raw("age BETWEEN @min AND @max", map[string]any{"min": 18, "max": 30})).
+		Where(clause.Raw("data ?? ? OR name <> 'who?'", "vip")).
+		OrderBy(clause.Raw("CASE WHEN city = ? THEN 0 ELSE 1 END", "Paris")).
+		Desc("id").
+		Select("id").
+		SelectExpr(clause.As(clause.Raw("UPPER(name)"), "upper_name"))
+
+	db := getTestDB(t)
+	stmt := db.Model(&User{}).
+		Scopes(
+			SelectScope(q.FieldsExpr()),
+			WhereScope(q.WhereExpr()),
+			OrderByScope(q.OrderByExpr()),
+		).
+		Find(&[]map[string]any{}).Statement
+
+	sql := stmt.SQL.String()
+	t.Logf("SQL: %s", sql)
+
+	expected := "SELECT `id`, UPPER(name) AS `upper_name` FROM `users` WHERE `city` = ? AND LENGTH(name) > ? AND (age BETWEEN ? AND ?) AND (data ? ? OR name <> 'who?') ORDER BY CASE WHEN city = ? THEN 0 ELSE 1 END, `id` DESC"
+	if sql != expected {
+		t.Errorf("Expected SQL: %s, got: %s", expected, sql)
+	}
+
+	expectedVars := []any{"London", 3, 18, 30, "vip", "Paris"}
+	if !reflect.DeepEqual(stmt.Vars, expectedVars) {
+		t.Errorf("Expected vars %v, got %v", expectedVars, stmt.Vars)
+	}
+}
+
+// 测试 INSERT 冲突处理转换
+func TestOnConflictScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		conflict clause.OnConflict
+		expected string
+		vars     []any
+	}{
+		{
+			name:     "DoNothing",
+			conflict: query.Table("users").Insert("name", "John").OnConflict("name").DoNothing().OnConflictExpr(),
+			expected: "INSERT INTO `users` (`name`,`age`,`city`) VALUES (?,?,?) ON CONFLICT (`name`) DO NOTHING RETURNING `id`",
+			vars:     []any{"John", 30, "London"},
+		},
+		{
+			name:     "DoUpdate",
+			conflict: query.Table("users").Insert("name", "John").OnConflict("name").DoUpdate(map[string]any{"age": clause.Excluded{Column: "age"}, "city": "Paris"}).OnConflictExpr(),
+			expected: "INSERT INTO `users` (`name`,`age`,`city`) VALUES (?,?,?) ON CONFLICT (`name`) DO UPDATE SET `age`=`excluded`.`age`,`city`=? RETURNING `id`",
+			vars:     []any{"John", 30, "London", "Paris"},
+		},
+		{
+			name:     "Empty",
+			conflict: clause.OnConflict{},
+			expected: "INSERT INTO `users` (`name`,`age`,`city`) VALUES (?,?,?) RETURNING `id`",
+			vars:     []any{"John", 30, "London"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := getTestDB(t)
+			stmt := db.Scopes(OnConflictScope(tt.conflict)).Create(&User{Name: "John", Age: 30, City: "London"}).Statement
+
+			sql := stmt.SQL.String()
+			if sql != tt.expected {
+				t.Errorf("Expected SQL: %s, got: %s", tt.expected, sql)
+			}
+
+			if !reflect.DeepEqual(stmt.Vars, tt.vars) {
+				t.Errorf("Expected vars %v, got %v", tt.vars, stmt.Vars)
+			}
+		})
+	}
+}
+
+func TestKeysetScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *query.Query
+		expected string
+		vars     []any
+	}{
+		{
+			name:     "Compound",
+			query:    query.Eq("city", "London").Asc("age").Asc("id").Limit(10).AfterValues(18, 100),
+			expected: "SELECT * FROM `users` WHERE `city` = ? AND (`age`, `id`) > (?, ?) ORDER BY `age`,`id` LIMIT 10",
+			vars:     []any{"London", 18, 100},
+		},
+		{
+			name:     "Mixed directions",
+			query:    query.Desc("age").Asc("id").AfterValues(18, 100),
+			expected: "SELECT * FROM `users` WHERE (`age` < ? OR (`age` = ? AND `id` > ?)) ORDER BY `age` DESC,`id`",
+			vars:     []any{18, 18, 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := getTestDB(t)
+			scope := QueryScope(tt.query.WhereExpr(), tt.query.OrderByExpr(), tt.query.PaginationExpr())
+			stmt := db.Model(&User{}).Scopes(scope).Find(&[]User{}).Statement
+
+			sql := stmt.SQL.String()
+			if sql != tt.expected {
+				t.Errorf("Expected SQL: %s, got: %s", tt.expected, sql)
+			}
+
+			if !reflect.DeepEqual(stmt.Vars, tt.vars) {
+				t.Errorf("Expected vars %v, got %v", tt.vars, stmt.Vars)
+			}
+		})
+	}
+}
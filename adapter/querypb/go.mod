@@ -0,0 +1,10 @@
+module github.com/epkgs/query/adapter/querypb
+
+go 1.23.0
+
+require (
+	github.com/epkgs/query v0.0.0-00010101000000-000000000000
+	google.golang.org/protobuf v1.36.6
+)
+
+replace github.com/epkgs/query => ../../
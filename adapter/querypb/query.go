@@ -0,0 +1,402 @@
+package querypb
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/epkgs/query"
+	"github.com/epkgs/query/clause"
+)
+
+// 支持的聚合函数，还原时拒绝其他函数名，避免写入任意 SQL
+var aggregateFuncs = map[string]clause.AggregateFunc{
+	string(clause.FuncCount): clause.FuncCount,
+	string(clause.FuncSum):   clause.FuncSum,
+	string(clause.FuncAvg):   clause.FuncAvg,
+	string(clause.FuncMin):   clause.FuncMin,
+	string(clause.FuncMax):   clause.FuncMax,
+}
+
+// JOIN 类型与 Protobuf 枚举的对应关系
+var joinTypes = map[clause.JoinType]JoinType{
+	clause.InnerJoin: JoinType_JOIN_TYPE_INNER,
+	clause.LeftJoin:  JoinType_JOIN_TYPE_LEFT,
+	clause.RightJoin: JoinType_JOIN_TYPE_RIGHT,
+	clause.CrossJoin: JoinType_JOIN_TYPE_CROSS,
+}
+
+// ToSelect 将 SELECT 查询转换为 Protobuf 消息，查询构建过程中记录的错误原样返回
+func ToSelect(q *query.SelectQuery) (*SelectQuery, error) {
+	if q.Error != nil {
+		return nil, q.Error
+	}
+
+	fields := make([]*Field, 0, len(q.FieldsExpr()))
+	for _, e := range q.FieldsExpr() {
+		f, err := toField(e)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+
+	joins := make([]*Join, 0, len(q.JoinExpr()))
+	for _, j := range q.JoinExpr() {
+		on, err := ToWhere(j.On)
+		if err != nil {
+			return nil, err
+		}
+		joins = append(joins, &Join{Type: joinTypes[j.Type], Table: toTable(j.Table), On: on})
+	}
+
+	where, err := ToWhere(q.WhereExpr())
+	if err != nil {
+		return nil, err
+	}
+	having, err := ToWhere(q.HavingExpr())
+	if err != nil {
+		return nil, err
+	}
+	orderBys, err := ToOrderBys(q.OrderByExpr())
+	if err != nil {
+		return nil, err
+	}
+	pagination, err := ToPagination(q.PaginationExpr())
+	if err != nil {
+		return nil, err
+	}
+
+	return &SelectQuery{
+		Table:      toTable(q.TableExpr()),
+		Fields:     fields,
+		Joins:      joins,
+		Where:      where,
+		GroupBy:    q.GroupByExpr().Columns,
+		Having:     having,
+		OrderBys:   orderBys,
+		Pagination: pagination,
+	}, nil
+}
+
+// FromSelect 将 Protobuf 消息还原为 SELECT 查询
+func FromSelect(m *SelectQuery) (*query.SelectQuery, error) {
+	q := query.Table(fromTable(m.GetTable())).Select()
+
+	for _, f := range m.GetFields() {
+		e, err := fromField(f)
+		if err != nil {
+			return nil, err
+		}
+		q.SelectExpr(e)
+	}
+
+	for _, j := range m.GetJoins() {
+		if j.GetTable().GetName() == "" {
+			return nil, fmt.Errorf("%w: join without table", ErrInvalidMessage)
+		}
+		on, err := FromWhere(j.GetOn())
+		if err != nil {
+			return nil, err
+		}
+		table := fromTable(j.GetTable())
+		switch j.GetType() {
+		case JoinType_JOIN_TYPE_INNER:
+			q.Join(table, on.Exprs...)
+		case JoinType_JOIN_TYPE_LEFT:
+			q.LeftJoin(table, on.Exprs...)
+		case JoinType_JOIN_TYPE_RIGHT:
+			q.RightJoin(table, on.Exprs...)
+		case JoinType_JOIN_TYPE_CROSS:
+			q.CrossJoin(table)
+		default:
+			return nil, fmt.Errorf("%w: unknown join type %s", ErrInvalidMessage, j.GetType())
+		}
+	}
+
+	where, err := FromWhere(m.GetWhere())
+	if err != nil {
+		return nil, err
+	}
+	having, err := FromWhere(m.GetHaving())
+	if err != nil {
+		return nil, err
+	}
+	orderBys, err := FromOrderBys(m.GetOrderBys())
+	if err != nil {
+		return nil, err
+	}
+	pagination, err := FromPagination(m.GetPagination())
+	if err != nil {
+		return nil, err
+	}
+
+	q.Where(where).GroupBy(m.GetGroupBy()...).Having(having).OrderBy(orderBys)
+	paginate[*query.SelectQuery](q, pagination)
+	return q, nil
+}
+
+// ToInsert 将 INSERT 查询转换为 Protobuf 消息，各行的字段按字段名排序
+func ToInsert(q *query.InsertQuery) (*InsertQuery, error) {
+	if q.Error != nil {
+		return nil, q.Error
+	}
+
+	rows := make([]*Row, 0, len(q.ValuesExpr()))
+	for _, values := range q.ValuesExpr() {
+		assignments, err := toAssignments(values)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, &Row{Values: assignments})
+	}
+
+	m := &InsertQuery{
+		Table:     toTable(q.TableExpr()),
+		Rows:      rows,
+		Returning: q.ReturningExpr().Columns,
+	}
+
+	conflict := q.OnConflictExpr()
+	if len(conflict.Columns) > 0 || conflict.DoNothing || len(conflict.DoUpdates) > 0 {
+		m.OnConflict = &OnConflict{Columns: conflict.Columns, DoNothing: conflict.DoNothing}
+		for _, a := range conflict.DoUpdates {
+			val, err := ToValue(a.Value)
+			if err != nil {
+				return nil, err
+			}
+			m.OnConflict.DoUpdates = append(m.OnConflict.DoUpdates, &Assignment{Column: a.Column, Value: val})
+		}
+	}
+
+	return m, nil
+}
+
+// FromInsert 将 Protobuf 消息还原为 INSERT 查询
+func FromInsert(m *InsertQuery) (*query.InsertQuery, error) {
+	rows := make([]map[string]any, 0, len(m.GetRows()))
+	for _, row := range m.GetRows() {
+		values, err := fromAssignments(row.GetValues())
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, values)
+	}
+
+	q := query.Table(fromTable(m.GetTable())).Insert(rows)
+
+	if conflict := m.GetOnConflict(); conflict != nil {
+		c := q.OnConflict(conflict.GetColumns()...)
+		if conflict.GetDoNothing() {
+			c.DoNothing()
+		}
+		for _, a := range conflict.GetDoUpdates() {
+			if a.GetColumn() == "" {
+				return nil, fmt.Errorf("%w: assignment without column", ErrInvalidMessage)
+			}
+			val, err := FromValue(a.GetValue())
+			if err != nil {
+				return nil, err
+			}
+			c.DoUpdate(a.GetColumn(), val)
+		}
+	}
+
+	return q.Returning(m.GetReturning()...), nil
+}
+
+// ToUpdate 将 UPDATE 查询转换为 Protobuf 消息，更新的字段按字段名排序
+func ToUpdate(q *query.UpdateQuery) (*UpdateQuery, error) {
+	if q.Error != nil {
+		return nil, q.Error
+	}
+
+	values, err := toAssignments(q.ValuesExpr())
+	if err != nil {
+		return nil, err
+	}
+	where, err := ToWhere(q.WhereExpr())
+	if err != nil {
+		return nil, err
+	}
+	pagination, err := ToPagination(q.PaginationExpr())
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateQuery{
+		Table:      toTable(q.TableExpr()),
+		Values:     values,
+		Where:      where,
+		Pagination: pagination,
+		Returning:  q.ReturningExpr().Columns,
+	}, nil
+}
+
+// FromUpdate 将 Protobuf 消息还原为 UPDATE 查询
+func FromUpdate(m *UpdateQuery) (*query.UpdateQuery, error) {
+	values, err := fromAssignments(m.GetValues())
+	if err != nil {
+		return nil, err
+	}
+	where, err := FromWhere(m.GetWhere())
+	if err != nil {
+		return nil, err
+	}
+	pagination, err := FromPagination(m.GetPagination())
+	if err != nil {
+		return nil, err
+	}
+
+	q := query.Table(fromTable(m.GetTable())).Where(where).Update(values)
+	paginate[*query.UpdateQuery](q, pagination)
+	return q.Returning(m.GetReturning()...), nil
+}
+
+// ToDelete 将 DELETE 查询转换为 Protobuf 消息
+func ToDelete(q *query.DeleteQuery) (*DeleteQuery, error) {
+	if q.Error != nil {
+		return nil, q.Error
+	}
+
+	where, err := ToWhere(q.WhereExpr())
+	if err != nil {
+		return nil, err
+	}
+	pagination, err := ToPagination(q.PaginationExpr())
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteQuery{
+		Table:      toTable(q.TableExpr()),
+		Where:      where,
+		Returning:  q.ReturningExpr().Columns,
+		Pagination: pagination,
+	}, nil
+}
+
+// FromDelete 将 Protobuf 消息还原为 DELETE 查询
+func FromDelete(m *DeleteQuery) (*query.DeleteQuery, error) {
+	where, err := FromWhere(m.GetWhere())
+	if err != nil {
+		return nil, err
+	}
+	pagination, err := FromPagination(m.GetPagination())
+	if err != nil {
+		return nil, err
+	}
+
+	q := query.Table(fromTable(m.GetTable())).Where(where).Delete()
+	paginate[*query.DeleteQuery](q, pagination)
+	return q.Returning(m.GetReturning()...), nil
+}
+
+func toTable(t clause.Table) *Table {
+	return &Table{Name: t.Name, Alias: t.Alias}
+}
+
+// fromTable 还原为带别名的表名，如 "users u"
+func fromTable(m *Table) string {
+	if m.GetAlias() == "" {
+		return m.GetName()
+	}
+	return m.GetName() + " " + m.GetAlias()
+}
+
+func toField(e clause.Expression) (*Field, error) {
+	switch e := e.(type) {
+	case clause.Column:
+		return &Field{Expr: &Field_Column{Column: e.Name}}, nil
+	case clause.Aggregate:
+		return &Field{Expr: &Field_Aggregate{Aggregate: &Aggregate{Func: string(e.Func), Column: e.Col, Distinct: e.Distinct}}}, nil
+	case clause.Alias:
+		f, err := toField(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+		if f.Alias != "" {
+			return nil, fmt.Errorf("%w: nested alias", ErrUnsupportedExpr)
+		}
+		f.Alias = e.Name
+		return f, nil
+	}
+	return nil, fmt.Errorf("%w: field %T", ErrUnsupportedExpr, e)
+}
+
+func fromField(m *Field) (clause.Expression, error) {
+	var e clause.Expression
+	switch f := m.GetExpr().(type) {
+	case *Field_Column:
+		if f.Column == "" {
+			return nil, fmt.Errorf("%w: empty field column", ErrInvalidMessage)
+		}
+		e = clause.Column{Name: f.Column}
+	case *Field_Aggregate:
+		fn, ok := aggregateFuncs[f.Aggregate.GetFunc()]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown aggregate function %q", ErrInvalidMessage, f.Aggregate.GetFunc())
+		}
+		e = clause.Aggregate{Func: fn, Col: f.Aggregate.GetColumn(), Distinct: f.Aggregate.GetDistinct()}
+	default:
+		return nil, fmt.Errorf("%w: empty field", ErrInvalidMessage)
+	}
+
+	if m.GetAlias() != "" {
+		return clause.As(e, m.GetAlias()), nil
+	}
+	return e, nil
+}
+
+// toAssignments 将字段值转换为按字段名排序的赋值列表
+func toAssignments(values map[string]any) ([]*Assignment, error) {
+	columns := make([]string, 0, len(values))
+	for column := range values {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	assignments := make([]*Assignment, 0, len(columns))
+	for _, column := range columns {
+		val, err := ToValue(values[column])
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, &Assignment{Column: column, Value: val})
+	}
+	return assignments, nil
+}
+
+func fromAssignments(list []*Assignment) (map[string]any, error) {
+	values := make(map[string]any, len(list))
+	for _, a := range list {
+		if a.GetColumn() == "" {
+			return nil, fmt.Errorf("%w: assignment without column", ErrInvalidMessage)
+		}
+		val, err := FromValue(a.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		values[a.GetColumn()] = val
+	}
+	return values, nil
+}
+
+// paginator 是支持分页的查询
+type paginator[Q any] interface {
+	Limit(limit int) Q
+	Offset(offset int) Q
+	AfterValues(values ...any) Q
+}
+
+// paginate 将分页参数设置到查询上
+func paginate[Q any](q paginator[Q], p clause.Pagination) {
+	if p.Limit != nil {
+		q.Limit(*p.Limit)
+	}
+	if p.Offset > 0 {
+		q.Offset(p.Offset)
+	}
+	if len(p.After) > 0 {
+		q.AfterValues(p.After...)
+	}
+}
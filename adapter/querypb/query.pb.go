@@ -0,0 +1,1924 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: query.proto
+
+package querypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Operator 表示比较操作符
+type Operator int32
+
+const (
+	Operator_OPERATOR_UNSPECIFIED Operator = 0
+	Operator_OPERATOR_EQ          Operator = 1
+	Operator_OPERATOR_NEQ         Operator = 2
+	Operator_OPERATOR_GT          Operator = 3
+	Operator_OPERATOR_GTE         Operator = 4
+	Operator_OPERATOR_LT          Operator = 5
+	Operator_OPERATOR_LTE         Operator = 6
+	Operator_OPERATOR_LIKE        Operator = 7
+	Operator_OPERATOR_NOT_LIKE    Operator = 8
+	Operator_OPERATOR_ILIKE       Operator = 9
+	Operator_OPERATOR_IN          Operator = 10
+	Operator_OPERATOR_NOT_IN      Operator = 11
+	Operator_OPERATOR_BETWEEN     Operator = 12
+	Operator_OPERATOR_NOT_BETWEEN Operator = 13
+	Operator_OPERATOR_IS_NULL     Operator = 14
+	Operator_OPERATOR_IS_NOT_NULL Operator = 15
+	Operator_OPERATOR_STARTS_WITH Operator = 16
+	Operator_OPERATOR_ENDS_WITH   Operator = 17
+	Operator_OPERATOR_CONTAINS    Operator = 18
+)
+
+// Enum value maps for Operator.
+var (
+	Operator_name = map[int32]string{
+		0:  "OPERATOR_UNSPECIFIED",
+		1:  "OPERATOR_EQ",
+		2:  "OPERATOR_NEQ",
+		3:  "OPERATOR_GT",
+		4:  "OPERATOR_GTE",
+		5:  "OPERATOR_LT",
+		6:  "OPERATOR_LTE",
+		7:  "OPERATOR_LIKE",
+		8:  "OPERATOR_NOT_LIKE",
+		9:  "OPERATOR_ILIKE",
+		10: "OPERATOR_IN",
+		11: "OPERATOR_NOT_IN",
+		12: "OPERATOR_BETWEEN",
+		13: "OPERATOR_NOT_BETWEEN",
+		14: "OPERATOR_IS_NULL",
+		15: "OPERATOR_IS_NOT_NULL",
+		16: "OPERATOR_STARTS_WITH",
+		17: "OPERATOR_ENDS_WITH",
+		18: "OPERATOR_CONTAINS",
+	}
+	Operator_value = map[string]int32{
+		"OPERATOR_UNSPECIFIED": 0,
+		"OPERATOR_EQ":          1,
+		"OPERATOR_NEQ":         2,
+		"OPERATOR_GT":          3,
+		"OPERATOR_GTE":         4,
+		"OPERATOR_LT":          5,
+		"OPERATOR_LTE":         6,
+		"OPERATOR_LIKE":        7,
+		"OPERATOR_NOT_LIKE":    8,
+		"OPERATOR_ILIKE":       9,
+		"OPERATOR_IN":          10,
+		"OPERATOR_NOT_IN":      11,
+		"OPERATOR_BETWEEN":     12,
+		"OPERATOR_NOT_BETWEEN": 13,
+		"OPERATOR_IS_NULL":     14,
+		"OPERATOR_IS_NOT_NULL": 15,
+		"OPERATOR_STARTS_WITH": 16,
+		"OPERATOR_ENDS_WITH":   17,
+		"OPERATOR_CONTAINS":    18,
+	}
+)
+
+func (x Operator) Enum() *Operator {
+	p := new(Operator)
+	*p = x
+	return p
+}
+
+func (x Operator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Operator) Descriptor() protoreflect.EnumDescriptor {
+	return file_query_proto_enumTypes[0].Descriptor()
+}
+
+func (Operator) Type() protoreflect.EnumType {
+	return &file_query_proto_enumTypes[0]
+}
+
+func (x Operator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Operator.Descriptor instead.
+func (Operator) EnumDescriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{0}
+}
+
+// LogicalOperator 表示逻辑运算符
+type LogicalOperator int32
+
+const (
+	LogicalOperator_LOGICAL_OPERATOR_UNSPECIFIED LogicalOperator = 0
+	LogicalOperator_LOGICAL_OPERATOR_AND         LogicalOperator = 1
+	LogicalOperator_LOGICAL_OPERATOR_OR          LogicalOperator = 2
+	LogicalOperator_LOGICAL_OPERATOR_NOT         LogicalOperator = 3
+)
+
+// Enum value maps for LogicalOperator.
+var (
+	LogicalOperator_name = map[int32]string{
+		0: "LOGICAL_OPERATOR_UNSPECIFIED",
+		1: "LOGICAL_OPERATOR_AND",
+		2: "LOGICAL_OPERATOR_OR",
+		3: "LOGICAL_OPERATOR_NOT",
+	}
+	LogicalOperator_value = map[string]int32{
+		"LOGICAL_OPERATOR_UNSPECIFIED": 0,
+		"LOGICAL_OPERATOR_AND":         1,
+		"LOGICAL_OPERATOR_OR":          2,
+		"LOGICAL_OPERATOR_NOT":         3,
+	}
+)
+
+func (x LogicalOperator) Enum() *LogicalOperator {
+	p := new(LogicalOperator)
+	*p = x
+	return p
+}
+
+func (x LogicalOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LogicalOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_query_proto_enumTypes[1].Descriptor()
+}
+
+func (LogicalOperator) Type() protoreflect.EnumType {
+	return &file_query_proto_enumTypes[1]
+}
+
+func (x LogicalOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LogicalOperator.Descriptor instead.
+func (LogicalOperator) EnumDescriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{1}
+}
+
+// JoinType 表示 JOIN 的类型
+type JoinType int32
+
+const (
+	JoinType_JOIN_TYPE_UNSPECIFIED JoinType = 0
+	JoinType_JOIN_TYPE_INNER       JoinType = 1
+	JoinType_JOIN_TYPE_LEFT        JoinType = 2
+	JoinType_JOIN_TYPE_RIGHT       JoinType = 3
+	JoinType_JOIN_TYPE_CROSS       JoinType = 4
+)
+
+// Enum value maps for JoinType.
+var (
+	JoinType_name = map[int32]string{
+		0: "JOIN_TYPE_UNSPECIFIED",
+		1: "JOIN_TYPE_INNER",
+		2: "JOIN_TYPE_LEFT",
+		3: "JOIN_TYPE_RIGHT",
+		4: "JOIN_TYPE_CROSS",
+	}
+	JoinType_value = map[string]int32{
+		"JOIN_TYPE_UNSPECIFIED": 0,
+		"JOIN_TYPE_INNER":       1,
+		"JOIN_TYPE_LEFT":        2,
+		"JOIN_TYPE_RIGHT":       3,
+		"JOIN_TYPE_CROSS":       4,
+	}
+)
+
+func (x JoinType) Enum() *JoinType {
+	p := new(JoinType)
+	*p = x
+	return p
+}
+
+func (x JoinType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JoinType) Descriptor() protoreflect.EnumDescriptor {
+	return file_query_proto_enumTypes[2].Descriptor()
+}
+
+func (JoinType) Type() protoreflect.EnumType {
+	return &file_query_proto_enumTypes[2]
+}
+
+func (x JoinType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JoinType.Descriptor instead.
+func (JoinType) EnumDescriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{2}
+}
+
+// Value 表示条件、赋值和游标中的值
+type Value struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*Value_NullValue
+	//	*Value_StringValue
+	//	*Value_IntValue
+	//	*Value_UintValue
+	//	*Value_DoubleValue
+	//	*Value_BoolValue
+	//	*Value_BytesValue
+	//	*Value_TimestampValue
+	//	*Value_DurationValue
+	//	*Value_ListValue
+	//	*Value_Column
+	//	*Value_Excluded
+	Kind          isValue_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Value) Reset() {
+	*x = Value{}
+	mi := &file_query_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value) ProtoMessage() {}
+
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Value) GetKind() isValue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *Value) GetNullValue() structpb.NullValue {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_NullValue); ok {
+			return x.NullValue
+		}
+	}
+	return structpb.NullValue(0)
+}
+
+func (x *Value) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *Value) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetUintValue() uint64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_UintValue); ok {
+			return x.UintValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetDoubleValue() float64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_DoubleValue); ok {
+			return x.DoubleValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *Value) GetBytesValue() []byte {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_BytesValue); ok {
+			return x.BytesValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetTimestampValue() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_TimestampValue); ok {
+			return x.TimestampValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetDurationValue() *durationpb.Duration {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_DurationValue); ok {
+			return x.DurationValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetListValue() *ValueList {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_ListValue); ok {
+			return x.ListValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetColumn() string {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_Column); ok {
+			return x.Column
+		}
+	}
+	return ""
+}
+
+func (x *Value) GetExcluded() string {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_Excluded); ok {
+			return x.Excluded
+		}
+	}
+	return ""
+}
+
+type isValue_Kind interface {
+	isValue_Kind()
+}
+
+type Value_NullValue struct {
+	// 空值（NULL）
+	NullValue structpb.NullValue `protobuf:"varint,1,opt,name=null_value,json=nullValue,proto3,enum=google.protobuf.NullValue,oneof"`
+}
+
+type Value_StringValue struct {
+	StringValue string `protobuf:"bytes,2,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type Value_IntValue struct {
+	// 有符号整数，解码为 int64
+	IntValue int64 `protobuf:"varint,3,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type Value_UintValue struct {
+	// 无符号整数，解码为 uint64
+	UintValue uint64 `protobuf:"varint,4,opt,name=uint_value,json=uintValue,proto3,oneof"`
+}
+
+type Value_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,5,opt,name=double_value,json=doubleValue,proto3,oneof"`
+}
+
+type Value_BoolValue struct {
+	BoolValue bool `protobuf:"varint,6,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type Value_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,7,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
+}
+
+type Value_TimestampValue struct {
+	TimestampValue *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=timestamp_value,json=timestampValue,proto3,oneof"`
+}
+
+type Value_DurationValue struct {
+	DurationValue *durationpb.Duration `protobuf:"bytes,9,opt,name=duration_value,json=durationValue,proto3,oneof"`
+}
+
+type Value_ListValue struct {
+	// 切片值，如 Eq 的切片值
+	ListValue *ValueList `protobuf:"bytes,10,opt,name=list_value,json=listValue,proto3,oneof"`
+}
+
+type Value_Column struct {
+	// 列引用，如 JOIN 条件中的 u.id
+	Column string `protobuf:"bytes,11,opt,name=column,proto3,oneof"`
+}
+
+type Value_Excluded struct {
+	// 冲突时待插入的新值，仅用于 OnConflict 的赋值
+	Excluded string `protobuf:"bytes,12,opt,name=excluded,proto3,oneof"`
+}
+
+func (*Value_NullValue) isValue_Kind() {}
+
+func (*Value_StringValue) isValue_Kind() {}
+
+func (*Value_IntValue) isValue_Kind() {}
+
+func (*Value_UintValue) isValue_Kind() {}
+
+func (*Value_DoubleValue) isValue_Kind() {}
+
+func (*Value_BoolValue) isValue_Kind() {}
+
+func (*Value_BytesValue) isValue_Kind() {}
+
+func (*Value_TimestampValue) isValue_Kind() {}
+
+func (*Value_DurationValue) isValue_Kind() {}
+
+func (*Value_ListValue) isValue_Kind() {}
+
+func (*Value_Column) isValue_Kind() {}
+
+func (*Value_Excluded) isValue_Kind() {}
+
+// ValueList 表示值的列表
+type ValueList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*Value               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValueList) Reset() {
+	*x = ValueList{}
+	mi := &file_query_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValueList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValueList) ProtoMessage() {}
+
+func (x *ValueList) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValueList.ProtoReflect.Descriptor instead.
+func (*ValueList) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ValueList) GetValues() []*Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Comparison 表示比较条件
+type Comparison struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Operator Operator               `protobuf:"varint,1,opt,name=operator,proto3,enum=epkgs.query.v1.Operator" json:"operator,omitempty"`
+	Column   string                 `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"`
+	// 单值操作符的值，IS NULL、IS NOT NULL 没有值
+	Value *Value `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// IN、NOT IN 的值列表，BETWEEN、NOT BETWEEN 的上下限
+	Values        []*Value `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comparison) Reset() {
+	*x = Comparison{}
+	mi := &file_query_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comparison) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comparison) ProtoMessage() {}
+
+func (x *Comparison) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comparison.ProtoReflect.Descriptor instead.
+func (*Comparison) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Comparison) GetOperator() Operator {
+	if x != nil {
+		return x.Operator
+	}
+	return Operator_OPERATOR_UNSPECIFIED
+}
+
+func (x *Comparison) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *Comparison) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Comparison) GetValues() []*Value {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Logical 表示逻辑表达式（AND/OR/NOT）
+type Logical struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operator      LogicalOperator        `protobuf:"varint,1,opt,name=operator,proto3,enum=epkgs.query.v1.LogicalOperator" json:"operator,omitempty"`
+	Exprs         []*Expression          `protobuf:"bytes,2,rep,name=exprs,proto3" json:"exprs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Logical) Reset() {
+	*x = Logical{}
+	mi := &file_query_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Logical) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Logical) ProtoMessage() {}
+
+func (x *Logical) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Logical.ProtoReflect.Descriptor instead.
+func (*Logical) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Logical) GetOperator() LogicalOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return LogicalOperator_LOGICAL_OPERATOR_UNSPECIFIED
+}
+
+func (x *Logical) GetExprs() []*Expression {
+	if x != nil {
+		return x.Exprs
+	}
+	return nil
+}
+
+// Expression 表示条件表达式
+type Expression struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Expr:
+	//
+	//	*Expression_Comparison
+	//	*Expression_Logical
+	Expr          isExpression_Expr `protobuf_oneof:"expr"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Expression) Reset() {
+	*x = Expression{}
+	mi := &file_query_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Expression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Expression) ProtoMessage() {}
+
+func (x *Expression) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Expression.ProtoReflect.Descriptor instead.
+func (*Expression) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Expression) GetExpr() isExpression_Expr {
+	if x != nil {
+		return x.Expr
+	}
+	return nil
+}
+
+func (x *Expression) GetComparison() *Comparison {
+	if x != nil {
+		if x, ok := x.Expr.(*Expression_Comparison); ok {
+			return x.Comparison
+		}
+	}
+	return nil
+}
+
+func (x *Expression) GetLogical() *Logical {
+	if x != nil {
+		if x, ok := x.Expr.(*Expression_Logical); ok {
+			return x.Logical
+		}
+	}
+	return nil
+}
+
+type isExpression_Expr interface {
+	isExpression_Expr()
+}
+
+type Expression_Comparison struct {
+	Comparison *Comparison `protobuf:"bytes,1,opt,name=comparison,proto3,oneof"`
+}
+
+type Expression_Logical struct {
+	Logical *Logical `protobuf:"bytes,2,opt,name=logical,proto3,oneof"`
+}
+
+func (*Expression_Comparison) isExpression_Expr() {}
+
+func (*Expression_Logical) isExpression_Expr() {}
+
+// Where 表示 WHERE 条件，保留表达式树的结构
+type Where struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exprs         []*Expression          `protobuf:"bytes,1,rep,name=exprs,proto3" json:"exprs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Where) Reset() {
+	*x = Where{}
+	mi := &file_query_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Where) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Where) ProtoMessage() {}
+
+func (x *Where) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Where.ProtoReflect.Descriptor instead.
+func (*Where) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Where) GetExprs() []*Expression {
+	if x != nil {
+		return x.Exprs
+	}
+	return nil
+}
+
+// OrderBy 表示排序字段
+type OrderBy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Column        string                 `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	Desc          bool                   `protobuf:"varint,2,opt,name=desc,proto3" json:"desc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderBy) Reset() {
+	*x = OrderBy{}
+	mi := &file_query_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBy) ProtoMessage() {}
+
+func (x *OrderBy) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBy.ProtoReflect.Descriptor instead.
+func (*OrderBy) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OrderBy) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *OrderBy) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+// Pagination 表示分页参数
+type Pagination struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 未设置时不限制条数
+	Limit  *int64 `protobuf:"varint,1,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// 键集分页的游标值，与排序字段一一对应
+	After         []*Value `protobuf:"bytes,3,rep,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_query_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Pagination) GetLimit() int64 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *Pagination) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Pagination) GetAfter() []*Value {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+// Table 表示表名和别名
+type Table struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Alias         string                 `protobuf:"bytes,2,opt,name=alias,proto3" json:"alias,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Table) Reset() {
+	*x = Table{}
+	mi := &file_query_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Table) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Table) ProtoMessage() {}
+
+func (x *Table) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Table.ProtoReflect.Descriptor instead.
+func (*Table) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Table) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Table) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+// Join 表示 JOIN 子句
+type Join struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  JoinType               `protobuf:"varint,1,opt,name=type,proto3,enum=epkgs.query.v1.JoinType" json:"type,omitempty"`
+	Table *Table                 `protobuf:"bytes,2,opt,name=table,proto3" json:"table,omitempty"`
+	// ON 条件，CROSS JOIN 没有 ON 条件
+	On            *Where `protobuf:"bytes,3,opt,name=on,proto3" json:"on,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Join) Reset() {
+	*x = Join{}
+	mi := &file_query_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Join) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Join) ProtoMessage() {}
+
+func (x *Join) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Join.ProtoReflect.Descriptor instead.
+func (*Join) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Join) GetType() JoinType {
+	if x != nil {
+		return x.Type
+	}
+	return JoinType_JOIN_TYPE_UNSPECIFIED
+}
+
+func (x *Join) GetTable() *Table {
+	if x != nil {
+		return x.Table
+	}
+	return nil
+}
+
+func (x *Join) GetOn() *Where {
+	if x != nil {
+		return x.On
+	}
+	return nil
+}
+
+// Aggregate 表示聚合函数
+type Aggregate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 函数名，如 COUNT、SUM、AVG、MIN、MAX
+	Func string `protobuf:"bytes,1,opt,name=func,proto3" json:"func,omitempty"`
+	// 列名，为空或 "*" 时表示所有列
+	Column        string `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"`
+	Distinct      bool   `protobuf:"varint,3,opt,name=distinct,proto3" json:"distinct,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Aggregate) Reset() {
+	*x = Aggregate{}
+	mi := &file_query_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Aggregate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Aggregate) ProtoMessage() {}
+
+func (x *Aggregate) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Aggregate.ProtoReflect.Descriptor instead.
+func (*Aggregate) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Aggregate) GetFunc() string {
+	if x != nil {
+		return x.Func
+	}
+	return ""
+}
+
+func (x *Aggregate) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *Aggregate) GetDistinct() bool {
+	if x != nil {
+		return x.Distinct
+	}
+	return false
+}
+
+// Field 表示 SELECT 的查询字段
+type Field struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Expr:
+	//
+	//	*Field_Column
+	//	*Field_Aggregate
+	Expr isField_Expr `protobuf_oneof:"expr"`
+	// 字段别名
+	Alias         string `protobuf:"bytes,3,opt,name=alias,proto3" json:"alias,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Field) Reset() {
+	*x = Field{}
+	mi := &file_query_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Field) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Field) ProtoMessage() {}
+
+func (x *Field) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Field.ProtoReflect.Descriptor instead.
+func (*Field) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Field) GetExpr() isField_Expr {
+	if x != nil {
+		return x.Expr
+	}
+	return nil
+}
+
+func (x *Field) GetColumn() string {
+	if x != nil {
+		if x, ok := x.Expr.(*Field_Column); ok {
+			return x.Column
+		}
+	}
+	return ""
+}
+
+func (x *Field) GetAggregate() *Aggregate {
+	if x != nil {
+		if x, ok := x.Expr.(*Field_Aggregate); ok {
+			return x.Aggregate
+		}
+	}
+	return nil
+}
+
+func (x *Field) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+type isField_Expr interface {
+	isField_Expr()
+}
+
+type Field_Column struct {
+	Column string `protobuf:"bytes,1,opt,name=column,proto3,oneof"`
+}
+
+type Field_Aggregate struct {
+	Aggregate *Aggregate `protobuf:"bytes,2,opt,name=aggregate,proto3,oneof"`
+}
+
+func (*Field_Column) isField_Expr() {}
+
+func (*Field_Aggregate) isField_Expr() {}
+
+// SelectQuery 表示 SELECT 查询
+type SelectQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Table *Table                 `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	// 为空时查询所有字段
+	Fields        []*Field    `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	Joins         []*Join     `protobuf:"bytes,3,rep,name=joins,proto3" json:"joins,omitempty"`
+	Where         *Where      `protobuf:"bytes,4,opt,name=where,proto3" json:"where,omitempty"`
+	GroupBy       []string    `protobuf:"bytes,5,rep,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	Having        *Where      `protobuf:"bytes,6,opt,name=having,proto3" json:"having,omitempty"`
+	OrderBys      []*OrderBy  `protobuf:"bytes,7,rep,name=order_bys,json=orderBys,proto3" json:"order_bys,omitempty"`
+	Pagination    *Pagination `protobuf:"bytes,8,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SelectQuery) Reset() {
+	*x = SelectQuery{}
+	mi := &file_query_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SelectQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SelectQuery) ProtoMessage() {}
+
+func (x *SelectQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SelectQuery.ProtoReflect.Descriptor instead.
+func (*SelectQuery) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SelectQuery) GetTable() *Table {
+	if x != nil {
+		return x.Table
+	}
+	return nil
+}
+
+func (x *SelectQuery) GetFields() []*Field {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *SelectQuery) GetJoins() []*Join {
+	if x != nil {
+		return x.Joins
+	}
+	return nil
+}
+
+func (x *SelectQuery) GetWhere() *Where {
+	if x != nil {
+		return x.Where
+	}
+	return nil
+}
+
+func (x *SelectQuery) GetGroupBy() []string {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+func (x *SelectQuery) GetHaving() *Where {
+	if x != nil {
+		return x.Having
+	}
+	return nil
+}
+
+func (x *SelectQuery) GetOrderBys() []*OrderBy {
+	if x != nil {
+		return x.OrderBys
+	}
+	return nil
+}
+
+func (x *SelectQuery) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+// Assignment 表示字段赋值
+type Assignment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Column        string                 `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	Value         *Value                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Assignment) Reset() {
+	*x = Assignment{}
+	mi := &file_query_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Assignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Assignment) ProtoMessage() {}
+
+func (x *Assignment) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Assignment.ProtoReflect.Descriptor instead.
+func (*Assignment) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Assignment) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *Assignment) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Row 表示 INSERT 的一行数据，按字段名排序
+type Row struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*Assignment          `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Row) Reset() {
+	*x = Row{}
+	mi := &file_query_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Row) ProtoMessage() {}
+
+func (x *Row) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Row.ProtoReflect.Descriptor instead.
+func (*Row) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Row) GetValues() []*Assignment {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// OnConflict 表示 INSERT 的冲突处理
+type OnConflict struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Columns       []string               `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	DoNothing     bool                   `protobuf:"varint,2,opt,name=do_nothing,json=doNothing,proto3" json:"do_nothing,omitempty"`
+	DoUpdates     []*Assignment          `protobuf:"bytes,3,rep,name=do_updates,json=doUpdates,proto3" json:"do_updates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OnConflict) Reset() {
+	*x = OnConflict{}
+	mi := &file_query_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OnConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OnConflict) ProtoMessage() {}
+
+func (x *OnConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OnConflict.ProtoReflect.Descriptor instead.
+func (*OnConflict) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OnConflict) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *OnConflict) GetDoNothing() bool {
+	if x != nil {
+		return x.DoNothing
+	}
+	return false
+}
+
+func (x *OnConflict) GetDoUpdates() []*Assignment {
+	if x != nil {
+		return x.DoUpdates
+	}
+	return nil
+}
+
+// InsertQuery 表示 INSERT 查询
+type InsertQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Table         *Table                 `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	Rows          []*Row                 `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	OnConflict    *OnConflict            `protobuf:"bytes,3,opt,name=on_conflict,json=onConflict,proto3" json:"on_conflict,omitempty"`
+	Returning     []string               `protobuf:"bytes,4,rep,name=returning,proto3" json:"returning,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InsertQuery) Reset() {
+	*x = InsertQuery{}
+	mi := &file_query_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InsertQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InsertQuery) ProtoMessage() {}
+
+func (x *InsertQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InsertQuery.ProtoReflect.Descriptor instead.
+func (*InsertQuery) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *InsertQuery) GetTable() *Table {
+	if x != nil {
+		return x.Table
+	}
+	return nil
+}
+
+func (x *InsertQuery) GetRows() []*Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *InsertQuery) GetOnConflict() *OnConflict {
+	if x != nil {
+		return x.OnConflict
+	}
+	return nil
+}
+
+func (x *InsertQuery) GetReturning() []string {
+	if x != nil {
+		return x.Returning
+	}
+	return nil
+}
+
+// UpdateQuery 表示 UPDATE 查询
+type UpdateQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Table *Table                 `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	// 按字段名排序
+	Values        []*Assignment `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	Where         *Where        `protobuf:"bytes,3,opt,name=where,proto3" json:"where,omitempty"`
+	Pagination    *Pagination   `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Returning     []string      `protobuf:"bytes,5,rep,name=returning,proto3" json:"returning,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateQuery) Reset() {
+	*x = UpdateQuery{}
+	mi := &file_query_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateQuery) ProtoMessage() {}
+
+func (x *UpdateQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateQuery.ProtoReflect.Descriptor instead.
+func (*UpdateQuery) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateQuery) GetTable() *Table {
+	if x != nil {
+		return x.Table
+	}
+	return nil
+}
+
+func (x *UpdateQuery) GetValues() []*Assignment {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *UpdateQuery) GetWhere() *Where {
+	if x != nil {
+		return x.Where
+	}
+	return nil
+}
+
+func (x *UpdateQuery) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *UpdateQuery) GetReturning() []string {
+	if x != nil {
+		return x.Returning
+	}
+	return nil
+}
+
+// DeleteQuery 表示 DELETE 查询
+type DeleteQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Table         *Table                 `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	Where         *Where                 `protobuf:"bytes,2,opt,name=where,proto3" json:"where,omitempty"`
+	Returning     []string               `protobuf:"bytes,3,rep,name=returning,proto3" json:"returning,omitempty"`
+	Pagination    *Pagination            `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteQuery) Reset() {
+	*x = DeleteQuery{}
+	mi := &file_query_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteQuery) ProtoMessage() {}
+
+func (x *DeleteQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_query_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteQuery.ProtoReflect.Descriptor instead.
+func (*DeleteQuery) Descriptor() ([]byte, []int) {
+	return file_query_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeleteQuery) GetTable() *Table {
+	if x != nil {
+		return x.Table
+	}
+	return nil
+}
+
+func (x *DeleteQuery) GetWhere() *Where {
+	if x != nil {
+		return x.Where
+	}
+	return nil
+}
+
+func (x *DeleteQuery) GetReturning() []string {
+	if x != nil {
+		return x.Returning
+	}
+	return nil
+}
+
+func (x *DeleteQuery) GetPagination() *Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+var File_query_proto protoreflect.FileDescriptor
+
+const file_query_proto_rawDesc = "" +
+	"\n" +
+	"\vquery.proto\x12\x0eepkgs.query.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x99\x04\n" +
+	"\x05Value\x12;\n" +
+	"\n" +
+	"null_value\x18\x01 \x01(\x0e2\x1a.google.protobuf.NullValueH\x00R\tnullValue\x12#\n" +
+	"\fstring_value\x18\x02 \x01(\tH\x00R\vstringValue\x12\x1d\n" +
+	"\tint_value\x18\x03 \x01(\x03H\x00R\bintValue\x12\x1f\n" +
+	"\n" +
+	"uint_value\x18\x04 \x01(\x04H\x00R\tuintValue\x12#\n" +
+	"\fdouble_value\x18\x05 \x01(\x01H\x00R\vdoubleValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x06 \x01(\bH\x00R\tboolValue\x12!\n" +
+	"\vbytes_value\x18\a \x01(\fH\x00R\n" +
+	"bytesValue\x12E\n" +
+	"\x0ftimestamp_value\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x0etimestampValue\x12B\n" +
+	"\x0eduration_value\x18\t \x01(\v2\x19.google.protobuf.DurationH\x00R\rdurationValue\x12:\n" +
+	"\n" +
+	"list_value\x18\n" +
+	" \x01(\v2\x19.epkgs.query.v1.ValueListH\x00R\tlistValue\x12\x18\n" +
+	"\x06column\x18\v \x01(\tH\x00R\x06column\x12\x1c\n" +
+	"\bexcluded\x18\f \x01(\tH\x00R\bexcludedB\x06\n" +
+	"\x04kind\":\n" +
+	"\tValueList\x12-\n" +
+	"\x06values\x18\x01 \x03(\v2\x15.epkgs.query.v1.ValueR\x06values\"\xb6\x01\n" +
+	"\n" +
+	"Comparison\x124\n" +
+	"\boperator\x18\x01 \x01(\x0e2\x18.epkgs.query.v1.OperatorR\boperator\x12\x16\n" +
+	"\x06column\x18\x02 \x01(\tR\x06column\x12+\n" +
+	"\x05value\x18\x03 \x01(\v2\x15.epkgs.query.v1.ValueR\x05value\x12-\n" +
+	"\x06values\x18\x04 \x03(\v2\x15.epkgs.query.v1.ValueR\x06values\"x\n" +
+	"\aLogical\x12;\n" +
+	"\boperator\x18\x01 \x01(\x0e2\x1f.epkgs.query.v1.LogicalOperatorR\boperator\x120\n" +
+	"\x05exprs\x18\x02 \x03(\v2\x1a.epkgs.query.v1.ExpressionR\x05exprs\"\x87\x01\n" +
+	"\n" +
+	"Expression\x12<\n" +
+	"\n" +
+	"comparison\x18\x01 \x01(\v2\x1a.epkgs.query.v1.ComparisonH\x00R\n" +
+	"comparison\x123\n" +
+	"\alogical\x18\x02 \x01(\v2\x17.epkgs.query.v1.LogicalH\x00R\alogicalB\x06\n" +
+	"\x04expr\"9\n" +
+	"\x05Where\x120\n" +
+	"\x05exprs\x18\x01 \x03(\v2\x1a.epkgs.query.v1.ExpressionR\x05exprs\"5\n" +
+	"\aOrderBy\x12\x16\n" +
+	"\x06column\x18\x01 \x01(\tR\x06column\x12\x12\n" +
+	"\x04desc\x18\x02 \x01(\bR\x04desc\"v\n" +
+	"\n" +
+	"Pagination\x12\x19\n" +
+	"\x05limit\x18\x01 \x01(\x03H\x00R\x05limit\x88\x01\x01\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12+\n" +
+	"\x05after\x18\x03 \x03(\v2\x15.epkgs.query.v1.ValueR\x05afterB\b\n" +
+	"\x06_limit\"1\n" +
+	"\x05Table\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05alias\x18\x02 \x01(\tR\x05alias\"\x88\x01\n" +
+	"\x04Join\x12,\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x18.epkgs.query.v1.JoinTypeR\x04type\x12+\n" +
+	"\x05table\x18\x02 \x01(\v2\x15.epkgs.query.v1.TableR\x05table\x12%\n" +
+	"\x02on\x18\x03 \x01(\v2\x15.epkgs.query.v1.WhereR\x02on\"S\n" +
+	"\tAggregate\x12\x12\n" +
+	"\x04func\x18\x01 \x01(\tR\x04func\x12\x16\n" +
+	"\x06column\x18\x02 \x01(\tR\x06column\x12\x1a\n" +
+	"\bdistinct\x18\x03 \x01(\bR\bdistinct\"z\n" +
+	"\x05Field\x12\x18\n" +
+	"\x06column\x18\x01 \x01(\tH\x00R\x06column\x129\n" +
+	"\taggregate\x18\x02 \x01(\v2\x19.epkgs.query.v1.AggregateH\x00R\taggregate\x12\x14\n" +
+	"\x05alias\x18\x03 \x01(\tR\x05aliasB\x06\n" +
+	"\x04expr\"\xfe\x02\n" +
+	"\vSelectQuery\x12+\n" +
+	"\x05table\x18\x01 \x01(\v2\x15.epkgs.query.v1.TableR\x05table\x12-\n" +
+	"\x06fields\x18\x02 \x03(\v2\x15.epkgs.query.v1.FieldR\x06fields\x12*\n" +
+	"\x05joins\x18\x03 \x03(\v2\x14.epkgs.query.v1.JoinR\x05joins\x12+\n" +
+	"\x05where\x18\x04 \x01(\v2\x15.epkgs.query.v1.WhereR\x05where\x12\x19\n" +
+	"\bgroup_by\x18\x05 \x03(\tR\agroupBy\x12-\n" +
+	"\x06having\x18\x06 \x01(\v2\x15.epkgs.query.v1.WhereR\x06having\x124\n" +
+	"\torder_bys\x18\a \x03(\v2\x17.epkgs.query.v1.OrderByR\borderBys\x12:\n" +
+	"\n" +
+	"pagination\x18\b \x01(\v2\x1a.epkgs.query.v1.PaginationR\n" +
+	"pagination\"Q\n" +
+	"\n" +
+	"Assignment\x12\x16\n" +
+	"\x06column\x18\x01 \x01(\tR\x06column\x12+\n" +
+	"\x05value\x18\x02 \x01(\v2\x15.epkgs.query.v1.ValueR\x05value\"9\n" +
+	"\x03Row\x122\n" +
+	"\x06values\x18\x01 \x03(\v2\x1a.epkgs.query.v1.AssignmentR\x06values\"\x80\x01\n" +
+	"\n" +
+	"OnConflict\x12\x18\n" +
+	"\acolumns\x18\x01 \x03(\tR\acolumns\x12\x1d\n" +
+	"\n" +
+	"do_nothing\x18\x02 \x01(\bR\tdoNothing\x129\n" +
+	"\n" +
+	"do_updates\x18\x03 \x03(\v2\x1a.epkgs.query.v1.AssignmentR\tdoUpdates\"\xbe\x01\n" +
+	"\vInsertQuery\x12+\n" +
+	"\x05table\x18\x01 \x01(\v2\x15.epkgs.query.v1.TableR\x05table\x12'\n" +
+	"\x04rows\x18\x02 \x03(\v2\x13.epkgs.query.v1.RowR\x04rows\x12;\n" +
+	"\von_conflict\x18\x03 \x01(\v2\x1a.epkgs.query.v1.OnConflictR\n" +
+	"onConflict\x12\x1c\n" +
+	"\treturning\x18\x04 \x03(\tR\treturning\"\xf5\x01\n" +
+	"\vUpdateQuery\x12+\n" +
+	"\x05table\x18\x01 \x01(\v2\x15.epkgs.query.v1.TableR\x05table\x122\n" +
+	"\x06values\x18\x02 \x03(\v2\x1a.epkgs.query.v1.AssignmentR\x06values\x12+\n" +
+	"\x05where\x18\x03 \x01(\v2\x15.epkgs.query.v1.WhereR\x05where\x12:\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1a.epkgs.query.v1.PaginationR\n" +
+	"pagination\x12\x1c\n" +
+	"\treturning\x18\x05 \x03(\tR\treturning\"\xc1\x01\n" +
+	"\vDeleteQuery\x12+\n" +
+	"\x05table\x18\x01 \x01(\v2\x15.epkgs.query.v1.TableR\x05table\x12+\n" +
+	"\x05where\x18\x02 \x01(\v2\x15.epkgs.query.v1.WhereR\x05where\x12\x1c\n" +
+	"\treturning\x18\x03 \x03(\tR\treturning\x12:\n" +
+	"\n" +
+	"pagination\x18\x04 \x01(\v2\x1a.epkgs.query.v1.PaginationR\n" +
+	"pagination*\x9a\x03\n" +
+	"\bOperator\x12\x18\n" +
+	"\x14OPERATOR_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vOPERATOR_EQ\x10\x01\x12\x10\n" +
+	"\fOPERATOR_NEQ\x10\x02\x12\x0f\n" +
+	"\vOPERATOR_GT\x10\x03\x12\x10\n" +
+	"\fOPERATOR_GTE\x10\x04\x12\x0f\n" +
+	"\vOPERATOR_LT\x10\x05\x12\x10\n" +
+	"\fOPERATOR_LTE\x10\x06\x12\x11\n" +
+	"\rOPERATOR_LIKE\x10\a\x12\x15\n" +
+	"\x11OPERATOR_NOT_LIKE\x10\b\x12\x12\n" +
+	"\x0eOPERATOR_ILIKE\x10\t\x12\x0f\n" +
+	"\vOPERATOR_IN\x10\n" +
+	"\x12\x13\n" +
+	"\x0fOPERATOR_NOT_IN\x10\v\x12\x14\n" +
+	"\x10OPERATOR_BETWEEN\x10\f\x12\x18\n" +
+	"\x14OPERATOR_NOT_BETWEEN\x10\r\x12\x14\n" +
+	"\x10OPERATOR_IS_NULL\x10\x0e\x12\x18\n" +
+	"\x14OPERATOR_IS_NOT_NULL\x10\x0f\x12\x18\n" +
+	"\x14OPERATOR_STARTS_WITH\x10\x10\x12\x16\n" +
+	"\x12OPERATOR_ENDS_WITH\x10\x11\x12\x15\n" +
+	"\x11OPERATOR_CONTAINS\x10\x12*\x80\x01\n" +
+	"\x0fLogicalOperator\x12 \n" +
+	"\x1cLOGICAL_OPERATOR_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14LOGICAL_OPERATOR_AND\x10\x01\x12\x17\n" +
+	"\x13LOGICAL_OPERATOR_OR\x10\x02\x12\x18\n" +
+	"\x14LOGICAL_OPERATOR_NOT\x10\x03*x\n" +
+	"\bJoinType\x12\x19\n" +
+	"\x15JOIN_TYPE_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fJOIN_TYPE_INNER\x10\x01\x12\x12\n" +
+	"\x0eJOIN_TYPE_LEFT\x10\x02\x12\x13\n" +
+	"\x0fJOIN_TYPE_RIGHT\x10\x03\x12\x13\n" +
+	"\x0fJOIN_TYPE_CROSS\x10\x04B0Z.github.com/epkgs/query/adapter/querypb;querypbb\x06proto3"
+
+var (
+	file_query_proto_rawDescOnce sync.Once
+	file_query_proto_rawDescData []byte
+)
+
+func file_query_proto_rawDescGZIP() []byte {
+	file_query_proto_rawDescOnce.Do(func() {
+		file_query_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_query_proto_rawDesc), len(file_query_proto_rawDesc)))
+	})
+	return file_query_proto_rawDescData
+}
+
+var file_query_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_query_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_query_proto_goTypes = []any{
+	(Operator)(0),                 // 0: epkgs.query.v1.Operator
+	(LogicalOperator)(0),          // 1: epkgs.query.v1.LogicalOperator
+	(JoinType)(0),                 // 2: epkgs.query.v1.JoinType
+	(*Value)(nil),                 // 3: epkgs.query.v1.Value
+	(*ValueList)(nil),             // 4: epkgs.query.v1.ValueList
+	(*Comparison)(nil),            // 5: epkgs.query.v1.Comparison
+	(*Logical)(nil),               // 6: epkgs.query.v1.Logical
+	(*Expression)(nil),            // 7: epkgs.query.v1.Expression
+	(*Where)(nil),                 // 8: epkgs.query.v1.Where
+	(*OrderBy)(nil),               // 9: epkgs.query.v1.OrderBy
+	(*Pagination)(nil),            // 10: epkgs.query.v1.Pagination
+	(*Table)(nil),                 // 11: epkgs.query.v1.Table
+	(*Join)(nil),                  // 12: epkgs.query.v1.Join
+	(*Aggregate)(nil),             // 13: epkgs.query.v1.Aggregate
+	(*Field)(nil),                 // 14: epkgs.query.v1.Field
+	(*SelectQuery)(nil),           // 15: epkgs.query.v1.SelectQuery
+	(*Assignment)(nil),            // 16: epkgs.query.v1.Assignment
+	(*Row)(nil),                   // 17: epkgs.query.v1.Row
+	(*OnConflict)(nil),            // 18: epkgs.query.v1.OnConflict
+	(*InsertQuery)(nil),           // 19: epkgs.query.v1.InsertQuery
+	(*UpdateQuery)(nil),           // 20: epkgs.query.v1.UpdateQuery
+	(*DeleteQuery)(nil),           // 21: epkgs.query.v1.DeleteQuery
+	(structpb.NullValue)(0),       // 22: google.protobuf.NullValue
+	(*timestamppb.Timestamp)(nil), // 23: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 24: google.protobuf.Duration
+}
+var file_query_proto_depIdxs = []int32{
+	22, // 0: epkgs.query.v1.Value.null_value:type_name -> google.protobuf.NullValue
+	23, // 1: epkgs.query.v1.Value.timestamp_value:type_name -> google.protobuf.Timestamp
+	24, // 2: epkgs.query.v1.Value.duration_value:type_name -> google.protobuf.Duration
+	4,  // 3: epkgs.query.v1.Value.list_value:type_name -> epkgs.query.v1.ValueList
+	3,  // 4: epkgs.query.v1.ValueList.values:type_name -> epkgs.query.v1.Value
+	0,  // 5: epkgs.query.v1.Comparison.operator:type_name -> epkgs.query.v1.Operator
+	3,  // 6: epkgs.query.v1.Comparison.value:type_name -> epkgs.query.v1.Value
+	3,  // 7: epkgs.query.v1.Comparison.values:type_name -> epkgs.query.v1.Value
+	1,  // 8: epkgs.query.v1.Logical.operator:type_name -> epkgs.query.v1.LogicalOperator
+	7,  // 9: epkgs.query.v1.Logical.exprs:type_name -> epkgs.query.v1.Expression
+	5,  // 10: epkgs.query.v1.Expression.comparison:type_name -> epkgs.query.v1.Comparison
+	6,  // 11: epkgs.query.v1.Expression.logical:type_name -> epkgs.query.v1.Logical
+	7,  // 12: epkgs.query.v1.Where.exprs:type_name -> epkgs.query.v1.Expression
+	3,  // 13: epkgs.query.v1.Pagination.after:type_name -> epkgs.query.v1.Value
+	2,  // 14: epkgs.query.v1.Join.type:type_name -> epkgs.query.v1.JoinType
+	11, // 15: epkgs.query.v1.Join.table:type_name -> epkgs.query.v1.Table
+	8,  // 16: epkgs.query.v1.Join.on:type_name -> epkgs.query.v1.Where
+	13, // 17: epkgs.query.v1.Field.aggregate:type_name -> epkgs.query.v1.Aggregate
+	11, // 18: epkgs.query.v1.SelectQuery.table:type_name -> epkgs.query.v1.Table
+	14, // 19: epkgs.query.v1.SelectQuery.fields:type_name -> epkgs.query.v1.Field
+	12, // 20: epkgs.query.v1.SelectQuery.joins:type_name -> epkgs.query.v1.Join
+	8,  // 21: epkgs.query.v1.SelectQuery.where:type_name -> epkgs.query.v1.Where
+	8,  // 22: epkgs.query.v1.SelectQuery.having:type_name -> epkgs.query.v1.Where
+	9,  // 23: epkgs.query.v1.SelectQuery.order_bys:type_name -> epkgs.query.v1.OrderBy
+	10, // 24: epkgs.query.v1.SelectQuery.pagination:type_name -> epkgs.query.v1.Pagination
+	3,  // 25: epkgs.query.v1.Assignment.value:type_name -> epkgs.query.v1.Value
+	16, // 26: epkgs.query.v1.Row.values:type_name -> epkgs.query.v1.Assignment
+	16, // 27: epkgs.query.v1.OnConflict.do_updates:type_name -> epkgs.query.v1.Assignment
+	11, // 28: epkgs.query.v1.InsertQuery.table:type_name -> epkgs.query.v1.Table
+	17, // 29: epkgs.query.v1.InsertQuery.rows:type_name -> epkgs.query.v1.Row
+	18, // 30: epkgs.query.v1.InsertQuery.on_conflict:type_name -> epkgs.query.v1.OnConflict
+	11, // 31: epkgs.query.v1.UpdateQuery.table:type_name -> epkgs.query.v1.Table
+	16, // 32: epkgs.query.v1.UpdateQuery.values:type_name -> epkgs.query.v1.Assignment
+	8,  // 33: epkgs.query.v1.UpdateQuery.where:type_name -> epkgs.query.v1.Where
+	10, // 34: epkgs.query.v1.UpdateQuery.pagination:type_name -> epkgs.query.v1.Pagination
+	11, // 35: epkgs.query.v1.DeleteQuery.table:type_name -> epkgs.query.v1.Table
+	8,  // 36: epkgs.query.v1.DeleteQuery.where:type_name -> epkgs.query.v1.Where
+	10, // 37: epkgs.query.v1.DeleteQuery.pagination:type_name -> epkgs.query.v1.Pagination
+	38, // [38:38] is the sub-list for method output_type
+	38, // [38:38] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
+}
+
+func init() { file_query_proto_init() }
+func file_query_proto_init() {
+	if File_query_proto != nil {
+		return
+	}
+	file_query_proto_msgTypes[0].OneofWrappers = []any{
+		(*Value_NullValue)(nil),
+		(*Value_StringValue)(nil),
+		(*Value_IntValue)(nil),
+		(*Value_UintValue)(nil),
+		(*Value_DoubleValue)(nil),
+		(*Value_BoolValue)(nil),
+		(*Value_BytesValue)(nil),
+		(*Value_TimestampValue)(nil),
+		(*Value_DurationValue)(nil),
+		(*Value_ListValue)(nil),
+		(*Value_Column)(nil),
+		(*Value_Excluded)(nil),
+	}
+	file_query_proto_msgTypes[4].OneofWrappers = []any{
+		(*Expression_Comparison)(nil),
+		(*Expression_Logical)(nil),
+	}
+	file_query_proto_msgTypes[7].OneofWrappers = []any{}
+	file_query_proto_msgTypes[11].OneofWrappers = []any{
+		(*Field_Column)(nil),
+		(*Field_Aggregate)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_query_proto_rawDesc), len(file_query_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_query_proto_goTypes,
+		DependencyIndexes: file_query_proto_depIdxs,
+		EnumInfos:         file_query_proto_enumTypes,
+		MessageInfos:      file_query_proto_msgTypes,
+	}.Build()
+	File_query_proto = out.File
+	file_query_proto_goTypes = nil
+	file_query_proto_depIdxs = nil
+}
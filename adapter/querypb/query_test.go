@@ -0,0 +1,147 @@
+package querypb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/epkgs/query"
+	"github.com/epkgs/query/clause"
+)
+
+func TestSelect(t *testing.T) {
+	q := query.Table("users u").
+		LeftJoin("orders o", query.On("o.user_id", "u.id"), clause.Gt{Col: "o.amount", Val: 0}).
+		CrossJoin("regions").
+		Eq("u.status", "active").
+		OrWhere(clause.Gt{Col: "u.age", Val: 18}).
+		Desc("cnt").
+		Limit(10).
+		Offset(20).
+		Select("u.city", "u.country AS c").
+		SelectExpr(clause.As(clause.Count("*"), "cnt"), clause.Sum("o.amount")).
+		GroupBy("u.city", "u.country").
+		Having("cnt", ">", 5)
+
+	m, err := ToSelect(q)
+	if err != nil {
+		t.Fatalf("ToSelect() error = %v", err)
+	}
+	got, err := FromSelect(transport(t, m, &SelectQuery{}))
+	if err != nil {
+		t.Fatalf("FromSelect() error = %v", err)
+	}
+	assertSameSQL(t, q, got)
+}
+
+func TestSelect_Keyset(t *testing.T) {
+	q := query.Table("users").Desc("created_at").Asc("id").AfterValues("2024-01-01", 42).Limit(20).Select()
+
+	m, err := ToSelect(q)
+	if err != nil {
+		t.Fatalf("ToSelect() error = %v", err)
+	}
+	got, err := FromSelect(transport(t, m, &SelectQuery{}))
+	if err != nil {
+		t.Fatalf("FromSelect() error = %v", err)
+	}
+	assertSameSQL(t, q, got)
+}
+
+func TestSelect_Errors(t *testing.T) {
+	if _, err := ToSelect(query.Table("users").Where("name", "~", "x").Select()); !errors.Is(err, query.ErrInvalidOperator) {
+		t.Errorf("ToSelect() error = %v, want ErrInvalidOperator", err)
+	}
+	if _, err := ToSelect(query.Table("users").Where(clause.Raw("1 = 1")).Select()); !errors.Is(err, ErrUnsupportedExpr) {
+		t.Errorf("ToSelect(raw) error = %v, want ErrUnsupportedExpr", err)
+	}
+
+	// 聚合函数名只允许内置函数，避免写入任意 SQL
+	m := &SelectQuery{
+		Table:  &Table{Name: "users"},
+		Fields: []*Field{{Expr: &Field_Aggregate{Aggregate: &Aggregate{Func: "pg_sleep(10); --"}}}},
+	}
+	if _, err := FromSelect(m); !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("FromSelect(aggregate) error = %v, want ErrInvalidMessage", err)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	q := query.Table("users").
+		Insert(map[string]any{"name": "John", "age": 30, "id": 1}, map[string]any{"name": "Jane", "email": nil}).
+		Omit("id").
+		OnConflict("email").DoUpdate(map[string]any{"updated": true, "name": clause.Excluded{Column: "name"}}).
+		Returning("id")
+
+	m, err := ToInsert(q)
+	if err != nil {
+		t.Fatalf("ToInsert() error = %v", err)
+	}
+	got, err := FromInsert(transport(t, m, &InsertQuery{}))
+	if err != nil {
+		t.Fatalf("FromInsert() error = %v", err)
+	}
+	assertSameSQL(t, q, got)
+
+	q = query.Table("users").Insert("email", "a@example.com").OnConflict("email").DoNothing()
+	m, err = ToInsert(q)
+	if err != nil {
+		t.Fatalf("ToInsert() error = %v", err)
+	}
+	got, err = FromInsert(transport(t, m, &InsertQuery{}))
+	if err != nil {
+		t.Fatalf("FromInsert() error = %v", err)
+	}
+	assertSameSQL(t, q, got)
+}
+
+func TestUpdate(t *testing.T) {
+	q := query.Table("users").
+		Eq("id", 1).
+		Limit(1).
+		Update(map[string]any{"name": "John", "age": 30, "deleted_at": nil}).
+		Only("name", "deleted_at").
+		Returning("id", "name")
+
+	m, err := ToUpdate(q)
+	if err != nil {
+		t.Fatalf("ToUpdate() error = %v", err)
+	}
+	if len(m.GetValues()) != 2 || m.GetValues()[0].GetColumn() != "deleted_at" {
+		t.Errorf("ToUpdate() values = %v, want sorted and filtered", m.GetValues())
+	}
+	got, err := FromUpdate(transport(t, m, &UpdateQuery{}))
+	if err != nil {
+		t.Fatalf("FromUpdate() error = %v", err)
+	}
+	assertSameSQL(t, q, got)
+}
+
+func TestDelete(t *testing.T) {
+	q := query.Table("users").
+		Where(clause.Or(clause.Lt{Col: "last_login", Val: "2020-01-01"}, clause.IsNull{Col: "email"})).
+		Delete().
+		Returning("*")
+
+	m, err := ToDelete(q)
+	if err != nil {
+		t.Fatalf("ToDelete() error = %v", err)
+	}
+	got, err := FromDelete(transport(t, m, &DeleteQuery{}))
+	if err != nil {
+		t.Fatalf("FromDelete() error = %v", err)
+	}
+	assertSameSQL(t, q, got)
+
+	// 行数限制不能在转换中丢失，否则会删除所有匹配的行
+	m, err = ToDelete(query.Table("users").Eq("status", "banned").Limit(10).Delete())
+	if err != nil {
+		t.Fatalf("ToDelete() error = %v", err)
+	}
+	got, err = FromDelete(transport(t, m, &DeleteQuery{}))
+	if err != nil {
+		t.Fatalf("FromDelete() error = %v", err)
+	}
+	if limit := got.PaginationExpr().Limit; limit == nil || *limit != 10 {
+		t.Errorf("FromDelete() limit = %v, want 10", limit)
+	}
+}
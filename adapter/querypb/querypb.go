@@ -0,0 +1,184 @@
+// Package querypb 提供查询的 Protobuf 表示（query.proto），以及与 query/clause 查询组件之间的转换，
+// 用于通过 gRPC 在服务之间传递查询：一个服务使用链式 API 构建查询，另一个服务还原后执行，
+// 无需经过字符串形式的过滤语法。
+//
+// 典型工作流程：
+//
+//	// 调用方：构建查询并转换为 Protobuf 消息
+//	q := query.Table("users").Eq("status", "active").Desc("created_at").Limit(20).Select("id", "name")
+//	msg, err := querypb.ToSelect(q)
+//
+//	// 执行方：还原查询
+//	sq, err := querypb.FromSelect(msg)
+//	sql, vars, err := dialect.Build(dialect.PostgreSQL, sq)
+//
+// 原生 SQL、子查询和按表达式排序无法转换，返回 ErrUnsupportedExpr。
+package querypb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative query.proto
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/epkgs/query/clause"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	// ErrUnsupportedExpr 表示表达式或值无法转换为 Protobuf 消息
+	ErrUnsupportedExpr = errors.New("expression unsupported by protobuf")
+	// ErrInvalidMessage 表示 Protobuf 消息无法还原为查询
+	ErrInvalidMessage = errors.New("invalid query message")
+)
+
+// ToValue 将值转换为 Protobuf 消息。
+// nil 为 null_value，切片为 list_value，clause.Column 为 column，clause.Excluded 为 excluded；
+// 实现了 driver.Valuer 的值使用 Value() 的结果，指针使用其指向的值。
+func ToValue(v any) (*Value, error) {
+	if valuer, ok := v.(driver.Valuer); ok {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return ToValue(nil)
+		}
+		val, err := valuer.Value()
+		if err != nil {
+			return nil, err
+		}
+		v = val
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return &Value{Kind: &Value_NullValue{NullValue: structpb.NullValue_NULL_VALUE}}, nil
+	case clause.Column:
+		return &Value{Kind: &Value_Column{Column: v.Name}}, nil
+	case clause.Excluded:
+		return &Value{Kind: &Value_Excluded{Excluded: v.Column}}, nil
+	case clause.Expression:
+		return nil, fmt.Errorf("%w: value %T", ErrUnsupportedExpr, v)
+	case []byte:
+		return &Value{Kind: &Value_BytesValue{BytesValue: v}}, nil
+	case time.Time:
+		return &Value{Kind: &Value_TimestampValue{TimestampValue: timestamppb.New(v)}}, nil
+	case time.Duration:
+		return &Value{Kind: &Value_DurationValue{DurationValue: durationpb.New(v)}}, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return ToValue(nil)
+		}
+		return ToValue(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		vals := make([]any, rv.Len())
+		for i := range vals {
+			vals[i] = rv.Index(i).Interface()
+		}
+		list, err := toValues(vals)
+		if err != nil {
+			return nil, err
+		}
+		return &Value{Kind: &Value_ListValue{ListValue: &ValueList{Values: list}}}, nil
+	case reflect.String:
+		return &Value{Kind: &Value_StringValue{StringValue: rv.String()}}, nil
+	case reflect.Bool:
+		return &Value{Kind: &Value_BoolValue{BoolValue: rv.Bool()}}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Value{Kind: &Value_IntValue{IntValue: rv.Int()}}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Value{Kind: &Value_UintValue{UintValue: rv.Uint()}}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Value{Kind: &Value_DoubleValue{DoubleValue: rv.Float()}}, nil
+	}
+	return nil, fmt.Errorf("%w: value type %T", ErrUnsupportedExpr, v)
+}
+
+// FromValue 将 Protobuf 消息还原为值。
+// 整数为 int64/uint64，浮点数为 float64，时间为 UTC 的 time.Time，list_value 为 []any；
+// 未设置的消息为 nil。
+func FromValue(m *Value) (any, error) {
+	switch k := m.GetKind().(type) {
+	case nil, *Value_NullValue:
+		return nil, nil
+	case *Value_StringValue:
+		return k.StringValue, nil
+	case *Value_IntValue:
+		return k.IntValue, nil
+	case *Value_UintValue:
+		return k.UintValue, nil
+	case *Value_DoubleValue:
+		return k.DoubleValue, nil
+	case *Value_BoolValue:
+		return k.BoolValue, nil
+	case *Value_BytesValue:
+		return k.BytesValue, nil
+	case *Value_TimestampValue:
+		if err := k.TimestampValue.CheckValid(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+		}
+		return k.TimestampValue.AsTime(), nil
+	case *Value_DurationValue:
+		if err := k.DurationValue.CheckValid(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+		}
+		return k.DurationValue.AsDuration(), nil
+	case *Value_ListValue:
+		vals, err := fromValues(k.ListValue.GetValues())
+		if err != nil {
+			return nil, err
+		}
+		if vals == nil {
+			vals = []any{}
+		}
+		return vals, nil
+	case *Value_Column:
+		if k.Column == "" {
+			return nil, fmt.Errorf("%w: empty column", ErrInvalidMessage)
+		}
+		return clause.Column{Name: k.Column}, nil
+	case *Value_Excluded:
+		if k.Excluded == "" {
+			return nil, fmt.Errorf("%w: empty excluded column", ErrInvalidMessage)
+		}
+		return clause.Excluded{Column: k.Excluded}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown value kind %T", ErrInvalidMessage, m.GetKind())
+}
+
+func toValues(vals []any) ([]*Value, error) {
+	if vals == nil {
+		return nil, nil
+	}
+	result := make([]*Value, len(vals))
+	for i, v := range vals {
+		m, err := ToValue(v)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = m
+	}
+	return result, nil
+}
+
+func fromValues(list []*Value) ([]any, error) {
+	if list == nil {
+		return nil, nil
+	}
+	vals := make([]any, len(list))
+	for i, m := range list {
+		v, err := FromValue(m)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = v
+	}
+	return vals, nil
+}
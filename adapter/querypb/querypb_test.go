@@ -0,0 +1,87 @@
+package querypb
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/epkgs/query/clause"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValue(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	name := "John"
+	var nilPtr *string
+
+	tests := []struct {
+		name string
+		in   any
+		want any
+	}{
+		{"nil", nil, nil},
+		{"string", "John", "John"},
+		{"int", 18, int64(18)},
+		{"int32", int32(-5), int64(-5)},
+		{"uint", uint8(7), uint64(7)},
+		{"float", float32(1.5), float64(1.5)},
+		{"bool", true, true},
+		{"bytes", []byte("abc"), []byte("abc")},
+		{"time", ts, ts},
+		{"duration", 90 * time.Second, 90 * time.Second},
+		{"pointer", &name, "John"},
+		{"nil pointer", nilPtr, nil},
+		{"valuer", sql.NullString{String: "x", Valid: true}, "x"},
+		{"null valuer", sql.NullInt64{}, nil},
+		{"slice", []int{1, 2}, []any{int64(1), int64(2)}},
+		{"empty slice", []string{}, []any{}},
+		{"column", clause.Column{Name: "u.id"}, clause.Column{Name: "u.id"}},
+		{"excluded", clause.Excluded{Column: "name"}, clause.Excluded{Column: "name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ToValue(tt.in)
+			if err != nil {
+				t.Fatalf("ToValue() error = %v", err)
+			}
+
+			// 经过序列化，模拟 gRPC 传输
+			data, err := proto.Marshal(m)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			decoded := &Value{}
+			if err := proto.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			got, err := FromValue(decoded)
+			if err != nil {
+				t.Fatalf("FromValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValue_Errors(t *testing.T) {
+	if _, err := ToValue(clause.Raw("NOW()")); !errors.Is(err, ErrUnsupportedExpr) {
+		t.Errorf("ToValue(Raw) error = %v, want ErrUnsupportedExpr", err)
+	}
+	if _, err := ToValue(map[string]any{"a": 1}); !errors.Is(err, ErrUnsupportedExpr) {
+		t.Errorf("ToValue(map) error = %v, want ErrUnsupportedExpr", err)
+	}
+	if _, err := FromValue(&Value{Kind: &Value_Column{}}); !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("FromValue(empty column) error = %v, want ErrInvalidMessage", err)
+	}
+
+	// 未设置的值视为 NULL
+	if got, err := FromValue(nil); err != nil || got != nil {
+		t.Errorf("FromValue(nil) = %v, %v, want nil", got, err)
+	}
+}
@@ -0,0 +1,277 @@
+package querypb
+
+import (
+	"fmt"
+
+	"github.com/epkgs/query/clause"
+)
+
+// 比较操作符与 Protobuf 枚举的对应关系
+var (
+	operators = map[clause.Operator]Operator{
+		clause.OpEQ:         Operator_OPERATOR_EQ,
+		clause.OpNEQ:        Operator_OPERATOR_NEQ,
+		clause.OpGT:         Operator_OPERATOR_GT,
+		clause.OpGTE:        Operator_OPERATOR_GTE,
+		clause.OpLT:         Operator_OPERATOR_LT,
+		clause.OpLTE:        Operator_OPERATOR_LTE,
+		clause.OpLIKE:       Operator_OPERATOR_LIKE,
+		clause.OpNOTLIKE:    Operator_OPERATOR_NOT_LIKE,
+		clause.OpILIKE:      Operator_OPERATOR_ILIKE,
+		clause.OpIN:         Operator_OPERATOR_IN,
+		clause.OpNOTIN:      Operator_OPERATOR_NOT_IN,
+		clause.OpBETWEEN:    Operator_OPERATOR_BETWEEN,
+		clause.OpNOTBETWEEN: Operator_OPERATOR_NOT_BETWEEN,
+		clause.OpISNULL:     Operator_OPERATOR_IS_NULL,
+		clause.OpISNOTNULL:  Operator_OPERATOR_IS_NOT_NULL,
+		clause.OpSTARTSWITH: Operator_OPERATOR_STARTS_WITH,
+		clause.OpENDSWITH:   Operator_OPERATOR_ENDS_WITH,
+		clause.OpCONTAINS:   Operator_OPERATOR_CONTAINS,
+	}
+	logicalOperators = map[clause.LogicalOperator]LogicalOperator{
+		clause.LogicAnd: LogicalOperator_LOGICAL_OPERATOR_AND,
+		clause.LogicOr:  LogicalOperator_LOGICAL_OPERATOR_OR,
+		clause.LogicNot: LogicalOperator_LOGICAL_OPERATOR_NOT,
+	}
+)
+
+// ToWhere 将 WHERE 条件转换为 Protobuf 消息，保留表达式树的结构，
+// 还原后构建的 SQL 与转换前一致。原生 SQL、子查询等表达式返回 ErrUnsupportedExpr。
+func ToWhere(where clause.Where) (*Where, error) {
+	exprs, err := toExpressions(where.Exprs)
+	if err != nil {
+		return nil, err
+	}
+	return &Where{Exprs: exprs}, nil
+}
+
+// FromWhere 将 Protobuf 消息还原为 WHERE 条件，未设置的消息为空条件
+func FromWhere(m *Where) (clause.Where, error) {
+	exprs, err := fromExpressions(m.GetExprs())
+	if err != nil {
+		return clause.Where{}, err
+	}
+	return clause.Where{Exprs: exprs}, nil
+}
+
+// ToExpression 将单个条件表达式转换为 Protobuf 消息
+func ToExpression(e clause.Expression) (*Expression, error) {
+	switch e := e.(type) {
+	case clause.LogicalExpression:
+		exprs, err := toExpressions(e.SubExprs())
+		if err != nil {
+			return nil, err
+		}
+		return &Expression{Expr: &Expression_Logical{Logical: &Logical{
+			Operator: logicalOperators[e.Operator()],
+			Exprs:    exprs,
+		}}}, nil
+
+	case clause.ComparisonExpression:
+		op, ok := operators[e.Operator()]
+		if !ok {
+			return nil, fmt.Errorf("%w: operator %q", ErrUnsupportedExpr, e.Operator())
+		}
+		cmp := &Comparison{Operator: op, Column: e.Column()}
+		switch e.Operator() {
+		case clause.OpISNULL, clause.OpISNOTNULL:
+		case clause.OpIN, clause.OpNOTIN, clause.OpBETWEEN, clause.OpNOTBETWEEN:
+			vals, err := toValues(e.Value().([]any))
+			if err != nil {
+				return nil, err
+			}
+			cmp.Values = vals
+		default:
+			val, err := ToValue(e.Value())
+			if err != nil {
+				return nil, err
+			}
+			cmp.Value = val
+		}
+		return &Expression{Expr: &Expression_Comparison{Comparison: cmp}}, nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedExpr, e)
+}
+
+// FromExpression 将 Protobuf 消息还原为单个条件表达式
+func FromExpression(m *Expression) (clause.Expression, error) {
+	switch e := m.GetExpr().(type) {
+	case *Expression_Logical:
+		return fromLogical(e.Logical)
+	case *Expression_Comparison:
+		return fromComparison(e.Comparison)
+	}
+	return nil, fmt.Errorf("%w: empty expression", ErrInvalidMessage)
+}
+
+func toExpressions(exprs []clause.Expression) ([]*Expression, error) {
+	result := make([]*Expression, 0, len(exprs))
+	for _, e := range exprs {
+		if e == nil {
+			continue
+		}
+		m, err := ToExpression(e)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+func fromExpressions(list []*Expression) ([]clause.Expression, error) {
+	exprs := make([]clause.Expression, 0, len(list))
+	for _, m := range list {
+		e, err := FromExpression(m)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+	return exprs, nil
+}
+
+func fromLogical(m *Logical) (clause.Expression, error) {
+	exprs, err := fromExpressions(m.GetExprs())
+	if err != nil {
+		return nil, err
+	}
+	if len(exprs) == 0 {
+		return nil, fmt.Errorf("%w: empty %s", ErrInvalidMessage, m.GetOperator())
+	}
+	switch m.GetOperator() {
+	case LogicalOperator_LOGICAL_OPERATOR_AND:
+		return clause.AndExpr{Exprs: exprs}, nil
+	case LogicalOperator_LOGICAL_OPERATOR_OR:
+		return clause.OrExpr{Exprs: exprs}, nil
+	case LogicalOperator_LOGICAL_OPERATOR_NOT:
+		return clause.NotExpr{Exprs: exprs}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown logical operator %s", ErrInvalidMessage, m.GetOperator())
+}
+
+func fromComparison(m *Comparison) (clause.Expression, error) {
+	col := m.GetColumn()
+	if col == "" {
+		return nil, fmt.Errorf("%w: %s without column", ErrInvalidMessage, m.GetOperator())
+	}
+
+	switch m.GetOperator() {
+	case Operator_OPERATOR_IS_NULL:
+		return clause.IsNull{Col: col}, nil
+	case Operator_OPERATOR_IS_NOT_NULL:
+		return clause.IsNotNull{Col: col}, nil
+	case Operator_OPERATOR_IN, Operator_OPERATOR_NOT_IN:
+		vals, err := fromValues(m.GetValues())
+		if err != nil {
+			return nil, err
+		}
+		if m.GetOperator() == Operator_OPERATOR_IN {
+			return clause.IN{Col: col, Vals: vals}, nil
+		}
+		return clause.NotIn{Col: col, Vals: vals}, nil
+	case Operator_OPERATOR_BETWEEN, Operator_OPERATOR_NOT_BETWEEN:
+		vals, err := fromValues(m.GetValues())
+		if err != nil {
+			return nil, err
+		}
+		if len(vals) != 2 {
+			return nil, fmt.Errorf("%w: %s requires 2 values", ErrInvalidMessage, m.GetOperator())
+		}
+		if m.GetOperator() == Operator_OPERATOR_BETWEEN {
+			return clause.Between{Col: col, From: vals[0], To: vals[1]}, nil
+		}
+		return clause.NotBetween{Col: col, From: vals[0], To: vals[1]}, nil
+	}
+
+	val, err := FromValue(m.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	eq := clause.Eq{Col: col, Val: val}
+	switch m.GetOperator() {
+	case Operator_OPERATOR_EQ:
+		return eq, nil
+	case Operator_OPERATOR_NEQ:
+		return clause.Neq(eq), nil
+	case Operator_OPERATOR_GT:
+		return clause.Gt(eq), nil
+	case Operator_OPERATOR_GTE:
+		return clause.Gte(eq), nil
+	case Operator_OPERATOR_LT:
+		return clause.Lt(eq), nil
+	case Operator_OPERATOR_LTE:
+		return clause.Lte(eq), nil
+	case Operator_OPERATOR_LIKE:
+		return clause.Like(eq), nil
+	case Operator_OPERATOR_NOT_LIKE:
+		return clause.NotLike(eq), nil
+	case Operator_OPERATOR_ILIKE:
+		return clause.ILike(eq), nil
+	case Operator_OPERATOR_STARTS_WITH:
+		return clause.StartsWith(eq), nil
+	case Operator_OPERATOR_ENDS_WITH:
+		return clause.EndsWith(eq), nil
+	case Operator_OPERATOR_CONTAINS:
+		return clause.Contains(eq), nil
+	}
+	return nil, fmt.Errorf("%w: unknown operator %s", ErrInvalidMessage, m.GetOperator())
+}
+
+// ToOrderBys 将排序条件转换为 Protobuf 消息，按表达式排序返回 ErrUnsupportedExpr
+func ToOrderBys(orders clause.OrderBys) ([]*OrderBy, error) {
+	result := make([]*OrderBy, 0, len(orders))
+	for _, o := range orders {
+		if o == nil {
+			continue
+		}
+		if o.Expr != nil {
+			return nil, fmt.Errorf("%w: order by expression %T", ErrUnsupportedExpr, o.Expr)
+		}
+		result = append(result, &OrderBy{Column: o.Column, Desc: o.Desc})
+	}
+	return result, nil
+}
+
+// FromOrderBys 将 Protobuf 消息还原为排序条件
+func FromOrderBys(list []*OrderBy) (clause.OrderBys, error) {
+	orders := make(clause.OrderBys, 0, len(list))
+	for _, m := range list {
+		if m.GetColumn() == "" {
+			return nil, fmt.Errorf("%w: order by without column", ErrInvalidMessage)
+		}
+		orders = append(orders, &clause.OrderBy{Column: m.GetColumn(), Desc: m.GetDesc()})
+	}
+	return orders, nil
+}
+
+// ToPagination 将分页参数转换为 Protobuf 消息
+func ToPagination(p clause.Pagination) (*Pagination, error) {
+	after, err := toValues(p.After)
+	if err != nil {
+		return nil, err
+	}
+	m := &Pagination{Offset: int64(p.Offset), After: after}
+	if p.Limit != nil {
+		limit := int64(*p.Limit)
+		m.Limit = &limit
+	}
+	return m, nil
+}
+
+// FromPagination 将 Protobuf 消息还原为分页参数，未设置的消息为不分页
+func FromPagination(m *Pagination) (clause.Pagination, error) {
+	if m.GetOffset() < 0 || m.GetLimit() < 0 {
+		return clause.Pagination{}, fmt.Errorf("%w: negative limit or offset", ErrInvalidMessage)
+	}
+	after, err := fromValues(m.GetAfter())
+	if err != nil {
+		return clause.Pagination{}, err
+	}
+	p := clause.Pagination{Offset: int(m.GetOffset()), After: after}
+	if m != nil && m.Limit != nil {
+		limit := int(*m.Limit)
+		p.Limit = &limit
+	}
+	return p, nil
+}
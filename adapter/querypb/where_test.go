@@ -0,0 +1,171 @@
+package querypb
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/epkgs/query/clause"
+	"google.golang.org/protobuf/proto"
+)
+
+// transport 将消息序列化后再反序列化，模拟 gRPC 传输
+func transport[M proto.Message](t *testing.T, m M, decoded M) M {
+	t.Helper()
+	data, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if err := proto.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	return decoded
+}
+
+// assertSameSQL 断言两个表达式构建的 SQL 和参数一致。
+// 使用 clause.String 内联参数后比较，与方言无关（如 UPDATE 同时带 LIMIT 和 RETURNING 时没有方言能构建），
+// 整数类型的变化（如 int 变为 int64）不影响比较。
+func assertSameSQL(t *testing.T, want, got clause.Expression) {
+	t.Helper()
+	if wantSQL, gotSQL := clause.String(want), clause.String(got); gotSQL != wantSQL {
+		t.Errorf("SQL = %q, want %q", gotSQL, wantSQL)
+	}
+}
+
+func TestWhere(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		where clause.Where
+	}{
+		{
+			name:  "comparisons",
+			where: clause.Where{Exprs: []clause.Expression{clause.Eq{Col: "status", Val: "active"}, clause.Gte{Col: "age", Val: 18}, clause.Lt{Col: "created_at", Val: ts}}},
+		},
+		{
+			name: "in and between",
+			where: clause.Where{Exprs: []clause.Expression{
+				clause.IN{Col: "id", Vals: []any{1, 2, 3}},
+				clause.NotIn{Col: "role", Vals: []any{"guest"}},
+				clause.Between{Col: "score", From: 1.5, To: 9.5},
+				clause.NotBetween{Col: "age", From: 10, To: 20},
+			}},
+		},
+		{
+			name: "null and like",
+			where: clause.Where{Exprs: []clause.Expression{
+				clause.IsNull{Col: "deleted_at"},
+				clause.IsNotNull{Col: "email"},
+				clause.Like{Col: "name", Val: "J%"},
+				clause.NotLike{Col: "name", Val: "%x"},
+				clause.ILike{Col: "email", Val: "%@example.com"},
+				clause.StartsWith{Col: "code", Val: "A"},
+				clause.EndsWith{Col: "code", Val: "Z"},
+				clause.Contains{Col: "title", Val: "go"},
+				clause.Neq{Col: "tags", Val: []string{"a", "b"}},
+			}},
+		},
+		{
+			name: "logical",
+			where: clause.Where{Exprs: []clause.Expression{
+				clause.Eq{Col: "tenant_id", Val: 1},
+				clause.Or(clause.Eq{Col: "status", Val: "active"}, clause.And(clause.Gt{Col: "age", Val: 18}, clause.Lte{Col: "age", Val: 60})),
+				clause.Not(clause.Eq{Col: "role", Val: "admin"}, clause.IsNull{Col: "email"}),
+				clause.Or(clause.Eq{Col: "vip", Val: true}),
+			}},
+		},
+		{
+			name:  "column value",
+			where: clause.Where{Exprs: []clause.Expression{clause.Eq{Col: "o.user_id", Val: clause.Column{Name: "u.id"}}}},
+		},
+		{
+			name:  "empty",
+			where: clause.Where{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ToWhere(tt.where)
+			if err != nil {
+				t.Fatalf("ToWhere() error = %v", err)
+			}
+			got, err := FromWhere(transport(t, m, &Where{}))
+			if err != nil {
+				t.Fatalf("FromWhere() error = %v", err)
+			}
+			assertSameSQL(t, tt.where, got)
+		})
+	}
+}
+
+func TestWhere_Errors(t *testing.T) {
+	unsupported := []clause.Where{
+		{Exprs: []clause.Expression{clause.Raw("age > ?", 18)}},
+		{Exprs: []clause.Expression{clause.Or(clause.Eq{Col: "a", Val: 1}, clause.Raw("b IS TRUE"))}},
+		{Exprs: []clause.Expression{clause.Eq{Col: "a", Val: clause.Raw("NOW()")}}},
+	}
+	for _, where := range unsupported {
+		if _, err := ToWhere(where); !errors.Is(err, ErrUnsupportedExpr) {
+			t.Errorf("ToWhere(%v) error = %v, want ErrUnsupportedExpr", where, err)
+		}
+	}
+
+	invalid := []*Where{
+		{Exprs: []*Expression{{}}},
+		{Exprs: []*Expression{{Expr: &Expression_Comparison{Comparison: &Comparison{Operator: Operator_OPERATOR_EQ}}}}},
+		{Exprs: []*Expression{{Expr: &Expression_Comparison{Comparison: &Comparison{Column: "a"}}}}},
+		{Exprs: []*Expression{{Expr: &Expression_Comparison{Comparison: &Comparison{Operator: Operator_OPERATOR_BETWEEN, Column: "a", Values: []*Value{{}}}}}}},
+		{Exprs: []*Expression{{Expr: &Expression_Logical{Logical: &Logical{Operator: LogicalOperator_LOGICAL_OPERATOR_AND}}}}},
+	}
+	for _, m := range invalid {
+		if _, err := FromWhere(m); !errors.Is(err, ErrInvalidMessage) {
+			t.Errorf("FromWhere(%v) error = %v, want ErrInvalidMessage", m, err)
+		}
+	}
+}
+
+func TestOrderBysAndPagination(t *testing.T) {
+	orders := clause.OrderBys{{Column: "created_at", Desc: true}, {Column: "id"}}
+	list, err := ToOrderBys(orders)
+	if err != nil {
+		t.Fatalf("ToOrderBys() error = %v", err)
+	}
+	gotOrders, err := FromOrderBys(list)
+	if err != nil {
+		t.Fatalf("FromOrderBys() error = %v", err)
+	}
+	if !reflect.DeepEqual(gotOrders, orders) {
+		t.Errorf("FromOrderBys() = %v, want %v", gotOrders, orders)
+	}
+
+	if _, err := ToOrderBys(clause.OrderBys{{Expr: clause.Raw("RANDOM()")}}); !errors.Is(err, ErrUnsupportedExpr) {
+		t.Errorf("ToOrderBys(expr) error = %v, want ErrUnsupportedExpr", err)
+	}
+
+	limit := 20
+	pagination := clause.Pagination{Limit: &limit, Offset: 40, After: []any{"2024-01-01", int64(42)}}
+	m, err := ToPagination(pagination)
+	if err != nil {
+		t.Fatalf("ToPagination() error = %v", err)
+	}
+	gotPagination, err := FromPagination(transport(t, m, &Pagination{}))
+	if err != nil {
+		t.Fatalf("FromPagination() error = %v", err)
+	}
+	if !reflect.DeepEqual(gotPagination, pagination) {
+		t.Errorf("FromPagination() = %+v, want %+v", gotPagination, pagination)
+	}
+
+	// 未设置 limit 时不限制条数，与 limit 为 0 区分
+	gotPagination, err = FromPagination(&Pagination{Offset: 10})
+	if err != nil || gotPagination.Limit != nil || gotPagination.Offset != 10 {
+		t.Errorf("FromPagination(no limit) = %+v, %v", gotPagination, err)
+	}
+
+	if _, err := FromPagination(&Pagination{Offset: -1}); !errors.Is(err, ErrInvalidMessage) {
+		t.Errorf("FromPagination(negative) error = %v, want ErrInvalidMessage", err)
+	}
+}
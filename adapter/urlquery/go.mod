@@ -0,0 +1,7 @@
+module github.com/epkgs/query/adapter/urlquery
+
+go 1.18
+
+require github.com/epkgs/query v0.0.0-00010101000000-000000000000
+
+replace github.com/epkgs/query => ../../
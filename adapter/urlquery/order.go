@@ -0,0 +1,90 @@
+package urlquery
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/epkgs/query"
+	"github.com/epkgs/query/clause"
+)
+
+// ParseOrderBys 解析排序参数（默认为 sort），多个字段以分隔符分隔，
+// 字段名前的 "-" 表示降序，"+" 或不写表示升序。多次传递排序参数时按出现的顺序追加。
+// 设置 Schema 时按 Schema 校验并改写字段名，未声明或不可排序的字段返回 *query.FieldError；
+// 未设置 Schema 时，字段策略（WithFields、WithAnyField）不允许的字段返回包装 query.ErrUnknownField 的 *query.FieldError。
+//
+// 示例:
+//
+//	values, _ := url.ParseQuery("sort=-created_at,name")
+//	orderBys, err := urlquery.ParseOrderBys(values, urlquery.WithFields("created_at", "name"))
+//	// orderBys: created_at DESC, name ASC
+func ParseOrderBys(values url.Values, opts ...Option) (clause.OrderBys, error) {
+	opt := newOptions(opts)
+
+	var orderBys clause.OrderBys
+	for _, raw := range values[opt.sortParam] {
+		for _, field := range strings.Split(raw, opt.separator) {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+
+			desc := false
+			switch field[0] {
+			case '-':
+				desc, field = true, field[1:]
+			case '+':
+				field = field[1:]
+			}
+			if field == "" {
+				return nil, fmt.Errorf("%w: %s=%q", ErrInvalidParam, opt.sortParam, raw)
+			}
+			if _, ok := opt.lookupField(field); !ok && opt.schema == nil {
+				return nil, &query.FieldError{Field: field, Err: query.ErrUnknownField}
+			}
+			orderBys = append(orderBys, &clause.OrderBy{Column: field, Desc: desc})
+		}
+	}
+
+	if opt.schema != nil && len(orderBys) > 0 {
+		return opt.schema.OrderBys(orderBys)
+	}
+	return orderBys, nil
+}
+
+// EncodeOrderBys 将 clause.OrderBys 编码为排序参数写入 values，是 ParseOrderBys 的逆向转换。
+// 按表达式排序（OrderBy.Expr）和无法按原样解析回来的字段名（如包含分隔符）返回 ErrUnsupportedExpr。
+//
+// 示例:
+//
+//	values := url.Values{}
+//	err := urlquery.EncodeOrderBys(values, query.Desc("created_at").Asc("name").OrderByExpr())
+//	// values: sort=-created_at,name
+func EncodeOrderBys(values url.Values, orders clause.OrderBys, opts ...Option) error {
+	opt := newOptions(opts)
+
+	fields := make([]string, 0, len(orders))
+	for _, order := range orders {
+		if order == nil {
+			continue
+		}
+		if order.Expr != nil || order.Column == "" {
+			return fmt.Errorf("%w: order by expression", ErrUnsupportedExpr)
+		}
+		if strings.Contains(order.Column, opt.separator) || strings.TrimSpace(order.Column) != order.Column ||
+			strings.HasPrefix(order.Column, "-") || strings.HasPrefix(order.Column, "+") {
+			return fmt.Errorf("%w: order by %q", ErrUnsupportedExpr, order.Column)
+		}
+		if order.Desc {
+			fields = append(fields, "-"+order.Column)
+		} else {
+			fields = append(fields, order.Column)
+		}
+	}
+
+	if len(fields) > 0 {
+		values.Set(opt.sortParam, strings.Join(fields, opt.separator))
+	}
+	return nil
+}
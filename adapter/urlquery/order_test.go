@@ -0,0 +1,78 @@
+package urlquery
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/epkgs/query"
+	"github.com/epkgs/query/clause"
+)
+
+func TestParseOrderBys(t *testing.T) {
+	values, _ := url.ParseQuery("sort=-created_at,+name,&sort=id")
+	orderBys, err := ParseOrderBys(values, WithFields("created_at", "name", "id"))
+	if err != nil {
+		t.Fatalf("ParseOrderBys() error = %v", err)
+	}
+	want := clause.OrderBys{
+		{Column: "created_at", Desc: true},
+		{Column: "name"},
+		{Column: "id"},
+	}
+	if !reflect.DeepEqual(orderBys, want) {
+		t.Errorf("ParseOrderBys() = %v, want %v", orderBys, want)
+	}
+
+	values, _ = url.ParseQuery("order=-")
+	if _, err := ParseOrderBys(values, WithSortParam("order"), WithAnyField()); !errors.Is(err, ErrInvalidParam) {
+		t.Errorf("ParseOrderBys(-) error = %v, want ErrInvalidParam", err)
+	}
+
+	// 未声明的字段不允许排序
+	values, _ = url.ParseQuery("sort=password_hash")
+	for _, opts := range [][]Option{nil, {WithFields("name")}} {
+		if _, err := ParseOrderBys(values, opts...); !errors.Is(err, query.ErrUnknownField) {
+			t.Errorf("ParseOrderBys(password_hash) error = %v, want ErrUnknownField", err)
+		}
+	}
+}
+
+func TestParseOrderBys_Schema(t *testing.T) {
+	schema := query.NewSchema(
+		query.Field{Name: "created", Column: "created_at", Sortable: true},
+		query.Field{Name: "name"},
+	)
+
+	values, _ := url.ParseQuery("sort=-created")
+	orderBys, err := ParseOrderBys(values, WithSchema(schema))
+	if err != nil {
+		t.Fatalf("ParseOrderBys() error = %v", err)
+	}
+	if len(orderBys) != 1 || orderBys[0].Column != "created_at" || !orderBys[0].Desc {
+		t.Errorf("ParseOrderBys() = %v, want created_at DESC", orderBys)
+	}
+
+	values, _ = url.ParseQuery("sort=name")
+	if _, err := ParseOrderBys(values, WithSchema(schema)); !errors.Is(err, query.ErrFieldNotSortable) {
+		t.Errorf("ParseOrderBys(name) error = %v, want ErrFieldNotSortable", err)
+	}
+}
+
+func TestEncodeOrderBys(t *testing.T) {
+	values := url.Values{}
+	if err := EncodeOrderBys(values, query.Desc("created_at").Asc("name").OrderByExpr()); err != nil {
+		t.Fatalf("EncodeOrderBys() error = %v", err)
+	}
+	if got := values.Get("sort"); got != "-created_at,name" {
+		t.Errorf("EncodeOrderBys() = %s, want -created_at,name", got)
+	}
+
+	if err := EncodeOrderBys(url.Values{}, clause.OrderBys{{Expr: clause.Raw("RANDOM()")}}); !errors.Is(err, ErrUnsupportedExpr) {
+		t.Errorf("EncodeOrderBys(expr) error = %v, want ErrUnsupportedExpr", err)
+	}
+	if err := EncodeOrderBys(url.Values{}, clause.OrderBys{{Column: "a,b"}}); !errors.Is(err, ErrUnsupportedExpr) {
+		t.Errorf("EncodeOrderBys(separator) error = %v, want ErrUnsupportedExpr", err)
+	}
+}
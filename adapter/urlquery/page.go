@@ -0,0 +1,101 @@
+package urlquery
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+
+	"github.com/epkgs/query/clause"
+)
+
+// ParsePagination 解析分页参数（默认为 page 和 per_page），page 从 1 开始。
+// 未传递 per_page 时使用 WithPageSize 的默认值，超过最大值或为 0 时使用最大值；
+// 未设置最大值且每页条数为 0 时不限制条数，此时只允许第 1 页。
+// 参数不是整数、page 小于 1、per_page 为负数或偏移量溢出时返回 ErrInvalidParam。
+//
+// 示例:
+//
+//	values, _ := url.ParseQuery("page=3&per_page=20")
+//	p, err := urlquery.ParsePagination(values)
+//	// p: LIMIT 20 OFFSET 40
+func ParsePagination(values url.Values, opts ...Option) (clause.Pagination, error) {
+	opt := newOptions(opts)
+
+	page, err := parseInt(values, opt.pageParam, 1)
+	if err != nil {
+		return clause.Pagination{}, err
+	}
+	if page < 1 {
+		return clause.Pagination{}, fmt.Errorf("%w: %s=%d", ErrInvalidParam, opt.pageParam, page)
+	}
+
+	size, err := parseInt(values, opt.perPageParam, opt.defaultSize)
+	if err != nil {
+		return clause.Pagination{}, err
+	}
+	if size < 0 {
+		return clause.Pagination{}, fmt.Errorf("%w: %s=%d", ErrInvalidParam, opt.perPageParam, size)
+	}
+	if opt.maxSize > 0 && (size == 0 || size > opt.maxSize) {
+		size = opt.maxSize
+	}
+
+	if size == 0 {
+		if page > 1 {
+			return clause.Pagination{}, fmt.Errorf("%w: %s=%d without %s", ErrInvalidParam, opt.pageParam, page, opt.perPageParam)
+		}
+		return clause.Pagination{}, nil
+	}
+	if page-1 > math.MaxInt/size {
+		return clause.Pagination{}, fmt.Errorf("%w: %s=%d overflows offset", ErrInvalidParam, opt.pageParam, page)
+	}
+	return clause.Pagination{Limit: &size, Offset: (page - 1) * size}, nil
+}
+
+// parseInt 解析整数参数，未传递时返回 defaultValue
+func parseInt(values url.Values, key string, defaultValue int) (int, error) {
+	raw := values.Get(key)
+	if raw == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s=%q", ErrInvalidParam, key, raw)
+	}
+	return n, nil
+}
+
+// EncodePagination 将 clause.Pagination 编码为分页参数写入 values，是 ParsePagination 的逆向转换。
+// 第 1 页只写入 per_page。未设置 Limit 时不能有 Offset，Offset 须为 Limit 的整数倍，
+// 键集分页（After）无法表示，均返回 ErrUnsupportedExpr。
+//
+// 示例:
+//
+//	values := url.Values{}
+//	err := urlquery.EncodePagination(values, query.Limit(20).Offset(40).PaginationExpr())
+//	// values: page=3&per_page=20
+func EncodePagination(values url.Values, p clause.Pagination, opts ...Option) error {
+	opt := newOptions(opts)
+
+	if len(p.After) > 0 {
+		return fmt.Errorf("%w: keyset pagination", ErrUnsupportedExpr)
+	}
+
+	if p.Limit == nil || *p.Limit <= 0 {
+		if p.Offset > 0 {
+			return fmt.Errorf("%w: offset %d without limit", ErrUnsupportedExpr, p.Offset)
+		}
+		return nil
+	}
+
+	size := *p.Limit
+	if p.Offset%size != 0 {
+		return fmt.Errorf("%w: offset %d is not a multiple of limit %d", ErrUnsupportedExpr, p.Offset, size)
+	}
+	values.Set(opt.perPageParam, strconv.Itoa(size))
+	if page := p.Offset/size + 1; page > 1 {
+		values.Set(opt.pageParam, strconv.Itoa(page))
+	}
+	return nil
+}
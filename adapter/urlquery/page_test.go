@@ -0,0 +1,77 @@
+package urlquery
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/epkgs/query/clause"
+)
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		query  string
+		opts   []Option
+		limit  int
+		offset int
+	}{
+		{"page=3&per_page=20", nil, 20, 40},
+		{"", nil, 0, 0},
+		{"page=2", []Option{WithPageSize(25, 100)}, 25, 25},
+		{"per_page=500", []Option{WithPageSize(25, 100)}, 100, 0},
+		{"per_page=0", []Option{WithPageSize(25, 100)}, 100, 0},
+		{"p=2&size=5", []Option{WithPageParams("p", "size")}, 5, 5},
+	}
+
+	for _, tt := range tests {
+		values, _ := url.ParseQuery(tt.query)
+		p, err := ParsePagination(values, tt.opts...)
+		if err != nil {
+			t.Fatalf("ParsePagination(%q) error = %v", tt.query, err)
+		}
+		limit := 0
+		if p.Limit != nil {
+			limit = *p.Limit
+		}
+		if limit != tt.limit || p.Offset != tt.offset {
+			t.Errorf("ParsePagination(%q) = limit %d offset %d, want limit %d offset %d", tt.query, limit, p.Offset, tt.limit, tt.offset)
+		}
+	}
+
+	for _, q := range []string{"page=0", "page=x", "per_page=-1", "page=2", "page=9223372036854775807&per_page=20"} {
+		values, _ := url.ParseQuery(q)
+		if _, err := ParsePagination(values); !errors.Is(err, ErrInvalidParam) {
+			t.Errorf("ParsePagination(%q) error = %v, want ErrInvalidParam", q, err)
+		}
+	}
+}
+
+func TestEncodePagination(t *testing.T) {
+	limit := 20
+
+	values := url.Values{}
+	if err := EncodePagination(values, clause.Pagination{Limit: &limit, Offset: 40}); err != nil {
+		t.Fatalf("EncodePagination() error = %v", err)
+	}
+	if got := values.Encode(); got != "page=3&per_page=20" {
+		t.Errorf("EncodePagination() = %s, want page=3&per_page=20", got)
+	}
+
+	values = url.Values{}
+	if err := EncodePagination(values, clause.Pagination{Limit: &limit}); err != nil {
+		t.Fatalf("EncodePagination() error = %v", err)
+	}
+	if got := values.Encode(); got != "per_page=20" {
+		t.Errorf("EncodePagination() = %s, want per_page=20", got)
+	}
+
+	for _, p := range []clause.Pagination{
+		{Limit: &limit, Offset: 10},
+		{Offset: 10},
+		{Limit: &limit, After: []any{1}},
+	} {
+		if err := EncodePagination(url.Values{}, p); !errors.Is(err, ErrUnsupportedExpr) {
+			t.Errorf("EncodePagination(%+v) error = %v, want ErrUnsupportedExpr", p, err)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Package urlquery 提供了 URL 查询参数（url.Values）与 query/clause 查询组件之间的转换，
+// 适用于不使用 AIP 的 REST API。
+//
+// 可过滤和排序的字段由 WithSchema 或 WithFields 声明，未声明字段策略时忽略全部过滤参数，
+// 排序字段返回 *query.FieldError。
+//
+// 过滤参数的写法由 Syntax 决定，排序和分页参数与写法无关：
+//
+//	?age[gte]=18&status[in]=a,b&sort=-created_at,name&page=2&per_page=20   // Bracket（默认）
+//	?age__gte=18&status__in=a,b&sort=-created_at,name&page=2&per_page=20    // DoubleUnderscore（Django 风格）
+//	?age:gte=18&status:in=a,b&sort=-created_at,name&page=2&per_page=20      // Colon
+//
+// 典型工作流程：
+//
+//	// 1. 解析请求参数
+//	params, err := urlquery.Parse(r.URL.Query(), urlquery.WithSchema(schema), urlquery.WithPageSize(20, 100))
+//
+//	// 2. 通过 GORM/Ent 适配器应用到 ORM 查询
+//	db.Scopes(gormadapter.QueryScope(params.Where, params.OrderBys, params.Pagination)).Find(&users)
+//
+//	// 3. 生成下一页的链接
+//	params.Pagination.Offset += 20
+//	values, err := urlquery.Encode(params)
+package urlquery
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+
+	"github.com/epkgs/query"
+	"github.com/epkgs/query/clause"
+)
+
+var (
+	// ErrInvalidParam 表示查询参数的格式不正确，如 page 不是正整数、between 的值不是两个
+	ErrInvalidParam = errors.New("invalid query parameter")
+	// ErrUnsupportedExpr 表示条件、排序或分页无法表示为查询参数，如 OR 条件、原生 SQL、键集分页
+	ErrUnsupportedExpr = errors.New("expression unsupported by url query")
+)
+
+// Syntax 表示过滤参数中字段与操作符的写法
+type Syntax int
+
+const (
+	// Bracket 以方括号写操作符，如 age[gte]=18
+	Bracket Syntax = iota
+	// DoubleUnderscore 以双下划线写操作符，如 age__gte=18（Django 风格）。
+	// 嵌套字段同样以双下划线分隔，如 author__name__contains=john 对应字段 "author.name"
+	DoubleUnderscore
+	// Colon 以冒号写操作符，如 age:gte=18
+	Colon
+)
+
+// options 为解析和编码的选项
+type options struct {
+	syntax       Syntax
+	schema       *query.Schema
+	fields       map[string]bool
+	anyField     bool
+	separator    string
+	sortParam    string
+	pageParam    string
+	perPageParam string
+	defaultSize  int
+	maxSize      int
+}
+
+// Option 设置解析和编码的选项
+type Option func(*options)
+
+func newOptions(opts []Option) *options {
+	opt := &options{
+		syntax:       Bracket,
+		separator:    ",",
+		sortParam:    "sort",
+		pageParam:    "page",
+		perPageParam: "per_page",
+	}
+	for _, o := range opts {
+		o(opt)
+	}
+	return opt
+}
+
+// WithSyntax 设置过滤参数的写法，默认为 Bracket
+func WithSyntax(syntax Syntax) Option {
+	return func(o *options) {
+		o.syntax = syntax
+	}
+}
+
+// WithSchema 设置字段策略：只有 Schema 中声明的字段作为过滤条件，其余参数忽略；
+// 参数值按字段的 Type 转换（如 "18" 转换为 int），字段名改写为列名，
+// 操作符、值和排序字段按 Schema 校验，校验失败时返回 *query.FieldError。
+func WithSchema(schema *query.Schema) Option {
+	return func(o *options) {
+		o.schema = schema
+	}
+}
+
+// WithFields 设置允许过滤和排序的字段（参数中的字段名），其余过滤参数忽略，其余排序字段返回 *query.FieldError。
+// 参数值保留为字符串，需要类型转换、操作符限制或列名改写时使用 WithSchema；设置了 Schema 时忽略此选项。
+func WithFields(fields ...string) Option {
+	return func(o *options) {
+		o.fields = make(map[string]bool, len(fields))
+		for _, f := range fields {
+			o.fields[f] = true
+		}
+	}
+}
+
+// WithAnyField 允许按任意字段过滤和排序，参数值保留为字符串。
+//
+// 该模式是不安全的：客户端可以按任意列（包括 password_hash 等敏感列）过滤和排序，
+// 跟踪参数（如 utm_source）、防缓存参数等同样会成为查询条件。只应用于可信的调用方或测试，
+// 对外的接口应使用 WithSchema 或 WithFields。
+func WithAnyField() Option {
+	return func(o *options) {
+		o.anyField = true
+	}
+}
+
+// WithSeparator 设置 in、nin、between 的值和 sort 中多个字段的分隔符，默认为 ","
+func WithSeparator(separator string) Option {
+	return func(o *options) {
+		o.separator = separator
+	}
+}
+
+// WithSortParam 设置排序参数名，默认为 "sort"
+func WithSortParam(name string) Option {
+	return func(o *options) {
+		o.sortParam = name
+	}
+}
+
+// WithPageParams 设置页码和每页条数的参数名，默认为 "page" 和 "per_page"
+func WithPageParams(page, perPage string) Option {
+	return func(o *options) {
+		o.pageParam = page
+		o.perPageParam = perPage
+	}
+}
+
+// WithPageSize 设置每页条数：未传递 per_page 时使用 defaultSize；
+// 超过 maxSize 时截断为 maxSize（maxSize 为 0 表示不限制）。
+func WithPageSize(defaultSize, maxSize int) Option {
+	return func(o *options) {
+		o.defaultSize = defaultSize
+		o.maxSize = maxSize
+	}
+}
+
+// lookupField 按字段策略（Schema、WithFields、WithAnyField）查找过滤字段，返回字段类型；
+// 不允许的字段返回 false，未设置字段策略时所有字段都不允许。
+func (o *options) lookupField(name string) (reflect.Type, bool) {
+	switch {
+	case o.schema != nil:
+		f, ok := o.schema.Field(name)
+		return f.Type, ok
+	case o.fields != nil:
+		return nil, o.fields[name]
+	}
+	return nil, o.anyField
+}
+
+// reserved 判断参数是否为排序或分页参数
+func (o *options) reserved(key string) bool {
+	return key == o.sortParam || key == o.pageParam || key == o.perPageParam
+}
+
+// Params 为从查询参数解析出的过滤条件、排序和分页
+type Params struct {
+	Where      clause.Where
+	OrderBys   clause.OrderBys
+	Pagination clause.Pagination
+}
+
+// Parse 解析查询参数中的过滤条件、排序和分页，见 ParseWhere、ParseOrderBys 和 ParsePagination。
+//
+// 示例:
+//
+//	values, _ := url.ParseQuery("age[gte]=18&status[in]=a,b&sort=-created_at,name&page=2&per_page=20")
+//	params, err := urlquery.Parse(values, urlquery.WithFields("age", "status", "created_at", "name"))
+//	// params.Where:      age >= '18' AND status IN ('a', 'b')
+//	// params.OrderBys:   created_at DESC, name
+//	// params.Pagination: LIMIT 20 OFFSET 20
+func Parse(values url.Values, opts ...Option) (Params, error) {
+	where, err := ParseWhere(values, opts...)
+	if err != nil {
+		return Params{}, err
+	}
+	orderBys, err := ParseOrderBys(values, opts...)
+	if err != nil {
+		return Params{}, err
+	}
+	pagination, err := ParsePagination(values, opts...)
+	if err != nil {
+		return Params{}, err
+	}
+	return Params{Where: where, OrderBys: orderBys, Pagination: pagination}, nil
+}
+
+// Encode 将过滤条件、排序和分页编码为查询参数，是 Parse 的逆向转换，
+// 见 EncodeWhere、EncodeOrderBys 和 EncodePagination。
+//
+// 编码使用条件中的列名，不按 Schema 改写；需要输出客户端字段名时，
+// 应编码经过 Schema 改写前的条件。
+func Encode(p Params, opts ...Option) (url.Values, error) {
+	values := url.Values{}
+	if err := EncodeWhere(values, p.Where, opts...); err != nil {
+		return nil, err
+	}
+	if err := EncodeOrderBys(values, p.OrderBys, opts...); err != nil {
+		return nil, err
+	}
+	if err := EncodePagination(values, p.Pagination, opts...); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
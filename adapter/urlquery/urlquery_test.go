@@ -0,0 +1,49 @@
+package urlquery
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/epkgs/query"
+)
+
+func TestParseAndEncode(t *testing.T) {
+	q := query.Table("users").
+		Gte("age", "18").
+		In("status", "a", "b").
+		Contains("name", "j%o").
+		Desc("created_at").
+		Asc("name").
+		Limit(20).
+		Offset(20)
+	want := Params{Where: q.WhereExpr(), OrderBys: q.OrderByExpr(), Pagination: q.PaginationExpr()}
+
+	for _, syntax := range []Syntax{Bracket, DoubleUnderscore, Colon} {
+		values, err := Encode(want, WithSyntax(syntax))
+		if err != nil {
+			t.Fatalf("Encode() error = %v", err)
+		}
+
+		// 经过 URL 编码和解码后还原
+		values, err = url.ParseQuery(values.Encode())
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Parse(values, WithSyntax(syntax), WithFields("age", "status", "name", "created_at"))
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+
+		// 解析时按参数名排序，条件的顺序可能不同
+		if got.Where.Canonical() != want.Where.Canonical() {
+			t.Errorf("syntax %d: Where = %s, want %s", syntax, got.Where, want.Where)
+		}
+		if !reflect.DeepEqual(got.OrderBys, want.OrderBys) {
+			t.Errorf("syntax %d: OrderBys = %v, want %v", syntax, got.OrderBys, want.OrderBys)
+		}
+		if *got.Pagination.Limit != *want.Pagination.Limit || got.Pagination.Offset != want.Pagination.Offset {
+			t.Errorf("syntax %d: Pagination = %+v, want %+v", syntax, got.Pagination, want.Pagination)
+		}
+	}
+}
@@ -0,0 +1,453 @@
+package urlquery
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/epkgs/query"
+	"github.com/epkgs/query/clause"
+)
+
+// 过滤参数中的操作符名
+const (
+	opEq       = "eq"
+	opIn       = "in"
+	opNin      = "nin"
+	opBetween  = "between"
+	opNbetween = "nbetween"
+	opNull     = "null"
+)
+
+// operators 为操作符名与 clause 操作符的对应关系，null 单独处理
+var operators = map[string]clause.Operator{
+	opEq:         clause.OpEQ,
+	"ne":         clause.OpNEQ,
+	"gt":         clause.OpGT,
+	"gte":        clause.OpGTE,
+	"lt":         clause.OpLT,
+	"lte":        clause.OpLTE,
+	opIn:         clause.OpIN,
+	opNin:        clause.OpNOTIN,
+	"like":       clause.OpLIKE,
+	"nlike":      clause.OpNOTLIKE,
+	"ilike":      clause.OpILIKE,
+	"startswith": clause.OpSTARTSWITH,
+	"endswith":   clause.OpENDSWITH,
+	"contains":   clause.OpCONTAINS,
+	opBetween:    clause.OpBETWEEN,
+	opNbetween:   clause.OpNOTBETWEEN,
+}
+
+// aliases 为 Django 风格的操作符别名，只用于解析
+var aliases = map[string]string{
+	"exact":  opEq,
+	"isnull": opNull,
+	"range":  opBetween,
+}
+
+// operatorNames 为 clause 操作符对应的操作符名，用于编码
+var operatorNames = func() map[clause.Operator]string {
+	names := make(map[clause.Operator]string, len(operators))
+	for name, op := range operators {
+		names[op] = name
+	}
+	return names
+}()
+
+// lookupOperator 查找操作符名，返回规范的操作符名
+func lookupOperator(name string) (string, bool) {
+	if alias, ok := aliases[name]; ok {
+		return alias, true
+	}
+	if _, ok := operators[name]; ok || name == opNull {
+		return name, true
+	}
+	return "", false
+}
+
+// ParseWhere 将查询参数中的过滤参数解析为 clause.Where，多个参数以 AND 组合，按参数名排序。
+// 排序和分页参数（默认为 sort、page、per_page）不作为过滤条件。
+//
+// 支持的操作符（未写操作符时为 eq）：
+//
+//	eq, ne, gt, gte, lt, lte          比较，如 age[gte]=18
+//	in, nin                           列表，值以分隔符分隔，如 status[in]=a,b
+//	between, nbetween                 区间，如 age[between]=18,30
+//	like, nlike, ilike                模式匹配，值原样作为模式
+//	startswith, endswith, contains    前缀、后缀、包含匹配，值中的通配符会被转义
+//	null                              true 为 IS NULL，false 为 IS NOT NULL
+//
+// 另支持 Django 风格的别名 exact、isnull 和 range。未知的操作符返回 query.ErrInvalidOperator。
+//
+// 只有字段策略允许的字段作为过滤条件，其余参数忽略：设置 Schema 时见 WithSchema；
+// 设置 WithFields 时只解析其中的字段，值为字符串；未设置字段策略时忽略全部过滤参数，
+// 除非使用不安全的 WithAnyField。
+func ParseWhere(values url.Values, opts ...Option) (clause.Where, error) {
+	opt := newOptions(opts)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if !opt.reserved(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	where := clause.Where{}
+	for _, key := range keys {
+		field, op, err := opt.splitKey(key)
+		if err != nil {
+			return clause.Where{}, err
+		}
+
+		typ, ok := opt.lookupField(field)
+		if !ok {
+			continue
+		}
+
+		for _, raw := range values[key] {
+			e, err := opt.parseCondition(field, op, raw, typ)
+			if err != nil {
+				return clause.Where{}, err
+			}
+			where.Exprs = append(where.Exprs, e)
+		}
+	}
+
+	if opt.schema != nil {
+		return opt.schema.Where(where)
+	}
+	return where, nil
+}
+
+// splitKey 按写法拆分参数名中的字段和操作符，未写操作符时返回 eq
+func (o *options) splitKey(key string) (field, op string, err error) {
+	switch o.syntax {
+	case DoubleUnderscore:
+		parts := strings.Split(key, "__")
+		if len(parts) > 1 {
+			if name, ok := lookupOperator(parts[len(parts)-1]); ok {
+				op = name
+				parts = parts[:len(parts)-1]
+			}
+		}
+		for _, part := range parts {
+			if part == "" {
+				return "", "", fmt.Errorf("%w: %q", ErrInvalidParam, key)
+			}
+		}
+		field = strings.Join(parts, ".")
+	case Colon:
+		field = key
+		if i := strings.LastIndexByte(key, ':'); i >= 0 {
+			field, op = key[:i], key[i+1:]
+		}
+	default:
+		field = key
+		if i := strings.LastIndexByte(key, '['); i >= 0 && strings.HasSuffix(key, "]") {
+			field, op = key[:i], key[i+1:len(key)-1]
+		}
+	}
+
+	if field == "" {
+		return "", "", fmt.Errorf("%w: %q", ErrInvalidParam, key)
+	}
+	if op == "" {
+		return field, opEq, nil
+	}
+	name, ok := lookupOperator(op)
+	if !ok {
+		return "", "", fmt.Errorf("%w: %q", query.ErrInvalidOperator, key)
+	}
+	return field, name, nil
+}
+
+// joinKey 按写法生成参数名，op 为空时只写字段
+func (o *options) joinKey(field, op string) string {
+	switch o.syntax {
+	case DoubleUnderscore:
+		field = strings.ReplaceAll(field, ".", "__")
+		if op != "" {
+			return field + "__" + op
+		}
+	case Colon:
+		if op != "" {
+			return field + ":" + op
+		}
+	default:
+		if op != "" {
+			return field + "[" + op + "]"
+		}
+	}
+	return field
+}
+
+// parseCondition 将单个过滤参数转换为比较表达式
+func (o *options) parseCondition(field, op, raw string, typ reflect.Type) (clause.Expression, error) {
+	if op == opNull {
+		isNull, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %s %q", ErrInvalidParam, field, op, raw)
+		}
+		if isNull {
+			return clause.IsNull{Col: field}, nil
+		}
+		return clause.IsNotNull{Col: field}, nil
+	}
+
+	operator := operators[op]
+	switch op {
+	case opIn, opNin, opBetween, opNbetween:
+		parts := strings.Split(raw, o.separator)
+		vals := make([]any, len(parts))
+		for i, part := range parts {
+			v, err := parseValue(typ, part)
+			if err != nil {
+				return nil, &query.FieldError{Field: field, Operator: operator, Err: err}
+			}
+			vals[i] = v
+		}
+
+		switch op {
+		case opIn:
+			return clause.IN{Col: field, Vals: vals}, nil
+		case opNin:
+			return clause.NotIn{Col: field, Vals: vals}, nil
+		}
+		if len(vals) != 2 {
+			return nil, fmt.Errorf("%w: %s %s requires 2 values", ErrInvalidParam, field, op)
+		}
+		if op == opBetween {
+			return clause.Between{Col: field, From: vals[0], To: vals[1]}, nil
+		}
+		return clause.NotBetween{Col: field, From: vals[0], To: vals[1]}, nil
+	}
+
+	val, err := parseValue(typ, raw)
+	if err != nil {
+		return nil, &query.FieldError{Field: field, Operator: operator, Err: err}
+	}
+	eq := clause.Eq{Col: field, Val: val}
+	switch operator {
+	case clause.OpNEQ:
+		return clause.Neq(eq), nil
+	case clause.OpGT:
+		return clause.Gt(eq), nil
+	case clause.OpGTE:
+		return clause.Gte(eq), nil
+	case clause.OpLT:
+		return clause.Lt(eq), nil
+	case clause.OpLTE:
+		return clause.Lte(eq), nil
+	case clause.OpLIKE:
+		return clause.Like(eq), nil
+	case clause.OpNOTLIKE:
+		return clause.NotLike(eq), nil
+	case clause.OpILIKE:
+		return clause.ILike(eq), nil
+	case clause.OpSTARTSWITH:
+		return clause.StartsWith(eq), nil
+	case clause.OpENDSWITH:
+		return clause.EndsWith(eq), nil
+	case clause.OpCONTAINS:
+		return clause.Contains(eq), nil
+	}
+	return eq, nil
+}
+
+// parseValue 将参数值转换为字段类型的值，t 为 nil 或无法转换的类型时保留字符串。
+// 实现了 encoding.TextUnmarshaler 的类型（如 time.Time）按其 UnmarshalText 转换。
+func parseValue(t reflect.Type, s string) (any, error) {
+	if t == nil {
+		return s, nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	v := reflect.New(t)
+	if u, ok := v.Interface().(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText([]byte(s)); err != nil {
+			return nil, fmt.Errorf("%w: %v", query.ErrInvalidFieldValue, err)
+		}
+		return v.Elem().Interface(), nil
+	}
+
+	elem := v.Elem()
+	switch t.Kind() {
+	case reflect.String:
+		elem.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not a bool", query.ErrInvalidFieldValue, s)
+		}
+		elem.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, t.Bits())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not a %s", query.ErrInvalidFieldValue, s, t)
+		}
+		elem.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, t.Bits())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not a %s", query.ErrInvalidFieldValue, s, t)
+		}
+		elem.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not a %s", query.ErrInvalidFieldValue, s, t)
+		}
+		elem.SetFloat(f)
+	default:
+		return s, nil
+	}
+	return elem.Interface(), nil
+}
+
+// EncodeWhere 将 clause.Where 编码为过滤参数写入 values，是 ParseWhere 的逆向转换。
+// 条件先按 Where.Normalize 规范化，只支持以 AND 组合的比较表达式；
+// OR 条件、无法下推的 NOT、原生 SQL、子查询，以及列表值中包含分隔符的条件返回 ErrUnsupportedExpr。
+//
+// 示例:
+//
+//	values := url.Values{}
+//	err := urlquery.EncodeWhere(values, clause.Where{Exprs: []clause.Expression{
+//	    clause.Gte{Col: "age", Val: 18},
+//	    clause.IN{Col: "status", Vals: []any{"a", "b"}},
+//	}})
+//	// values: age[gte]=18&status[in]=a,b
+func EncodeWhere(values url.Values, where clause.Where, opts ...Option) error {
+	opt := newOptions(opts)
+
+	for _, e := range where.Normalize().Exprs {
+		cmp, ok := e.(clause.ComparisonExpression)
+		if !ok {
+			return fmt.Errorf("%w: %T", ErrUnsupportedExpr, e)
+		}
+
+		op, raw, err := opt.formatCondition(cmp)
+		if err != nil {
+			return err
+		}
+		key, err := opt.encodeKey(cmp.Column(), op)
+		if err != nil {
+			return err
+		}
+		values.Add(key, raw)
+	}
+	return nil
+}
+
+// encodeKey 生成参数名：eq 条件只写字段，除非与排序、分页参数重名或无法按原样解析回来
+func (o *options) encodeKey(field, op string) (string, error) {
+	if op == opEq {
+		key := o.joinKey(field, "")
+		if f, p, err := o.splitKey(key); err == nil && !o.reserved(key) && f == field && p == opEq {
+			return key, nil
+		}
+	}
+
+	key := o.joinKey(field, op)
+	if f, p, err := o.splitKey(key); err != nil || f != field || p != op {
+		return "", fmt.Errorf("%w: field %q", ErrUnsupportedExpr, field)
+	}
+	return key, nil
+}
+
+// formatCondition 返回比较表达式的操作符名和参数值
+func (o *options) formatCondition(cmp clause.ComparisonExpression) (string, string, error) {
+	operator, value := cmp.Operator(), cmp.Value()
+
+	switch operator {
+	case clause.OpISNULL:
+		return opNull, "true", nil
+	case clause.OpISNOTNULL:
+		return opNull, "false", nil
+	case clause.OpEQ, clause.OpNEQ:
+		// Eq/Neq 的值为 nil 时构建为 IS NULL/IS NOT NULL，为切片时构建为 IN/NOT IN
+		if value == nil {
+			return opNull, strconv.FormatBool(operator == clause.OpEQ), nil
+		}
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+			if operator == clause.OpEQ {
+				operator = clause.OpIN
+			} else {
+				operator = clause.OpNOTIN
+			}
+		}
+	}
+
+	op, ok := operatorNames[operator]
+	if !ok {
+		return "", "", fmt.Errorf("%w: operator %s", ErrUnsupportedExpr, operator)
+	}
+
+	switch operator {
+	case clause.OpIN, clause.OpNOTIN, clause.OpBETWEEN, clause.OpNOTBETWEEN:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return "", "", fmt.Errorf("%w: %s %s value %T", ErrUnsupportedExpr, cmp.Column(), operator, value)
+		}
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			s, err := formatValue(rv.Index(i).Interface())
+			if err != nil {
+				return "", "", fmt.Errorf("%w: %s %s", err, cmp.Column(), operator)
+			}
+			if strings.Contains(s, o.separator) {
+				return "", "", fmt.Errorf("%w: %s %s value %q contains separator", ErrUnsupportedExpr, cmp.Column(), operator, s)
+			}
+			parts[i] = s
+		}
+		return op, strings.Join(parts, o.separator), nil
+	}
+
+	s, err := formatValue(value)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %s %s", err, cmp.Column(), operator)
+	}
+	return op, s, nil
+}
+
+// formatValue 将值格式化为参数值，是 parseValue 的逆向转换
+func formatValue(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case clause.Expression:
+		return "", fmt.Errorf("%w: value %T", ErrUnsupportedExpr, v)
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrUnsupportedExpr, err)
+		}
+		return string(text), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if !rv.IsNil() {
+			return formatValue(rv.Elem().Interface())
+		}
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("%w: value %T", ErrUnsupportedExpr, v)
+}
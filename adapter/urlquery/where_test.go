@@ -0,0 +1,180 @@
+package urlquery
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/epkgs/query"
+	"github.com/epkgs/query/clause"
+)
+
+func TestParseWhere(t *testing.T) {
+	tests := []struct {
+		name   string
+		syntax Syntax
+		query  string
+		want   string
+	}{
+		{
+			name:  "bracket",
+			query: "age[gte]=18&status[in]=a,b&name=John&email[null]=false&sort=-id&page=2&per_page=10",
+			want:  `age >= '18' AND email IS NOT NULL AND name = 'John' AND status IN ('a', 'b')`,
+		},
+		{
+			name:   "double underscore",
+			syntax: DoubleUnderscore,
+			query:  "age__range=18,30&author__name__contains=jo&role__exact=admin&created_at__isnull=true",
+			want:   `age BETWEEN '18' AND '30' AND author.name LIKE '%jo%' ESCAPE '!' AND created_at IS NULL AND role = 'admin'`,
+		},
+		{
+			name:   "colon",
+			syntax: Colon,
+			query:  "age:lt=30&status:nin=a,b&name:startswith=J&tag:ne=x",
+			want:   `age < '30' AND name LIKE 'J%' ESCAPE '!' AND status NOT IN ('a', 'b') AND tag <> 'x'`,
+		},
+		{
+			name:  "repeated",
+			query: "age[gt]=18&age[gt]=20",
+			want:  `age > '18' AND age > '20'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			where, err := ParseWhere(values, WithSyntax(tt.syntax), WithAnyField())
+			if err != nil {
+				t.Fatalf("ParseWhere() error = %v", err)
+			}
+			if got := where.String(); got != tt.want {
+				t.Errorf("ParseWhere() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWhere_Fields(t *testing.T) {
+	values, _ := url.ParseQuery("utm_source=x&password_hash[startswith]=a&name=John&_=1700000000")
+
+	// 未设置字段策略时忽略全部过滤参数
+	where, err := ParseWhere(values)
+	if err != nil {
+		t.Fatalf("ParseWhere() error = %v", err)
+	}
+	if len(where.Exprs) != 0 {
+		t.Errorf("ParseWhere() = %s, want no conditions", where)
+	}
+
+	where, err = ParseWhere(values, WithFields("name"))
+	if err != nil {
+		t.Fatalf("ParseWhere() error = %v", err)
+	}
+	if got, expected := where.String(), `name = 'John'`; got != expected {
+		t.Errorf("ParseWhere() = %s, want %s", got, expected)
+	}
+}
+
+func TestParseWhere_Schema(t *testing.T) {
+	schema := query.NewSchema(
+		query.Field{Name: "age", Column: "user_age", Type: reflect.TypeOf(0)},
+		query.Field{Name: "active", Type: reflect.TypeOf(false)},
+		query.Field{Name: "created_at", Type: reflect.TypeOf(time.Time{})},
+		query.Field{Name: "name", Type: reflect.TypeOf(""), Operators: []clause.Operator{clause.OpEQ}},
+	)
+
+	values, _ := url.ParseQuery("age[between]=18,30&active=true&created_at[gte]=2024-01-02T03:04:05Z&utm_source=mail")
+	where, err := ParseWhere(values, WithSchema(schema))
+	if err != nil {
+		t.Fatalf("ParseWhere() error = %v", err)
+	}
+	expected := `active = TRUE AND user_age BETWEEN 18 AND 30 AND created_at >= '2024-01-02T03:04:05Z'`
+	if got := where.String(); got != expected {
+		t.Errorf("ParseWhere() = %s, want %s", got, expected)
+	}
+
+	var fieldErr *query.FieldError
+	values, _ = url.ParseQuery("age[gt]=eighteen")
+	if _, err := ParseWhere(values, WithSchema(schema)); !errors.As(err, &fieldErr) || !errors.Is(err, query.ErrInvalidFieldValue) {
+		t.Errorf("ParseWhere(invalid value) error = %v, want ErrInvalidFieldValue", err)
+	}
+	values, _ = url.ParseQuery("name[like]=J%25")
+	if _, err := ParseWhere(values, WithSchema(schema)); !errors.Is(err, query.ErrOperatorNotAllowed) {
+		t.Errorf("ParseWhere(operator) error = %v, want ErrOperatorNotAllowed", err)
+	}
+}
+
+func TestParseWhere_Errors(t *testing.T) {
+	tests := []struct {
+		query string
+		want  error
+	}{
+		{"age[foo]=1", query.ErrInvalidOperator},
+		{"[gte]=1", ErrInvalidParam},
+		{"age[between]=1", ErrInvalidParam},
+		{"email[null]=maybe", ErrInvalidParam},
+	}
+	for _, tt := range tests {
+		values, _ := url.ParseQuery(tt.query)
+		if _, err := ParseWhere(values, WithAnyField()); !errors.Is(err, tt.want) {
+			t.Errorf("ParseWhere(%q) error = %v, want %v", tt.query, err, tt.want)
+		}
+	}
+}
+
+func TestEncodeWhere(t *testing.T) {
+	where := clause.Where{Exprs: []clause.Expression{
+		clause.Gte{Col: "age", Val: 18},
+		clause.IN{Col: "status", Vals: []any{"a", "b"}},
+		clause.Eq{Col: "author.name", Val: "John"},
+		clause.Not(clause.IsNull{Col: "email"}),
+		clause.Eq{Col: "sort", Val: "x"},
+	}}
+
+	tests := []struct {
+		syntax Syntax
+		want   string
+	}{
+		{Bracket, "age[gte]=18&author.name=John&email[null]=false&sort[eq]=x&status[in]=a,b"},
+		{DoubleUnderscore, "age__gte=18&author__name=John&email__null=false&sort__eq=x&status__in=a,b"},
+		{Colon, "age:gte=18&author.name=John&email:null=false&sort:eq=x&status:in=a,b"},
+	}
+
+	for _, tt := range tests {
+		values := url.Values{}
+		if err := EncodeWhere(values, where, WithSyntax(tt.syntax)); err != nil {
+			t.Fatalf("EncodeWhere() error = %v", err)
+		}
+		got, _ := url.QueryUnescape(values.Encode())
+		if got != tt.want {
+			t.Errorf("EncodeWhere(%d) = %s, want %s", tt.syntax, got, tt.want)
+		}
+
+		parsed, err := ParseWhere(values, WithSyntax(tt.syntax), WithSortParam("order"), WithAnyField())
+		if err != nil {
+			t.Fatalf("ParseWhere() error = %v", err)
+		}
+		if len(parsed.Exprs) != len(where.Exprs) {
+			t.Errorf("ParseWhere() = %s, want %d conditions", parsed, len(where.Exprs))
+		}
+	}
+}
+
+func TestEncodeWhere_Errors(t *testing.T) {
+	tests := []clause.Where{
+		{Exprs: []clause.Expression{clause.Or(clause.Eq{Col: "a", Val: 1}, clause.Eq{Col: "b", Val: 2})}},
+		{Exprs: []clause.Expression{clause.Raw("a = 1")}},
+		{Exprs: []clause.Expression{clause.IN{Col: "tag", Vals: []any{"a,b"}}}},
+		{Exprs: []clause.Expression{clause.Eq{Col: "o.user_id", Val: clause.Column{Name: "u.id"}}}},
+	}
+	for _, where := range tests {
+		if err := EncodeWhere(url.Values{}, where); !errors.Is(err, ErrUnsupportedExpr) {
+			t.Errorf("EncodeWhere(%s) error = %v, want ErrUnsupportedExpr", where, err)
+		}
+	}
+}
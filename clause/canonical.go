@@ -0,0 +1,218 @@
+package clause
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Canonical 返回表达式的规范文本，语义等价的表达式返回相同的文本，可用作缓存键：
+//   - WHERE 条件先按 Normalize 规范化，AND/OR 的子表达式按文本排序，与书写顺序无关
+//   - IN/NOT IN 的值按文本排序
+//   - 字符串带引号，整数、浮点数、时间等值按类型统一格式化，map 按键排序
+//
+// 规范文本只用于比较和计算指纹，不是可执行的 SQL。
+//
+// 示例:
+//
+//	clause.Canonical(clause.Where{Exprs: []clause.Expression{
+//	    clause.Eq{Col: "status", Val: "active"},
+//	    clause.Gte{Col: "age", Val: 18},
+//	}}) // age >= 18 AND status = "active"
+func Canonical(e Expression) string {
+	return canonicalString(e, false)
+}
+
+// Shape 返回表达式的结构文本，与 Canonical 相同但参数值以 ? 代替，IN 列表折叠为 (?)，
+// 只是参数值不同的表达式返回相同的文本，可用于按查询结构统计指标。
+func Shape(e Expression) string {
+	return canonicalString(e, true)
+}
+
+// Fingerprint 返回文本的 SHA-256 指纹（十六进制），通常与 Canonical 或 Shape 配合使用
+func Fingerprint(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// Canonical 返回 WHERE 条件的规范文本，见 Canonical 函数
+func (w Where) Canonical() string {
+	return Canonical(w)
+}
+
+// Shape 返回 WHERE 条件的结构文本，见 Shape 函数
+func (w Where) Shape() string {
+	return Shape(w)
+}
+
+// Fingerprint 返回 WHERE 条件规范文本的指纹，与条件的书写顺序无关
+func (w Where) Fingerprint() string {
+	return Fingerprint(w.Canonical())
+}
+
+// ShapeFingerprint 返回 WHERE 条件结构文本的指纹，与参数值无关
+func (w Where) ShapeFingerprint() string {
+	return Fingerprint(w.Shape())
+}
+
+func canonicalString(e Expression, shape bool) string {
+	b := &canonicalBuilder{shape: shape}
+	b.writeExpr(e)
+	return b.String()
+}
+
+// canonicalBuilder 以规范文本形式写入表达式，标识符不加引号，参数值直接写入（shape 为 true 时写入 ?）
+type canonicalBuilder struct {
+	strings.Builder
+	shape bool
+}
+
+func (b *canonicalBuilder) WriteQuoted(field interface{}) {
+	switch v := field.(type) {
+	case string:
+		b.WriteString(v)
+	case Expression:
+		b.writeExpr(v)
+	default:
+		b.WriteString(fmt.Sprint(v))
+	}
+}
+
+func (b *canonicalBuilder) AddVar(writer Writer, vars ...interface{}) {
+	for idx, v := range vars {
+		if idx > 0 {
+			b.WriteString(", ")
+		}
+		b.writeValue(v)
+	}
+}
+
+func (b *canonicalBuilder) AddError(err error) error {
+	return err
+}
+
+// writeExpr 写入表达式，WHERE 条件和逻辑表达式先规范化再排序
+func (b *canonicalBuilder) writeExpr(e Expression) {
+	switch e := e.(type) {
+	case nil:
+	case Where:
+		b.writeTerms(e.Normalize().Exprs, " AND ")
+	case LogicalExpression:
+		normalized := Where{Exprs: []Expression{e}}.Normalize()
+		if len(normalized.Exprs) > 1 {
+			b.WriteByte('(')
+			b.writeTerms(normalized.Exprs, " AND ")
+			b.WriteByte(')')
+			return
+		}
+		b.writeNormalized(normalized.expr())
+	default:
+		b.writeNormalized(e)
+	}
+}
+
+// writeNormalized 写入规范化后的表达式
+func (b *canonicalBuilder) writeNormalized(e Expression) {
+	switch e := e.(type) {
+	case nil:
+	case AndExpr:
+		b.WriteByte('(')
+		b.writeTerms(e.Exprs, " AND ")
+		b.WriteByte(')')
+	case OrExpr:
+		b.WriteByte('(')
+		b.writeTerms(e.Exprs, " OR ")
+		b.WriteByte(')')
+	case NotExpr:
+		b.WriteString("NOT ")
+		if len(e.Exprs) == 1 {
+			if _, ok := e.Exprs[0].(LogicalExpression); ok {
+				b.writeNormalized(e.Exprs[0])
+				return
+			}
+		}
+		b.WriteByte('(')
+		b.writeTerms(e.Exprs, " AND ")
+		b.WriteByte(')')
+	case ComparisonExpression:
+		b.writeComparison(e)
+	default:
+		e.Build(b)
+	}
+}
+
+// writeTerms 写入按文本排序的子表达式
+func (b *canonicalBuilder) writeTerms(exprs []Expression, sep string) {
+	terms := make([]string, 0, len(exprs))
+	for _, e := range exprs {
+		if e == nil {
+			continue
+		}
+		sub := &canonicalBuilder{shape: b.shape}
+		sub.writeNormalized(e)
+		if sub.Len() > 0 {
+			terms = append(terms, sub.String())
+		}
+	}
+	sort.Strings(terms)
+	b.WriteString(strings.Join(terms, sep))
+}
+
+func (b *canonicalBuilder) writeComparison(e ComparisonExpression) {
+	b.WriteString(e.Column())
+	b.WriteByte(' ')
+	b.WriteString(string(e.Operator()))
+
+	switch e.Operator() {
+	case OpISNULL, OpISNOTNULL:
+	case OpIN, OpNOTIN:
+		b.WriteString(" (")
+		if b.shape {
+			b.WriteByte('?')
+		} else {
+			vals, _ := e.Value().([]any)
+			terms := make([]string, len(vals))
+			for i, v := range vals {
+				terms[i] = canonicalValue(v)
+			}
+			sort.Strings(terms)
+			b.WriteString(strings.Join(terms, ", "))
+		}
+		b.WriteByte(')')
+	case OpBETWEEN, OpNOTBETWEEN:
+		vals, _ := e.Value().([]any)
+		if len(vals) == 2 {
+			b.WriteByte(' ')
+			b.writeValue(vals[0])
+			b.WriteString(" AND ")
+			b.writeValue(vals[1])
+		}
+	default:
+		b.WriteByte(' ')
+		b.writeValue(e.Value())
+	}
+}
+
+// writeValue 写入参数值，列引用和表达式在 shape 模式下同样保留
+func (b *canonicalBuilder) writeValue(v any) {
+	if e, ok := v.(Expression); ok {
+		b.writeExpr(e)
+		return
+	}
+	if b.shape {
+		b.WriteByte('?')
+		return
+	}
+	b.WriteString(canonicalValue(v))
+}
+
+// canonicalValue 返回值的规范文本
+func canonicalValue(v any) string {
+	if e, ok := v.(Expression); ok {
+		return Canonical(e)
+	}
+	return formatValue(v, strconv.Quote)
+}
@@ -2,8 +2,13 @@ package clause
 
 import (
 	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 )
 
 // mockBuilder 是用于测试的Builder实现
@@ -24,10 +29,14 @@ func (m *mockBuilder) WriteQuoted(field interface{}) {
 
 func (m *mockBuilder) AddVar(writer Writer, vars ...interface{}) {
 	for i, v := range vars {
-		m.vars = append(m.vars, v)
 		if i > 0 {
 			writer.WriteString(",")
 		}
+		if expr, ok := v.(Expression); ok {
+			expr.Build(m)
+			continue
+		}
+		m.vars = append(m.vars, v)
 		writer.WriteString(fmt.Sprintf("$%d", len(m.vars)))
 	}
 }
@@ -468,3 +477,785 @@ func TestNotExpression(t *testing.T) {
 		})
 	}
 }
+
+// TestSelectExpressions 测试聚合、别名与 GROUP BY 表达式
+func TestSelectExpressions(t *testing.T) {
+	tests := []struct {
+		name         string
+		expr         Expression
+		expected     string
+		expectedVars []interface{}
+	}{
+		{
+			name:     "Count star",
+			expr:     Count("*"),
+			expected: "COUNT(*)",
+		},
+		{
+			name:     "Count distinct",
+			expr:     CountDistinct("user_id"),
+			expected: "COUNT(DISTINCT `user_id`)",
+		},
+		{
+			name:     "Sum with alias",
+			expr:     As(Sum("amount"), "total"),
+			expected: "SUM(`amount`) AS `total`",
+		},
+		{
+			name:         "Group by with having",
+			expr:         GroupBy{Columns: []string{"city", "status"}, Having: Where{Exprs: []Expression{Gt{Col: "cnt", Val: 1}}}},
+			expected:     " GROUP BY `city`, `status` HAVING `cnt` > $1",
+			expectedVars: []interface{}{1},
+		},
+		{
+			name:     "Empty group by",
+			expr:     GroupBy{},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &mockBuilder{}
+			tt.expr.Build(builder)
+
+			if builder.String() != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, builder.String())
+			}
+
+			if len(builder.vars) != len(tt.expectedVars) {
+				t.Errorf("expected %d vars, got %d", len(tt.expectedVars), len(builder.vars))
+			}
+		})
+	}
+}
+
+// TestWithColumn 测试替换比较表达式的列名
+func TestWithColumn(t *testing.T) {
+	exprs := []ComparisonExpression{
+		Eq{Col: "a", Val: 1}, Neq{Col: "a", Val: 1}, Gt{Col: "a", Val: 1}, Gte{Col: "a", Val: 1},
+		Lt{Col: "a", Val: 1}, Lte{Col: "a", Val: 1}, Like{Col: "a", Val: "x"}, IN{Col: "a", Vals: []any{1}},
+		Between{Col: "a", From: 1, To: 2}, NotBetween{Col: "a", From: 1, To: 2}, IsNull{Col: "a"}, IsNotNull{Col: "a"},
+		NotIn{Col: "a", Vals: []any{1}}, NotLike{Col: "a", Val: "x"}, ILike{Col: "a", Val: "x"},
+		StartsWith{Col: "a", Val: "x"}, EndsWith{Col: "a", Val: "x"}, Contains{Col: "a", Val: "x"},
+	}
+
+	for _, expr := range exprs {
+		got := WithColumn(expr, "b")
+		if got.Column() != "b" || got.Operator() != expr.Operator() {
+			t.Errorf("WithColumn(%T): expected column b with operator %s, got %s %s", expr, expr.Operator(), got.Column(), got.Operator())
+		}
+		if expr.Column() != "a" {
+			t.Errorf("WithColumn(%T) modified the original expression", expr)
+		}
+	}
+}
+
+// TestSubQueryExpressions 测试子查询与 EXISTS 表达式
+func TestSubQueryExpressions(t *testing.T) {
+	inner := Where{Exprs: []Expression{Eq{Col: "status", Val: "paid"}}}
+	sub := SubQuery{Expr: inner}
+
+	tests := []struct {
+		name         string
+		expr         Expression
+		expected     string
+		expectedVars []interface{}
+	}{
+		{
+			name:         "Eq subquery",
+			expr:         Eq{Col: "id", Val: sub},
+			expected:     "`id` = ( WHERE `status` = $1)",
+			expectedVars: []interface{}{"paid"},
+		},
+		{
+			name:         "IN subquery",
+			expr:         IN{Col: "id", Vals: []interface{}{sub}},
+			expected:     "`id` IN ( WHERE `status` = $1)",
+			expectedVars: []interface{}{"paid"},
+		},
+		{
+			name:         "NOT IN subquery",
+			expr:         Not(IN{Col: "id", Vals: []interface{}{sub}}),
+			expected:     "`id` NOT IN ( WHERE `status` = $1)",
+			expectedVars: []interface{}{"paid"},
+		},
+		{
+			name:         "Exists",
+			expr:         Exists{Expr: inner},
+			expected:     "EXISTS ( WHERE `status` = $1)",
+			expectedVars: []interface{}{"paid"},
+		},
+		{
+			name:         "Not exists with args order",
+			expr:         And(Gt{Col: "age", Val: 18}, Not(Exists{Expr: inner})),
+			expected:     "(`age` > $1 AND NOT EXISTS ( WHERE `status` = $2))",
+			expectedVars: []interface{}{18, "paid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &mockBuilder{}
+			tt.expr.Build(builder)
+
+			if builder.String() != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, builder.String())
+			}
+
+			if !reflect.DeepEqual(builder.vars, tt.expectedVars) {
+				t.Errorf("expected vars: %v, got: %v", tt.expectedVars, builder.vars)
+			}
+		})
+	}
+}
+
+// TestExtendedComparisonExpressions 测试 BETWEEN、IS NULL、NOT IN、NOT LIKE、ILIKE 及前后缀匹配表达式
+func TestExtendedComparisonExpressions(t *testing.T) {
+	tests := []struct {
+		name         string
+		expr         Expression
+		expected     string
+		expectedVars []interface{}
+	}{
+		{name: "Between", expr: Between{Col: "age", From: 18, To: 30}, expected: "`age` BETWEEN $1 AND $2", expectedVars: []interface{}{18, 30}},
+		{name: "NotBetween", expr: NotBetween{Col: "age", From: 18, To: 30}, expected: "`age` NOT BETWEEN $1 AND $2", expectedVars: []interface{}{18, 30}},
+		{name: "Not Between", expr: Not(Between{Col: "age", From: 18, To: 30}), expected: "`age` NOT BETWEEN $1 AND $2", expectedVars: []interface{}{18, 30}},
+		{name: "IsNull", expr: IsNull{Col: "deleted_at"}, expected: "`deleted_at` IS NULL"},
+		{name: "IsNotNull", expr: IsNotNull{Col: "deleted_at"}, expected: "`deleted_at` IS NOT NULL"},
+		{name: "Not IsNull", expr: Not(IsNull{Col: "deleted_at"}), expected: "`deleted_at` IS NOT NULL"},
+		{name: "NotIn", expr: NotIn{Col: "id", Vals: []interface{}{1, 2}}, expected: "`id` NOT IN ($1,$2)", expectedVars: []interface{}{1, 2}},
+		{name: "Not NotIn", expr: Not(NotIn{Col: "id", Vals: []interface{}{1, 2}}), expected: "`id` IN ($1,$2)", expectedVars: []interface{}{1, 2}},
+		{name: "NotLike", expr: NotLike{Col: "name", Val: "J%"}, expected: "`name` NOT LIKE $1", expectedVars: []interface{}{"J%"}},
+		{name: "ILike", expr: ILike{Col: "name", Val: "j%"}, expected: "LOWER(`name`) LIKE LOWER($1)", expectedVars: []interface{}{"j%"}},
+		{name: "Not ILike", expr: Not(ILike{Col: "name", Val: "j%"}), expected: "LOWER(`name`) NOT LIKE LOWER($1)", expectedVars: []interface{}{"j%"}},
+		{name: "StartsWith", expr: StartsWith{Col: "name", Val: "50%_off"}, expected: "`name` LIKE $1 ESCAPE '!'", expectedVars: []interface{}{"50!%!_off%"}},
+		{name: "EndsWith", expr: EndsWith{Col: "email", Val: "@x.com"}, expected: "`email` LIKE $1 ESCAPE '!'", expectedVars: []interface{}{"%@x.com"}},
+		{name: "Contains", expr: Contains{Col: "title", Val: "hi!"}, expected: "`title` LIKE $1 ESCAPE '!'", expectedVars: []interface{}{"%hi!!%"}},
+		{name: "Not Contains", expr: Not(Contains{Col: "title", Val: "x"}), expected: "`title` NOT LIKE $1 ESCAPE '!'", expectedVars: []interface{}{"%x%"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &mockBuilder{}
+			tt.expr.Build(builder)
+
+			if builder.String() != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, builder.String())
+			}
+
+			if len(builder.vars) != 0 || len(tt.expectedVars) != 0 {
+				if !reflect.DeepEqual(builder.vars, tt.expectedVars) {
+					t.Errorf("expected vars: %v, got: %v", tt.expectedVars, builder.vars)
+				}
+			}
+		})
+	}
+}
+
+// TestEscapeLike 测试 LIKE 通配符转义
+func TestEscapeLike(t *testing.T) {
+	tests := map[string]string{
+		"abc":    "abc",
+		"50%":    "50!%",
+		"a_b":    "a!_b",
+		"wow!":   "wow!!",
+		`back\s`: `back\s`,
+	}
+
+	for input, expected := range tests {
+		if got := EscapeLike(input); got != expected {
+			t.Errorf("EscapeLike(%q): expected %q, got %q", input, expected, got)
+		}
+	}
+}
+
+// TestRawExpression 测试原生 SQL 表达式的位置参数与命名参数
+func TestRawExpression(t *testing.T) {
+	tests := []struct {
+		name         string
+		expr         Expression
+		expected     string
+		expectedVars []interface{}
+		expectedErr  bool
+	}{
+		{name: "No vars", expr: Raw("deleted_at IS NULL"), expected: "deleted_at IS NULL"},
+		{name: "Positional", expr: Raw("LENGTH(name) > ? AND age < ?", 3, 60), expected: "LENGTH(name) > $1 AND age < $2", expectedVars: []interface{}{3, 60}},
+		{name: "Expression var", expr: Raw("? = ?", Column{Name: "a"}, 1), expected: "`a` = $1", expectedVars: []interface{}{1}},
+		{name: "Named map", expr: Raw("age BETWEEN @min AND @max OR age = @min", map[string]any{"min": 18, "max": 30}), expected: "age BETWEEN $1 AND $2 OR age = $3", expectedVars: []interface{}{18, 30, 18}},
+		{name: "Named arg", expr: Raw("created_at > @since", sql.Named("since", "2024-01-01")), expected: "created_at > $1", expectedVars: []interface{}{"2024-01-01"}},
+		{name: "In condition", expr: And(Eq{Col: "status", Val: "paid"}, Raw("amount > ?", 100)), expected: "(`status` = $1 AND amount > $2)", expectedVars: []interface{}{"paid", 100}},
+		{name: "OrderBy", expr: OrderBys{{Expr: Raw("FIELD(status, ?, ?)", "a", "b"), Desc: true}, {Column: "id"}}, expected: " ORDER BY FIELD(status, $1, $2) DESC, `id` ASC", expectedVars: []interface{}{"a", "b"}},
+		{name: "Escaped placeholder", expr: Raw("data ?? ? AND data ??| ?", "vip", "tags"), expected: "data ? $1 AND data ?| $2", expectedVars: []interface{}{"vip", "tags"}},
+		{name: "Quoted literal", expr: Raw(`note <> 'what?' AND "a?b" = ? AND note <> 'it''s ?'`, 1), expected: `note <> 'what?' AND "a?b" = $1 AND note <> 'it''s ?'`, expectedVars: []interface{}{1}},
+		{name: "Named quoted literal", expr: Raw("email LIKE '%@x.com' AND id = @id AND data ?? 'k'", sql.Named("id", 1)), expected: "email LIKE '%@x.com' AND id = $1 AND data ? 'k'", expectedVars: []interface{}{1}},
+		{name: "Too few vars", expr: Raw("a = ? AND b = ?", 1), expected: "a = $1 AND b = ", expectedVars: []interface{}{1}, expectedErr: true},
+		{name: "Too many vars", expr: Raw("a = ?", 1, 2), expected: "a = $1", expectedVars: []interface{}{1}, expectedErr: true},
+		{name: "Missing named var", expr: Raw("a = @a AND b = @b", sql.Named("a", 1)), expected: "a = $1 AND b = ", expectedVars: []interface{}{1}, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &mockBuilder{}
+			tt.expr.Build(builder)
+
+			if builder.String() != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, builder.String())
+			}
+
+			if len(builder.vars) != 0 || len(tt.expectedVars) != 0 {
+				if !reflect.DeepEqual(builder.vars, tt.expectedVars) {
+					t.Errorf("expected vars: %v, got: %v", tt.expectedVars, builder.vars)
+				}
+			}
+
+			if tt.expectedErr != (len(builder.errors) > 0) {
+				t.Errorf("expected error: %v, got: %v", tt.expectedErr, builder.errors)
+			}
+		})
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	tests := []struct {
+		name         string
+		expr         Expression
+		expected     string
+		expectedVars []interface{}
+		expectedErr  bool
+	}{
+		{name: "Single column", expr: Keyset{OrderBys: OrderBys{{Column: "id"}}, Values: []any{10}}, expected: "`id` > $1", expectedVars: []interface{}{10}},
+		{name: "Single desc", expr: Keyset{OrderBys: OrderBys{{Column: "id", Desc: true}}, Values: []any{10}}, expected: "`id` < $1", expectedVars: []interface{}{10}},
+		{name: "Compound asc", expr: Keyset{OrderBys: OrderBys{{Column: "age"}, {Column: "id"}}, Values: []any{18, 10}}, expected: "(`age`, `id`) > ($1, $2)", expectedVars: []interface{}{18, 10}},
+		{name: "Compound desc", expr: Keyset{OrderBys: OrderBys{{Column: "age", Desc: true}, {Column: "id", Desc: true}}, Values: []any{18, 10}}, expected: "(`age`, `id`) < ($1, $2)", expectedVars: []interface{}{18, 10}},
+		{name: "Mixed directions", expr: Keyset{OrderBys: OrderBys{{Column: "age", Desc: true}, {Column: "name"}, {Column: "id"}}, Values: []any{18, "a", 10}}, expected: "(`age` < $1 OR (`age` = $2 AND `name` > $3) OR (`age` = $4 AND `name` = $5 AND `id` > $6))", expectedVars: []interface{}{18, 18, "a", 18, "a", 10}},
+		{name: "Values mismatch", expr: Keyset{OrderBys: OrderBys{{Column: "age"}, {Column: "id"}}, Values: []any{18}}, expected: "", expectedErr: true},
+		{name: "Expression order", expr: Keyset{OrderBys: OrderBys{{Expr: Raw("LENGTH(name)")}}, Values: []any{3}}, expected: "", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &mockBuilder{}
+			tt.expr.Build(builder)
+
+			if builder.String() != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, builder.String())
+			}
+
+			if len(builder.vars) != 0 || len(tt.expectedVars) != 0 {
+				if !reflect.DeepEqual(builder.vars, tt.expectedVars) {
+					t.Errorf("expected vars: %v, got: %v", tt.expectedVars, builder.vars)
+				}
+			}
+
+			if tt.expectedErr != (len(builder.errors) > 0) {
+				t.Errorf("expected error: %v, got: %v", tt.expectedErr, builder.errors)
+			}
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	values := []any{int32(18), uint(7), 1.5, "John", true, []byte("key"), ts}
+
+	cursor, err := EncodeCursor(values)
+	if err != nil {
+		t.Fatalf("encode cursor: %v", err)
+	}
+
+	decoded, err := DecodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("decode cursor: %v", err)
+	}
+
+	expected := []any{int64(18), uint64(7), 1.5, "John", true, []byte("key"), ts}
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("expected values: %v, got: %v", expected, decoded)
+	}
+
+	if _, err := EncodeCursor([]any{nil}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor for nil value, got: %v", err)
+	}
+
+	for _, invalid := range []string{"!!!", "bm90LWpzb24", "W3sidCI6InoiLCJ2IjoiMSJ9XQ"} {
+		if _, err := DecodeCursor(invalid); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("expected ErrInvalidCursor for %q, got: %v", invalid, err)
+		}
+	}
+}
+
+// recordingVisitor 记录 Enter/Leave 的调用顺序
+type recordingVisitor struct {
+	events []string
+	skip   LogicalOperator
+}
+
+func (v *recordingVisitor) Enter(n Node) bool {
+	v.events = append(v.events, fmt.Sprintf("enter %s %d %v", nodeName(n.Expr), n.Depth(), n.Path))
+	logical, ok := n.Expr.(LogicalExpression)
+	return !ok || logical.Operator() != v.skip
+}
+
+func (v *recordingVisitor) Leave(n Node) {
+	v.events = append(v.events, "leave "+nodeName(n.Expr))
+}
+
+func nodeName(e Expression) string {
+	switch e := e.(type) {
+	case ComparisonExpression:
+		return e.Column()
+	case LogicalExpression:
+		return [...]string{"AND", "OR", "NOT"}[e.Operator()]
+	}
+	return fmt.Sprintf("%T", e)
+}
+
+func TestWalk(t *testing.T) {
+	where := Where{Exprs: []Expression{
+		Eq{Col: "tenant_id", Val: 1},
+		Or(Eq{Col: "status", Val: "active"}, Not(Eq{Col: "role", Val: "guest"})),
+	}}
+
+	v := &recordingVisitor{skip: LogicNot}
+	Walk(where, v)
+
+	expected := []string{
+		"enter tenant_id 1 [0]",
+		"leave tenant_id",
+		"enter OR 1 [1]",
+		"enter status 2 [1 0]",
+		"leave status",
+		"enter NOT 2 [1 1]",
+		"leave NOT",
+		"leave OR",
+	}
+	if !reflect.DeepEqual(v.events, expected) {
+		t.Errorf("expected events: %v, got: %v", expected, v.events)
+	}
+
+	var parents []Expression
+	Inspect(where, func(n Node) bool {
+		if cmp, ok := n.Expr.(ComparisonExpression); ok && cmp.Column() == "role" {
+			parents = append(parents, n.Parent)
+		}
+		return true
+	})
+	if len(parents) != 1 || nodeName(parents[0]) != "NOT" {
+		t.Errorf("expected role to be under NOT, got: %v", parents)
+	}
+}
+
+func TestFindAndColumns(t *testing.T) {
+	where := Where{Exprs: []Expression{
+		Eq{Col: "tenant_id", Val: 1},
+		Or(
+			And(Eq{Col: "tenant_id", Val: 2}, Gt{Col: "updated_at", Val: Column{Name: "created_at"}}),
+			IN{Col: "status", Vals: []any{"a", "b"}},
+		),
+		Raw("LENGTH(?) > ?", Column{Name: "name"}, 3),
+	}}
+
+	found := Find(where, func(e Expression) bool {
+		eq, ok := e.(Eq)
+		return ok && eq.Col == "tenant_id"
+	})
+	if len(found) != 2 || found[0].(Eq).Val != 1 || found[1].(Eq).Val != 2 {
+		t.Errorf("expected two tenant_id conditions, got: %v", found)
+	}
+
+	expected := []string{"tenant_id", "updated_at", "created_at", "status", "name"}
+	if columns := Columns(where); !reflect.DeepEqual(columns, expected) {
+		t.Errorf("expected columns: %v, got: %v", expected, columns)
+	}
+}
+
+func buildWhere(where Where) (string, []any) {
+	builder := &mockBuilder{}
+	where.Build(builder)
+	return builder.String(), builder.vars
+}
+
+func TestWhereNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		where    Where
+		expected string
+	}{
+		{
+			name:     "flatten nested groups",
+			where:    Where{Exprs: []Expression{AndExpr{Exprs: []Expression{Eq{Col: "a", Val: 1}, AndExpr{Exprs: []Expression{Eq{Col: "b", Val: 2}}}}}, Or(Or(Eq{Col: "c", Val: 3}, Eq{Col: "d", Val: 4}), Eq{Col: "e", Val: 5})}},
+			expected: " WHERE `a` = $1 AND `b` = $2 AND (`c` = $3 OR `d` = $4 OR `e` = $5)",
+		},
+		{
+			name:     "or where",
+			where:    Where{Exprs: []Expression{Eq{Col: "a", Val: 1}, Eq{Col: "b", Val: 2}, Or(Eq{Col: "c", Val: 3})}},
+			expected: " WHERE ((`a` = $1 AND `b` = $2) OR `c` = $3)",
+		},
+		{
+			name:     "de morgan",
+			where:    Where{Exprs: []Expression{Not(Or(Eq{Col: "a", Val: 1}, And(Gt{Col: "b", Val: 2}, IN{Col: "c", Vals: []any{1, 2}})))}},
+			expected: " WHERE `a` <> $1 AND (`b` <= $2 OR `c` NOT IN ($3,$4))",
+		},
+		{
+			name:     "double negation",
+			where:    Where{Exprs: []Expression{Not(Not(IsNull{Col: "a"})), Not(Raw("x > 1"))}},
+			expected: " WHERE `a` IS NULL AND NOT x > 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, beforeVars := buildWhere(tt.where)
+			sql, vars := buildWhere(tt.where.Normalize())
+			if sql != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, sql)
+			}
+			if !reflect.DeepEqual(vars, beforeVars) {
+				t.Errorf("expected vars %v to be preserved, got: %v (from %s)", beforeVars, vars, before)
+			}
+		})
+	}
+}
+
+func TestWhereSimplify(t *testing.T) {
+	tests := []struct {
+		name     string
+		where    Where
+		expected string
+		err      error
+	}{
+		{
+			name:     "remove duplicates",
+			where:    Where{Exprs: []Expression{Eq{Col: "a", Val: 1}, And(Eq{Col: "a", Val: 1}, Eq{Col: "b", Val: 2})}},
+			expected: " WHERE `a` = $1 AND `b` = $2",
+		},
+		{
+			name:     "merge eq into in",
+			where:    Where{Exprs: []Expression{Or(Eq{Col: "a", Val: 1}, Eq{Col: "b", Val: 2}, Eq{Col: "a", Val: 3}, IN{Col: "a", Vals: []any{1, 4}})}},
+			expected: " WHERE (`a` IN ($1,$2,$3) OR `b` = $4)",
+		},
+		{
+			name:     "prune contradictory branch",
+			where:    Where{Exprs: []Expression{Or(And(Eq{Col: "a", Val: 1}, Eq{Col: "a", Val: int64(2)}), Eq{Col: "b", Val: 3})}},
+			expected: " WHERE `b` = $1",
+		},
+		{
+			name:  "eq conflict",
+			where: Where{Exprs: []Expression{Eq{Col: "a", Val: 1}, Eq{Col: "a", Val: 2.0}}},
+			err:   ErrContradiction,
+		},
+		{
+			name:  "eq and neq",
+			where: Where{Exprs: []Expression{Eq{Col: "a", Val: "x"}, Not(Eq{Col: "a", Val: "x"})}},
+			err:   ErrContradiction,
+		},
+		{
+			name:  "null and not null",
+			where: Where{Exprs: []Expression{IsNull{Col: "a"}, Gt{Col: "a", Val: 1}}},
+			err:   ErrContradiction,
+		},
+		{
+			name:  "eq not in list",
+			where: Where{Exprs: []Expression{Eq{Col: "a", Val: 5}, IN{Col: "a", Vals: []any{1, 2}}}},
+			err:   ErrContradiction,
+		},
+		{
+			name:     "same value is not a contradiction",
+			where:    Where{Exprs: []Expression{Eq{Col: "a", Val: 1}, Eq{Col: "a", Val: int64(1)}, IN{Col: "a", Vals: []any{1, 2}}}},
+			expected: " WHERE `a` = $1 AND `a` = $2 AND `a` IN ($3,$4)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, err := tt.where.Simplify()
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error: %v, got: %v", tt.err, err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if sql, _ := buildWhere(where); sql != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, sql)
+			}
+		})
+	}
+}
+
+func TestWhereJSON(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	where := Where{Exprs: []Expression{
+		Eq{Col: "status", Val: "active"},
+		Eq{Col: "deleted_at", Val: nil},
+		Gte{Col: "created_at", Val: ts},
+		Lt{Col: "ttl", Val: time.Hour},
+		OrExpr{Exprs: []Expression{
+			IN{Col: "id", Vals: []any{1, uint(2), 3.5}},
+			AndExpr{Exprs: []Expression{Between{Col: "age", From: 18, To: 30}, Neq{Col: "flag", Val: true}}},
+			NotExpr{Exprs: []Expression{StartsWith{Col: "name", Val: "Jo"}, IsNotNull{Col: "email"}}},
+		}},
+		Gt{Col: "updated_at", Val: Column{Name: "created_at"}},
+		Eq{Col: "role", Val: []string{"admin", "owner"}},
+		ILike{Col: "city", Val: "lon%"},
+	}}
+
+	data, err := json.Marshal(where)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var decoded Where
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	sql, vars := buildWhere(where)
+	decodedSQL, decodedVars := buildWhere(decoded)
+	if sql != decodedSQL {
+		t.Errorf("expected SQL: %s, got: %s", sql, decodedSQL)
+	}
+	if fmt.Sprint(vars) != fmt.Sprint(decodedVars) {
+		t.Errorf("expected vars: %v, got: %v", vars, decodedVars)
+	}
+	if ttl, ok := decoded.Exprs[3].(Lt).Val.(time.Duration); !ok || ttl != time.Hour {
+		t.Errorf("expected time.Duration to be preserved, got: %#v", decoded.Exprs[3])
+	}
+
+	// 格式稳定性
+	small, _ := json.Marshal(Where{Exprs: []Expression{Eq{Col: "age", Val: 18}, Not(IsNull{Col: "email"})}})
+	expected := `{"version":1,"exprs":[{"op":"=","col":"age","val":{"t":"i","v":"18"}},{"op":"NOT","exprs":[{"op":"IS NULL","col":"email"}]}]}`
+	if string(small) != expected {
+		t.Errorf("expected JSON: %s, got: %s", expected, small)
+	}
+}
+
+func TestWhereJSON_Errors(t *testing.T) {
+	if _, err := json.Marshal(Where{Exprs: []Expression{Raw("1 = 1")}}); !errors.Is(err, ErrUnsupportedJSON) {
+		t.Errorf("expected ErrUnsupportedJSON for raw, got: %v", err)
+	}
+	if _, err := json.Marshal(Where{Exprs: []Expression{IN{Col: "id", Vals: []any{SubQuery{}}}}}); !errors.Is(err, ErrUnsupportedJSON) {
+		t.Errorf("expected ErrUnsupportedJSON for subquery, got: %v", err)
+	}
+
+	tests := []string{
+		`{"version":2,"exprs":[]}`,
+		`{"version":1,"exprs":[{"op":"~","col":"a","val":null}]}`,
+		`{"version":1,"exprs":[{"op":"=","val":null}]}`,
+		`{"version":1,"exprs":[{"op":"BETWEEN","col":"a","vals":[null]}]}`,
+		`{"version":1,"exprs":[{"op":"AND","exprs":[]}]}`,
+		`{"version":1,"exprs":[{"op":"=","col":"a","val":{"t":"?","v":""}}]}`,
+	}
+	for _, data := range tests {
+		var where Where
+		if err := json.Unmarshal([]byte(data), &where); !errors.Is(err, ErrInvalidJSON) {
+			t.Errorf("expected ErrInvalidJSON for %s, got: %v", data, err)
+		}
+	}
+}
+
+func TestOrderBysAndPaginationJSON(t *testing.T) {
+	orders := OrderBys{{Column: "age", Desc: true}, {Column: "id"}}
+	data, err := json.Marshal(orders)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decodedOrders OrderBys
+	if err := json.Unmarshal(data, &decodedOrders); err != nil || !reflect.DeepEqual(decodedOrders, orders) {
+		t.Errorf("expected orders %v, got: %v (%v)", orders, decodedOrders, err)
+	}
+
+	if _, err := json.Marshal(OrderBys{{Expr: Raw("RAND()")}}); !errors.Is(err, ErrUnsupportedJSON) {
+		t.Errorf("expected ErrUnsupportedJSON, got: %v", err)
+	}
+
+	limit := 10
+	p := Pagination{Limit: &limit, Offset: 20, After: []any{int64(18), "u-1"}}
+	data, err = json.Marshal(p)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded Pagination
+	if err := json.Unmarshal(data, &decoded); err != nil || !reflect.DeepEqual(decoded, p) {
+		t.Errorf("expected pagination %+v, got: %+v (%v)", p, decoded, err)
+	}
+}
+
+func TestWhereCanonical(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("CST", 8*3600))
+
+	tests := []struct {
+		name  string
+		where Where
+		want  string
+		shape string
+	}{
+		{
+			name:  "comparisons",
+			where: Where{Exprs: []Expression{Eq{Col: "status", Val: "active"}, Gte{Col: "age", Val: 18}}},
+			want:  `age >= 18 AND status = "active"`,
+			shape: `age >= ? AND status = ?`,
+		},
+		{
+			name:  "in and between",
+			where: Where{Exprs: []Expression{IN{Col: "id", Vals: []any{3, 1, 2}}, Between{Col: "created_at", From: ts, To: nil}}},
+			want:  `created_at BETWEEN "2024-01-01T19:04:05Z" AND NULL AND id IN (1, 2, 3)`,
+			shape: `created_at BETWEEN ? AND ? AND id IN (?)`,
+		},
+		{
+			name: "logical",
+			where: Where{Exprs: []Expression{
+				Or(Eq{Col: "b", Val: true}, Eq{Col: "a", Val: []byte("x")}),
+				Not(Or(Like{Col: "name", Val: "J%"}, IsNull{Col: "email"})),
+			}},
+			want:  `(a = X'78' OR b = TRUE) AND email IS NOT NULL AND name NOT LIKE "J%"`,
+			shape: `(a = ? OR b = ?) AND email IS NOT NULL AND name NOT LIKE ?`,
+		},
+		{
+			name:  "column and raw",
+			where: Where{Exprs: []Expression{Raw("score > ?", 1.5), Eq{Col: "o.user_id", Val: Column{Name: "u.id"}}}},
+			want:  `o.user_id = u.id AND score > 1.5`,
+			shape: `o.user_id = u.id AND score > ?`,
+		},
+		{
+			name:  "map value",
+			where: Where{Exprs: []Expression{Eq{Col: "attrs", Val: map[string]int{"b": 2, "a": 1}}}},
+			want:  `attrs = {"a": 1, "b": 2}`,
+			shape: `attrs = ?`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.where.Canonical(); got != tt.want {
+				t.Errorf("expected canonical %q, got: %q", tt.want, got)
+			}
+			if got := tt.where.Shape(); got != tt.shape {
+				t.Errorf("expected shape %q, got: %q", tt.shape, got)
+			}
+		})
+	}
+}
+
+func TestWhereFingerprint(t *testing.T) {
+	a := Where{Exprs: []Expression{
+		Eq{Col: "tenant_id", Val: 1},
+		Or(Eq{Col: "status", Val: "active"}, And(Gt{Col: "age", Val: int64(18)}, IN{Col: "role", Vals: []any{"a", "b"}})),
+	}}
+	// 相同的条件，AND/OR 的顺序、IN 值的顺序和整数类型不同
+	b := Where{Exprs: []Expression{
+		Or(And(IN{Col: "role", Vals: []any{"b", "a"}}, Gt{Col: "age", Val: 18}), Eq{Col: "status", Val: "active"}),
+		Eq{Col: "tenant_id", Val: uint8(1)},
+	}}
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Errorf("expected equal fingerprints:\n%s\n%s", a.Canonical(), b.Canonical())
+	}
+	if len(a.Fingerprint()) != 64 {
+		t.Errorf("expected sha-256 hex fingerprint, got: %s", a.Fingerprint())
+	}
+
+	// 参数值不同时指纹不同，结构指纹相同
+	c := Where{Exprs: []Expression{
+		Eq{Col: "tenant_id", Val: 2},
+		Or(Eq{Col: "status", Val: "active"}, And(Gt{Col: "age", Val: 18}, IN{Col: "role", Vals: []any{"a"}})),
+	}}
+	if a.Fingerprint() == c.Fingerprint() {
+		t.Errorf("expected different fingerprints for different values")
+	}
+	if a.ShapeFingerprint() != c.ShapeFingerprint() {
+		t.Errorf("expected equal shape fingerprints:\n%s\n%s", a.Shape(), c.Shape())
+	}
+
+	// 条件结构不同（OrWhere 以 OR 连接）时指纹不同
+	d := Where{Exprs: []Expression{Eq{Col: "a", Val: 1}, Eq{Col: "b", Val: 2}}}
+	e := Where{Exprs: []Expression{Eq{Col: "a", Val: 1}, Or(Eq{Col: "b", Val: 2})}}
+	if d.Fingerprint() == e.Fingerprint() {
+		t.Errorf("expected different fingerprints, got: %s", d.Canonical())
+	}
+	if got := e.Canonical(); got != "(a = 1 OR b = 2)" {
+		t.Errorf("expected (a = 1 OR b = 2), got: %s", got)
+	}
+}
+
+func TestWhereString(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		where Where
+		want  string
+	}{
+		{
+			name:  "comparisons",
+			where: Where{Exprs: []Expression{Eq{Col: "status", Val: "active"}, Gte{Col: "age", Val: 18}, IsNull{Col: "deleted_at"}}},
+			want:  `status = 'active' AND age >= 18 AND deleted_at IS NULL`,
+		},
+		{
+			name:  "escaped string",
+			where: Where{Exprs: []Expression{Eq{Col: "name", Val: "O'Brien'; DROP TABLE users; --\n"}}},
+			want:  `name = 'O''Brien''; DROP TABLE users; --\n'`,
+		},
+		{
+			name:  "escaped backslash",
+			where: Where{Exprs: []Expression{Eq{Col: "a", Val: `C:\new`}, Eq{Col: "b", Val: "C:\new"}}},
+			want:  `a = 'C:\\new' AND b = 'C:\new'`,
+		},
+		{
+			name:  "local time",
+			where: Where{Exprs: []Expression{Eq{Col: "created_at", Val: ts.In(time.FixedZone("CST", 8*3600))}}},
+			want:  `created_at = '2024-01-02T03:04:05Z'`,
+		},
+		{
+			name:  "values",
+			where: Where{Exprs: []Expression{IN{Col: "id", Vals: []any{1, 2}}, Between{Col: "created_at", From: ts, To: nil}, Eq{Col: "data", Val: []byte("x")}, Eq{Col: "ok", Val: true}}},
+			want:  `id IN (1, 2) AND created_at BETWEEN '2024-01-02T03:04:05Z' AND NULL AND data = X'78' AND ok = TRUE`,
+		},
+		{
+			name:  "logical",
+			where: Where{Exprs: []Expression{Eq{Col: "a", Val: 1}, Or(Eq{Col: "b", Val: 2}, Eq{Col: "c", Val: 3})}},
+			want:  `a = 1 AND (b = 2 OR c = 3)`,
+		},
+		{
+			name:  "raw",
+			where: Where{Exprs: []Expression{Raw("score > ?", 1.5)}},
+			want:  `score > 1.5`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.where.String(); got != tt.want {
+				t.Errorf("String() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWhereExplain(t *testing.T) {
+	where := Where{Exprs: []Expression{
+		Eq{Col: "status", Val: "active"},
+		Or(
+			Gt{Col: "age", Val: 18},
+			And(Eq{Col: "role", Val: "admin"}, Not(Or(IsNull{Col: "email"}, Raw("score < ?", 10)))),
+		),
+	}}
+
+	expected := `status = 'active'
+AND (
+  age > 18
+  OR (
+    role = 'admin'
+    AND NOT (
+      email IS NULL
+      OR score < 10
+    )
+  )
+)`
+	if got := where.Explain(); got != expected {
+		t.Errorf("expected explain:\n%s\ngot:\n%s", expected, got)
+	}
+
+	if got := (Where{Exprs: []Expression{Eq{Col: "id", Val: 1}}}).Explain(); got != "id = 1" {
+		t.Errorf("expected single condition on one line, got %s", got)
+	}
+}
@@ -0,0 +1,315 @@
+package clause
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// explainIndent 为 Explain 每层缩进的空白
+const explainIndent = "  "
+
+// String 返回表达式的可读文本，参数值以 SQL 字面量的形式内联（字符串中的单引号和控制字符会被转义），
+// 标识符不加引号，用于日志和测试失败信息。文本不保证能在数据库中执行。
+//
+// 示例:
+//
+//	clause.String(clause.Where{Exprs: []clause.Expression{
+//	    clause.Eq{Col: "name", Val: "O'Brien"},
+//	    clause.Or(clause.Gt{Col: "age", Val: 18}),
+//	}}) // name = 'O''Brien' OR age > 18
+func String(e Expression) string {
+	b := &debugBuilder{}
+	b.build(e)
+	return b.text()
+}
+
+// Explain 与 String 相同，但将 WHERE/HAVING 条件展开为多行，嵌套的 AND/OR/NOT 分组逐层缩进，
+// 分组按构建 SQL 时的实际优先级显式给出：
+//
+//	status = 'active'
+//	AND (
+//	  age > 18
+//	  OR role = 'admin'
+//	)
+func Explain(e Expression) string {
+	b := &debugBuilder{explain: true}
+	b.build(e)
+	return b.text()
+}
+
+// String 返回 WHERE 条件的可读文本，见 String 函数
+func (w Where) String() string {
+	return String(w)
+}
+
+// Explain 返回 WHERE 条件按分组缩进的多行文本，见 Explain 函数
+func (w Where) Explain() string {
+	return Explain(w)
+}
+
+// ConditionWriter 表示以自定义格式写入 WHERE/HAVING 条件的 Builder（如 Explain 的多行缩进格式）。
+// leaf 为构建条件中各表达式时使用的 Builder，包装其他 Builder 的实现应将自身作为 leaf 转发给内层 Builder，
+// 返回 false 表示未写入，条件按默认格式构建。
+type ConditionWriter interface {
+	WriteCondition(leaf Builder, keyword string, exprs []Expression) bool
+}
+
+// debugBuilder 将表达式构建为内联参数值的可读文本
+type debugBuilder struct {
+	strings.Builder
+	explain bool
+	errs    []error
+	// leaf 为构建条件中各表达式时使用的 Builder，为空时使用自身
+	leaf Builder
+	// newline 表示多行条件之后的子句需要另起一行
+	newline bool
+}
+
+func (b *debugBuilder) WriteByte(c byte) error {
+	if c == ' ' && b.breakLine() {
+		return nil
+	}
+	return b.Builder.WriteByte(c)
+}
+
+func (b *debugBuilder) WriteString(s string) (int, error) {
+	if strings.HasPrefix(s, " ") && b.breakLine() {
+		s = s[1:]
+	}
+	return b.Builder.WriteString(s)
+}
+
+// breakLine 在多行条件之后另起一行，返回 true 表示已用换行代替了空格
+func (b *debugBuilder) breakLine() bool {
+	if !b.newline {
+		return false
+	}
+	b.newline = false
+	b.Builder.WriteByte('\n')
+	return true
+}
+
+func (b *debugBuilder) WriteQuoted(field interface{}) {
+	switch v := field.(type) {
+	case string:
+		b.WriteString(v)
+	case Expression:
+		v.Build(b)
+	default:
+		b.WriteString(fmt.Sprint(v))
+	}
+}
+
+func (b *debugBuilder) AddVar(writer Writer, vars ...interface{}) {
+	for idx, v := range vars {
+		if idx > 0 {
+			writer.WriteString(", ")
+		}
+
+		switch v := v.(type) {
+		case Expression:
+			v.Build(b)
+		case []interface{}:
+			writer.WriteByte('(')
+			if len(v) > 0 {
+				b.AddVar(writer, v...)
+			} else {
+				writer.WriteString("NULL")
+			}
+			writer.WriteByte(')')
+		default:
+			writer.WriteString(formatValue(v, quoteLiteral))
+		}
+	}
+}
+
+func (b *debugBuilder) AddError(err error) error {
+	if err != nil {
+		b.errs = append(b.errs, err)
+	}
+	return err
+}
+
+// build 构建表达式，WHERE 条件只构建条件本身，不含 WHERE 关键字
+func (b *debugBuilder) build(e Expression) {
+	switch e := e.(type) {
+	case nil:
+	case Where:
+		if b.explain {
+			b.writeTree(expandSeq(e.Exprs, LogicAnd), 0)
+		} else {
+			buildExprs(e.Exprs, b, AndWithSpace)
+		}
+	default:
+		e.Build(b)
+	}
+}
+
+// text 返回构建的文本，构建过程中的错误以注释的形式附加在末尾
+func (b *debugBuilder) text() string {
+	text := b.String()
+	for _, err := range b.errs {
+		text += " /* error: " + err.Error() + " */"
+	}
+	return text
+}
+
+// WriteCondition 写入 WHERE/HAVING 条件，Explain 时关键字单独一行，条件逐行缩进
+func (b *debugBuilder) WriteCondition(leaf Builder, keyword string, exprs []Expression) bool {
+	if !b.explain {
+		b.WriteString(" " + keyword + " ")
+		buildExprs(exprs, leaf, AndWithSpace)
+		return true
+	}
+
+	b.leaf = leaf
+	defer func() { b.leaf = nil }()
+
+	b.newline = false
+	b.Builder.WriteString("\n" + keyword + "\n" + explainIndent)
+	b.writeTree(expandSeq(exprs, LogicAnd), 1)
+	b.newline = true
+	return true
+}
+
+// leafBuilder 返回构建条件中各表达式时使用的 Builder
+func (b *debugBuilder) leafBuilder() Builder {
+	if b.leaf != nil {
+		return b.leaf
+	}
+	return b
+}
+
+// writeTree 写入按 expand 展开的表达式，AND/OR 分组的子表达式逐行写入
+func (b *debugBuilder) writeTree(e Expression, depth int) {
+	switch e := e.(type) {
+	case AndExpr:
+		b.writeGroup(e.Exprs, "AND", depth)
+	case OrExpr:
+		b.writeGroup(e.Exprs, "OR", depth)
+	default:
+		b.writeNode(e, depth)
+	}
+}
+
+func (b *debugBuilder) writeGroup(exprs []Expression, op string, depth int) {
+	for idx, e := range exprs {
+		if idx > 0 {
+			b.Builder.WriteString("\n" + strings.Repeat(explainIndent, depth) + op + " ")
+		}
+		b.writeNode(e, depth)
+	}
+}
+
+// writeNode 写入单个节点，分组以括号包裹并缩进一层
+func (b *debugBuilder) writeNode(e Expression, depth int) {
+	switch e := e.(type) {
+	case nil:
+	case AndExpr, OrExpr:
+		b.writeNested("(", e, depth)
+	case NotExpr:
+		if len(e.Exprs) == 1 {
+			if _, ok := e.Exprs[0].(LogicalExpression); ok {
+				b.writeNested("NOT (", e.Exprs[0], depth)
+				return
+			}
+		}
+		e.Build(b.leafBuilder())
+	default:
+		e.Build(b.leafBuilder())
+	}
+}
+
+func (b *debugBuilder) writeNested(open string, e Expression, depth int) {
+	b.Builder.WriteString(open + "\n" + strings.Repeat(explainIndent, depth+1))
+	b.writeTree(e, depth+1)
+	b.Builder.WriteString("\n" + strings.Repeat(explainIndent, depth) + ")")
+}
+
+// formatValue 返回参数值的文本形式，String 与 Canonical 共用，quote 为字符串的引用方式。
+// 时间统一转为 UTC，map 按键排序，与时区和遍历顺序无关
+func formatValue(v any, quote func(string) string) string {
+	if valuer, ok := v.(driver.Valuer); ok && !eqNilReflect(valuer) {
+		if val, err := valuer.Value(); err == nil {
+			v = val
+		}
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return quote(v)
+	case []byte:
+		return "X'" + hex.EncodeToString(v) + "'"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case time.Time:
+		return quote(v.UTC().Format(time.RFC3339Nano))
+	case time.Duration:
+		return strconv.FormatInt(int64(v), 10)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "NULL"
+		}
+		return formatValue(rv.Elem().Interface(), quote)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	case reflect.String:
+		return quote(rv.String())
+	case reflect.Slice, reflect.Array:
+		terms := make([]string, rv.Len())
+		for i := range terms {
+			terms[i] = formatValue(rv.Index(i).Interface(), quote)
+		}
+		return "(" + strings.Join(terms, ", ") + ")"
+	case reflect.Map:
+		terms := make([]string, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			terms = append(terms, formatValue(iter.Key().Interface(), quote)+": "+formatValue(iter.Value().Interface(), quote))
+		}
+		sort.Strings(terms)
+		return "{" + strings.Join(terms, ", ") + "}"
+	}
+	return quote(fmt.Sprint(v))
+}
+
+// quoteLiteral 以单引号包裹字符串，单引号写为两个单引号，反斜杠和控制字符按 Go 的转义形式写入，
+// 确保内联的值不会破坏文本的结构（如换行打断日志），且字面的反斜杠与转义后的控制字符可以区分
+func quoteLiteral(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('\'')
+	for _, r := range s {
+		switch {
+		case r == '\'':
+			sb.WriteString("''")
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r < ' ' || r == 0x7f:
+			q := strconv.QuoteRune(r)
+			sb.WriteString(q[1 : len(q)-1])
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('\'')
+	return sb.String()
+}
@@ -1,6 +1,10 @@
 package clause
 
-import "reflect"
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
 
 // Expression 是查询表达式的抽象接口。
 // 所有查询组件（比较条件、逻辑组合、排序、分页等）都实现此接口，
@@ -10,7 +14,7 @@ type Expression interface {
 }
 
 // ComparisonExpression 表示比较表达式（叶子谓词）。
-// Eq、Neq、Gt、Gte、Lt、Lte、Like、IN 等类型实现此接口。
+// Eq、Neq、Gt、Gte、Lt、Lte、Like、IN、Between、IsNull、StartsWith 等类型实现此接口。
 type ComparisonExpression interface {
 	Expression
 	Operator() Operator  // 返回比较操作符
@@ -40,6 +44,11 @@ func (in IN) Build(builder Builder) {
 	case 0:
 		builder.WriteString(" IN (NULL)")
 	case 1:
+		if sub, ok := in.Vals[0].(SubQuery); ok {
+			builder.WriteString(" IN ")
+			sub.Build(builder)
+			break
+		}
 		if _, ok := in.Vals[0].([]interface{}); !ok {
 			builder.WriteString(" = ")
 			builder.AddVar(builder, in.Vals[0])
@@ -60,6 +69,11 @@ func (in IN) NegationBuild(builder Builder) {
 	case 0:
 		builder.WriteString(" IS NOT NULL")
 	case 1:
+		if sub, ok := in.Vals[0].(SubQuery); ok {
+			builder.WriteString(" NOT IN ")
+			sub.Build(builder)
+			break
+		}
 		if _, ok := in.Vals[0].([]interface{}); !ok {
 			builder.WriteString(" <> ")
 			builder.AddVar(builder, in.Vals[0])
@@ -282,6 +296,216 @@ func (like Like) Operator() Operator { return OpLIKE }
 func (like Like) Column() string     { return like.Col }
 func (like Like) Value() any         { return like.Val }
 
+// Between 表示 BETWEEN 范围条件，包含边界值
+type Between struct {
+	Col  string
+	From interface{}
+	To   interface{}
+}
+
+func (between Between) Build(builder Builder) {
+	buildBetween(builder, between, " BETWEEN ")
+}
+
+func (between Between) NegationBuild(builder Builder) {
+	NotBetween(between).Build(builder)
+}
+func (between Between) comparisonExpr()  {}
+func (between Between) Operator() Operator { return OpBETWEEN }
+func (between Between) Column() string     { return between.Col }
+func (between Between) Value() any         { return []any{between.From, between.To} }
+
+// NotBetween 表示 NOT BETWEEN 范围条件
+type NotBetween Between
+
+func (notBetween NotBetween) Build(builder Builder) {
+	buildBetween(builder, Between(notBetween), " NOT BETWEEN ")
+}
+
+func (notBetween NotBetween) NegationBuild(builder Builder) {
+	Between(notBetween).Build(builder)
+}
+func (notBetween NotBetween) comparisonExpr()  {}
+func (notBetween NotBetween) Operator() Operator { return OpNOTBETWEEN }
+func (notBetween NotBetween) Column() string     { return notBetween.Col }
+func (notBetween NotBetween) Value() any         { return []any{notBetween.From, notBetween.To} }
+
+func buildBetween(builder Builder, between Between, op string) {
+	builder.WriteQuoted(between.Col)
+	builder.WriteString(op)
+	builder.AddVar(builder, between.From)
+	builder.WriteString(" AND ")
+	builder.AddVar(builder, between.To)
+}
+
+// IsNull 表示 IS NULL 条件
+type IsNull struct {
+	Col string
+}
+
+func (isNull IsNull) Build(builder Builder) {
+	builder.WriteQuoted(isNull.Col)
+	builder.WriteString(" IS NULL")
+}
+
+func (isNull IsNull) NegationBuild(builder Builder) {
+	IsNotNull(isNull).Build(builder)
+}
+func (isNull IsNull) comparisonExpr()  {}
+func (isNull IsNull) Operator() Operator { return OpISNULL }
+func (isNull IsNull) Column() string     { return isNull.Col }
+func (isNull IsNull) Value() any         { return nil }
+
+// IsNotNull 表示 IS NOT NULL 条件
+type IsNotNull IsNull
+
+func (isNotNull IsNotNull) Build(builder Builder) {
+	builder.WriteQuoted(isNotNull.Col)
+	builder.WriteString(" IS NOT NULL")
+}
+
+func (isNotNull IsNotNull) NegationBuild(builder Builder) {
+	IsNull(isNotNull).Build(builder)
+}
+func (isNotNull IsNotNull) comparisonExpr()  {}
+func (isNotNull IsNotNull) Operator() Operator { return OpISNOTNULL }
+func (isNotNull IsNotNull) Column() string     { return isNotNull.Col }
+func (isNotNull IsNotNull) Value() any         { return nil }
+
+// NotIn 表示 NOT IN 条件
+type NotIn IN
+
+func (notIn NotIn) Build(builder Builder) {
+	IN(notIn).NegationBuild(builder)
+}
+
+func (notIn NotIn) NegationBuild(builder Builder) {
+	IN(notIn).Build(builder)
+}
+func (notIn NotIn) comparisonExpr()  {}
+func (notIn NotIn) Operator() Operator { return OpNOTIN }
+func (notIn NotIn) Column() string     { return notIn.Col }
+func (notIn NotIn) Value() any         { return notIn.Vals }
+
+// NotLike 表示 NOT LIKE 条件
+type NotLike Eq
+
+func (notLike NotLike) Build(builder Builder) {
+	Like(notLike).NegationBuild(builder)
+}
+
+func (notLike NotLike) NegationBuild(builder Builder) {
+	Like(notLike).Build(builder)
+}
+func (notLike NotLike) comparisonExpr()  {}
+func (notLike NotLike) Operator() Operator { return OpNOTLIKE }
+func (notLike NotLike) Column() string     { return notLike.Col }
+func (notLike NotLike) Value() any         { return notLike.Val }
+
+// ILike 表示不区分大小写的 LIKE 条件。
+// 为兼容不支持 ILIKE 的数据库，生成 LOWER(col) LIKE LOWER(?)。
+type ILike Eq
+
+func (iLike ILike) Build(builder Builder) {
+	buildILike(builder, iLike, " LIKE ")
+}
+
+func (iLike ILike) NegationBuild(builder Builder) {
+	buildILike(builder, iLike, " NOT LIKE ")
+}
+func (iLike ILike) comparisonExpr()  {}
+func (iLike ILike) Operator() Operator { return OpILIKE }
+func (iLike ILike) Column() string     { return iLike.Col }
+func (iLike ILike) Value() any         { return iLike.Val }
+
+func buildILike(builder Builder, iLike ILike, op string) {
+	builder.WriteString("LOWER(")
+	builder.WriteQuoted(iLike.Col)
+	builder.WriteString(")")
+	builder.WriteString(op)
+	builder.WriteString("LOWER(")
+	builder.AddVar(builder, iLike.Val)
+	builder.WriteString(")")
+}
+
+// LikeEscapeChar 是 StartsWith、EndsWith、Contains 使用的 LIKE 转义字符。
+// 使用 '!' 而不是反斜杠，以避免各数据库对字符串字面量中反斜杠的不同解释。
+const LikeEscapeChar = '!'
+
+// EscapeLike 转义 LIKE 模式中的通配符 % 和 _ 以及转义字符本身，
+// 使其在 ESCAPE '!' 的 LIKE 条件中按字面匹配。
+func EscapeLike(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '%', '_', LikeEscapeChar:
+			b.WriteByte(LikeEscapeChar)
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// StartsWith 表示前缀匹配条件，值中的通配符会被转义
+type StartsWith Eq
+
+func (startsWith StartsWith) Build(builder Builder) {
+	buildEscapedLike(builder, startsWith.Col, " LIKE ", "", startsWith.Val, "%")
+}
+
+func (startsWith StartsWith) NegationBuild(builder Builder) {
+	buildEscapedLike(builder, startsWith.Col, " NOT LIKE ", "", startsWith.Val, "%")
+}
+func (startsWith StartsWith) comparisonExpr()  {}
+func (startsWith StartsWith) Operator() Operator { return OpSTARTSWITH }
+func (startsWith StartsWith) Column() string     { return startsWith.Col }
+func (startsWith StartsWith) Value() any         { return startsWith.Val }
+
+// EndsWith 表示后缀匹配条件，值中的通配符会被转义
+type EndsWith Eq
+
+func (endsWith EndsWith) Build(builder Builder) {
+	buildEscapedLike(builder, endsWith.Col, " LIKE ", "%", endsWith.Val, "")
+}
+
+func (endsWith EndsWith) NegationBuild(builder Builder) {
+	buildEscapedLike(builder, endsWith.Col, " NOT LIKE ", "%", endsWith.Val, "")
+}
+func (endsWith EndsWith) comparisonExpr()  {}
+func (endsWith EndsWith) Operator() Operator { return OpENDSWITH }
+func (endsWith EndsWith) Column() string     { return endsWith.Col }
+func (endsWith EndsWith) Value() any         { return endsWith.Val }
+
+// Contains 表示包含子串的匹配条件，值中的通配符会被转义
+type Contains Eq
+
+func (contains Contains) Build(builder Builder) {
+	buildEscapedLike(builder, contains.Col, " LIKE ", "%", contains.Val, "%")
+}
+
+func (contains Contains) NegationBuild(builder Builder) {
+	buildEscapedLike(builder, contains.Col, " NOT LIKE ", "%", contains.Val, "%")
+}
+func (contains Contains) comparisonExpr()  {}
+func (contains Contains) Operator() Operator { return OpCONTAINS }
+func (contains Contains) Column() string     { return contains.Col }
+func (contains Contains) Value() any         { return contains.Val }
+
+// LikePattern 返回转义后并加上前后缀通配符的 LIKE 模式，供适配器复用。
+func LikePattern(prefix string, value any, suffix string) string {
+	return prefix + EscapeLike(fmt.Sprint(value)) + suffix
+}
+
+func buildEscapedLike(builder Builder, col, op, prefix string, value any, suffix string) {
+	builder.WriteQuoted(col)
+	builder.WriteString(op)
+	builder.AddVar(builder, LikePattern(prefix, value, suffix))
+	builder.WriteString(" ESCAPE '!'")
+}
+
 func eqNil(value interface{}) bool {
 	if valuer, ok := value.(Valuer); ok && !eqNilReflect(valuer) {
 		value, _ = valuer.Value()
@@ -294,3 +518,65 @@ func eqNilReflect(value interface{}) bool {
 	reflectValue := reflect.ValueOf(value)
 	return reflectValue.Kind() == reflect.Ptr && reflectValue.IsNil()
 }
+
+// WithColumn 返回替换了列名的比较表达式副本，其余字段保持不变。
+// 常用于将字段名映射为实际列名或别名对应的表达式。
+func WithColumn(expr ComparisonExpression, column string) ComparisonExpression {
+	switch e := expr.(type) {
+	case Eq:
+		e.Col = column
+		return e
+	case Neq:
+		e.Col = column
+		return e
+	case Gt:
+		e.Col = column
+		return e
+	case Gte:
+		e.Col = column
+		return e
+	case Lt:
+		e.Col = column
+		return e
+	case Lte:
+		e.Col = column
+		return e
+	case Like:
+		e.Col = column
+		return e
+	case IN:
+		e.Col = column
+		return e
+	case Between:
+		e.Col = column
+		return e
+	case NotBetween:
+		e.Col = column
+		return e
+	case IsNull:
+		e.Col = column
+		return e
+	case IsNotNull:
+		e.Col = column
+		return e
+	case NotIn:
+		e.Col = column
+		return e
+	case NotLike:
+		e.Col = column
+		return e
+	case ILike:
+		e.Col = column
+		return e
+	case StartsWith:
+		e.Col = column
+		return e
+	case EndsWith:
+		e.Col = column
+		return e
+	case Contains:
+		e.Col = column
+		return e
+	}
+	return expr
+}
@@ -0,0 +1,98 @@
+package clause
+
+// Column 表示一个列引用。
+// 作为比较表达式的值使用时，生成列与列之间的比较，而不是绑定参数，
+// 常用于 JOIN 的 ON 条件，例如：
+//
+//	Eq{Col: "orders.user_id", Val: Column{Name: "users.id"}} // `orders`.`user_id` = `users`.`id`
+type Column struct {
+	Name string
+}
+
+func (c Column) Build(builder Builder) {
+	builder.WriteQuoted(c.Name)
+}
+
+// Table 表示数据表及其别名。
+type Table struct {
+	Name  string
+	Alias string
+}
+
+func (t Table) Build(builder Builder) {
+	builder.WriteQuoted(t.Name)
+	if t.Alias != "" {
+		builder.WriteString(" AS ")
+		builder.WriteQuoted(t.Alias)
+	}
+}
+
+// TargetTableBuilder 表示支持按方言构建 UPDATE/DELETE 目标表的 Builder。
+// 部分数据库不能直接为目标表指定别名（如 SQL Server），不支持的方言应通过 AddError 记录错误。
+type TargetTableBuilder interface {
+	BuildTargetTable(table Table)
+}
+
+// BuildTarget 构建 UPDATE/DELETE 的目标表。
+// 默认与 Build 相同，Builder 实现了 TargetTableBuilder 时交由其生成。
+func (t Table) BuildTarget(builder Builder) {
+	if tb, ok := builder.(TargetTableBuilder); ok {
+		tb.BuildTargetTable(t)
+		return
+	}
+	t.Build(builder)
+}
+
+// JoinType 表示 JOIN 的类型。
+type JoinType string
+
+// 支持的 JOIN 类型。
+const (
+	InnerJoin JoinType = "INNER"
+	LeftJoin  JoinType = "LEFT"
+	RightJoin JoinType = "RIGHT"
+	CrossJoin JoinType = "CROSS"
+)
+
+// Join 表示一个 JOIN 子句。
+// On 中的表达式之间以 AND 连接；CROSS JOIN 忽略 On。
+type Join struct {
+	Type  JoinType
+	Table Table
+	On    Where
+}
+
+func (j Join) Build(builder Builder) {
+	if j.Type != "" {
+		builder.WriteString(string(j.Type))
+		builder.WriteByte(' ')
+	}
+	builder.WriteString("JOIN ")
+	j.Table.Build(builder)
+
+	if j.Type == CrossJoin {
+		return
+	}
+
+	exprs := j.On.Exprs
+	if len(exprs) == 1 {
+		if logical, ok := exprs[0].(LogicalExpression); ok && logical.Operator() == LogicAnd {
+			exprs = logical.SubExprs()
+		}
+	}
+	if len(exprs) > 0 {
+		builder.WriteString(" ON ")
+		buildExprs(exprs, builder, AndWithSpace)
+	}
+}
+
+// Joins 是 JOIN 子句列表。
+type Joins []Join
+
+// Build 依次构建 JOIN 子句，每个子句前以空格分隔。
+func (joins Joins) Build(builder Builder) {
+	for _, j := range joins {
+		builder.WriteByte(' ')
+		j.Build(builder)
+	}
+}
@@ -0,0 +1,443 @@
+package clause
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// JSONVersion 为 Where、OrderBys、Pagination 的 JSON 格式版本。
+// 格式变化不兼容时递增，解码时拒绝未知的版本。
+const JSONVersion = 1
+
+var (
+	// ErrUnsupportedJSON 表示表达式无法编码为 JSON，如原生 SQL、子查询和按表达式排序。
+	ErrUnsupportedJSON = errors.New("expression not supported by json encoding")
+	// ErrInvalidJSON 表示 JSON 无法解码为表达式，或版本不受支持。
+	ErrInvalidJSON = errors.New("invalid expression json")
+)
+
+// jsonExpr 是表达式的 JSON 形式。
+//
+// 比较表达式：{"op": "=", "col": "age", "val": {"t": "i", "v": "18"}}，
+// IN、NOT IN、BETWEEN、NOT BETWEEN 的值为 "vals"，IS NULL、IS NOT NULL 没有值；
+// 逻辑表达式：{"op": "AND", "exprs": [...]}，op 为 AND、OR、NOT。
+type jsonExpr struct {
+	Op    string            `json:"op"`
+	Col   string            `json:"col,omitempty"`
+	Val   json.RawMessage   `json:"val,omitempty"`
+	Vals  []json.RawMessage `json:"vals,omitempty"`
+	Exprs []*jsonExpr       `json:"exprs,omitempty"`
+}
+
+// 逻辑表达式在 JSON 中的运算符
+const (
+	jsonOpAnd = "AND"
+	jsonOpOr  = "OR"
+	jsonOpNot = "NOT"
+)
+
+type jsonWhere struct {
+	Version int         `json:"version"`
+	Exprs   []*jsonExpr `json:"exprs"`
+}
+
+type jsonOrderBy struct {
+	Col  string `json:"col"`
+	Desc bool   `json:"desc,omitempty"`
+}
+
+type jsonOrderBys struct {
+	Version int           `json:"version"`
+	Orders  []jsonOrderBy `json:"orders"`
+}
+
+type jsonPagination struct {
+	Version int               `json:"version"`
+	Limit   *int              `json:"limit,omitempty"`
+	Offset  int               `json:"offset,omitempty"`
+	After   []json.RawMessage `json:"after,omitempty"`
+}
+
+// MarshalJSON 将 WHERE 条件编码为带版本号的 JSON，保留表达式树的结构：
+//
+//	{"version": 1, "exprs": [{"op": "=", "col": "status", "val": {"t": "s", "v": "active"}}]}
+//
+// 值带有类型标记，解码后整数为 int64/uint64、浮点数为 float64，time.Time、time.Duration、
+// []byte、nil 和 Column 保持不变，构建的 SQL 与编码前一致。
+// 原生 SQL、子查询等表达式返回 ErrUnsupportedJSON。
+func (w Where) MarshalJSON() ([]byte, error) {
+	exprs, err := toJSONExprs(w.Exprs)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonWhere{Version: JSONVersion, Exprs: exprs})
+}
+
+// UnmarshalJSON 解码 MarshalJSON 生成的 JSON
+func (w *Where) UnmarshalJSON(data []byte) error {
+	var jw jsonWhere
+	if err := json.Unmarshal(data, &jw); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	if err := checkJSONVersion(jw.Version); err != nil {
+		return err
+	}
+
+	exprs, err := fromJSONExprs(jw.Exprs)
+	if err != nil {
+		return err
+	}
+	w.Exprs = exprs
+	return nil
+}
+
+// MarshalJSON 将排序条件编码为带版本号的 JSON：
+//
+//	{"version": 1, "orders": [{"col": "age", "desc": true}]}
+//
+// 按表达式排序返回 ErrUnsupportedJSON。
+func (o OrderBys) MarshalJSON() ([]byte, error) {
+	orders := make([]jsonOrderBy, 0, len(o))
+	for _, order := range o {
+		if order == nil {
+			continue
+		}
+		if order.Expr != nil {
+			return nil, fmt.Errorf("%w: order by %T", ErrUnsupportedJSON, order.Expr)
+		}
+		orders = append(orders, jsonOrderBy{Col: order.Column, Desc: order.Desc})
+	}
+	return json.Marshal(jsonOrderBys{Version: JSONVersion, Orders: orders})
+}
+
+// UnmarshalJSON 解码 MarshalJSON 生成的 JSON
+func (o *OrderBys) UnmarshalJSON(data []byte) error {
+	var jo jsonOrderBys
+	if err := json.Unmarshal(data, &jo); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	if err := checkJSONVersion(jo.Version); err != nil {
+		return err
+	}
+
+	orders := make(OrderBys, 0, len(jo.Orders))
+	for _, order := range jo.Orders {
+		if order.Col == "" {
+			return fmt.Errorf("%w: order by without column", ErrInvalidJSON)
+		}
+		orders = append(orders, &OrderBy{Column: order.Col, Desc: order.Desc})
+	}
+	*o = orders
+	return nil
+}
+
+// MarshalJSON 将分页编码为带版本号的 JSON：
+//
+//	{"version": 1, "limit": 10, "offset": 20, "after": [{"t": "i", "v": "100"}]}
+func (p Pagination) MarshalJSON() ([]byte, error) {
+	after, err := toJSONValues(p.After)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(jsonPagination{Version: JSONVersion, Limit: p.Limit, Offset: p.Offset, After: after})
+}
+
+// UnmarshalJSON 解码 MarshalJSON 生成的 JSON
+func (p *Pagination) UnmarshalJSON(data []byte) error {
+	var jp jsonPagination
+	if err := json.Unmarshal(data, &jp); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	if err := checkJSONVersion(jp.Version); err != nil {
+		return err
+	}
+
+	after, err := fromJSONValues(jp.After)
+	if err != nil {
+		return err
+	}
+	*p = Pagination{Limit: jp.Limit, Offset: jp.Offset, After: after}
+	return nil
+}
+
+// MarshalExpression 将单个表达式编码为 JSON（不含版本号），格式与 Where 中的表达式相同
+func MarshalExpression(e Expression) ([]byte, error) {
+	je, err := toJSONExpr(e)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(je)
+}
+
+// UnmarshalExpression 解码 MarshalExpression 生成的 JSON
+func UnmarshalExpression(data []byte) (Expression, error) {
+	var je jsonExpr
+	if err := json.Unmarshal(data, &je); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	return fromJSONExpr(&je)
+}
+
+func checkJSONVersion(version int) error {
+	if version != JSONVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidJSON, version)
+	}
+	return nil
+}
+
+func toJSONExprs(exprs []Expression) ([]*jsonExpr, error) {
+	result := make([]*jsonExpr, 0, len(exprs))
+	for _, e := range exprs {
+		if e == nil {
+			continue
+		}
+		je, err := toJSONExpr(e)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, je)
+	}
+	return result, nil
+}
+
+func toJSONExpr(e Expression) (*jsonExpr, error) {
+	switch e := e.(type) {
+	case LogicalExpression:
+		exprs, err := toJSONExprs(e.SubExprs())
+		if err != nil {
+			return nil, err
+		}
+		op := jsonOpAnd
+		switch e.Operator() {
+		case LogicOr:
+			op = jsonOpOr
+		case LogicNot:
+			op = jsonOpNot
+		}
+		return &jsonExpr{Op: op, Exprs: exprs}, nil
+
+	case ComparisonExpression:
+		je := &jsonExpr{Op: string(e.Operator()), Col: e.Column()}
+		switch e.Operator() {
+		case OpISNULL, OpISNOTNULL:
+		case OpIN, OpNOTIN, OpBETWEEN, OpNOTBETWEEN:
+			vals, err := toJSONValues(e.Value().([]any))
+			if err != nil {
+				return nil, err
+			}
+			je.Vals = vals
+		default:
+			val, err := toJSONValue(e.Value())
+			if err != nil {
+				return nil, err
+			}
+			je.Val = val
+		}
+		return je, nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedJSON, e)
+}
+
+func fromJSONExprs(jes []*jsonExpr) ([]Expression, error) {
+	exprs := make([]Expression, 0, len(jes))
+	for _, je := range jes {
+		if je == nil {
+			return nil, fmt.Errorf("%w: null expression", ErrInvalidJSON)
+		}
+		e, err := fromJSONExpr(je)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+	return exprs, nil
+}
+
+func fromJSONExpr(je *jsonExpr) (Expression, error) {
+	switch je.Op {
+	case jsonOpAnd, jsonOpOr, jsonOpNot:
+		exprs, err := fromJSONExprs(je.Exprs)
+		if err != nil {
+			return nil, err
+		}
+		if len(exprs) == 0 {
+			return nil, fmt.Errorf("%w: empty %s", ErrInvalidJSON, je.Op)
+		}
+		switch je.Op {
+		case jsonOpOr:
+			return OrExpr{Exprs: exprs}, nil
+		case jsonOpNot:
+			return NotExpr{Exprs: exprs}, nil
+		}
+		return AndExpr{Exprs: exprs}, nil
+	}
+
+	if je.Col == "" {
+		return nil, fmt.Errorf("%w: %q without column", ErrInvalidJSON, je.Op)
+	}
+
+	op := Operator(je.Op)
+	switch op {
+	case OpISNULL:
+		return IsNull{Col: je.Col}, nil
+	case OpISNOTNULL:
+		return IsNotNull{Col: je.Col}, nil
+	case OpIN, OpNOTIN, OpBETWEEN, OpNOTBETWEEN:
+		vals, err := fromJSONValues(je.Vals)
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case OpIN:
+			return IN{Col: je.Col, Vals: vals}, nil
+		case OpNOTIN:
+			return NotIn{Col: je.Col, Vals: vals}, nil
+		}
+		if len(vals) != 2 {
+			return nil, fmt.Errorf("%w: %s requires 2 values", ErrInvalidJSON, op)
+		}
+		if op == OpBETWEEN {
+			return Between{Col: je.Col, From: vals[0], To: vals[1]}, nil
+		}
+		return NotBetween{Col: je.Col, From: vals[0], To: vals[1]}, nil
+	}
+
+	val, err := fromJSONValue(je.Val)
+	if err != nil {
+		return nil, err
+	}
+	eq := Eq{Col: je.Col, Val: val}
+	switch op {
+	case OpEQ:
+		return eq, nil
+	case OpNEQ:
+		return Neq(eq), nil
+	case OpGT:
+		return Gt(eq), nil
+	case OpGTE:
+		return Gte(eq), nil
+	case OpLT:
+		return Lt(eq), nil
+	case OpLTE:
+		return Lte(eq), nil
+	case OpLIKE:
+		return Like(eq), nil
+	case OpNOTLIKE:
+		return NotLike(eq), nil
+	case OpILIKE:
+		return ILike(eq), nil
+	case OpSTARTSWITH:
+		return StartsWith(eq), nil
+	case OpENDSWITH:
+		return EndsWith(eq), nil
+	case OpCONTAINS:
+		return Contains(eq), nil
+	}
+	return nil, fmt.Errorf("%w: unknown operator %q", ErrInvalidJSON, je.Op)
+}
+
+// jsonColumn 是 Column 值的类型标记
+const jsonColumn = "c"
+
+// toJSONValue 编码单个值：nil 为 null，切片为数组，Column 为 {"t": "c", "v": 列名}，
+// 其余值与游标使用相同的带类型编码
+func toJSONValue(v any) (json.RawMessage, error) {
+	if eqNil(v) {
+		return json.RawMessage("null"), nil
+	}
+
+	switch v := v.(type) {
+	case Column:
+		return json.Marshal(cursorValue{T: jsonColumn, V: v.Name})
+	case Expression:
+		return nil, fmt.Errorf("%w: value %T", ErrUnsupportedJSON, v)
+	case []byte:
+		// []byte 作为单个值
+	default:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+			vals := make([]any, rv.Len())
+			for i := range vals {
+				vals[i] = rv.Index(i).Interface()
+			}
+			list, err := toJSONValues(vals)
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(list)
+		}
+	}
+
+	cv, err := encodeCursorValue(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedJSON, err)
+	}
+	return json.Marshal(cv)
+}
+
+func toJSONValues(vals []any) ([]json.RawMessage, error) {
+	if vals == nil {
+		return nil, nil
+	}
+	result := make([]json.RawMessage, len(vals))
+	for i, v := range vals {
+		raw, err := toJSONValue(v)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = raw
+	}
+	return result, nil
+}
+
+func fromJSONValue(raw json.RawMessage) (any, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+
+	if raw[0] == '[' {
+		var list []json.RawMessage
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+		}
+		vals, err := fromJSONValues(list)
+		if err != nil {
+			return nil, err
+		}
+		if vals == nil {
+			vals = []any{}
+		}
+		return vals, nil
+	}
+
+	var cv cursorValue
+	if err := json.Unmarshal(raw, &cv); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	if cv.T == jsonColumn {
+		return Column{Name: cv.V}, nil
+	}
+	v, err := decodeCursorValue(cv)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	return v, nil
+}
+
+func fromJSONValues(list []json.RawMessage) ([]any, error) {
+	if list == nil {
+		return nil, nil
+	}
+	vals := make([]any, len(list))
+	for i, raw := range list {
+		v, err := fromJSONValue(raw)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = v
+	}
+	return vals, nil
+}
@@ -0,0 +1,256 @@
+package clause
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidCursor 表示游标无法解析，或其键值与排序字段不匹配。
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Keyset 表示键集（游标）分页条件，根据排序字段和上一页最后一行的键值，
+// 生成位于该行之后的比较条件，代替 OFFSET 进行翻页。
+//
+// Values 与 OrderBys 一一对应，ASC 字段取大于、DESC 字段取小于。
+// 排序字段应能唯一确定一行（通常以主键结尾），且键值不能为 NULL。
+//
+// 示例:
+//
+//	Keyset{OrderBys: OrderBys{{Column: "age"}, {Column: "id"}}, Values: []any{18, 100}}
+//	// (`age`, `id`) > ($1, $2)
+//	Keyset{OrderBys: OrderBys{{Column: "age", Desc: true}, {Column: "id"}}, Values: []any{18, 100}}
+//	// (`age` < $1 OR (`age` = $2 AND `id` > $3))
+type Keyset struct {
+	OrderBys OrderBys
+	Values   []any
+}
+
+// KeysetBuilder 表示支持按方言构建键集分页条件的 Builder。
+// 如 SQL Server 不支持行值比较，需要使用 BuildExpanded 生成展开的 OR 条件。
+type KeysetBuilder interface {
+	BuildKeyset(k Keyset)
+}
+
+// Validate 检查排序字段与键值是否匹配
+func (k Keyset) Validate() error {
+	if len(k.OrderBys) == 0 || len(k.Values) != len(k.OrderBys) {
+		return ErrInvalidCursor
+	}
+	for _, order := range k.OrderBys {
+		if order == nil || order.Column == "" || order.Expr != nil {
+			return fmt.Errorf("%w: keyset requires column order by", ErrInvalidCursor)
+		}
+	}
+	return nil
+}
+
+// IsCompound 判断是否可以使用行值比较，即排序字段多于一个且方向一致
+func (k Keyset) IsCompound() bool {
+	if len(k.OrderBys) < 2 {
+		return false
+	}
+	for _, order := range k.OrderBys[1:] {
+		if order.Desc != k.OrderBys[0].Desc {
+			return false
+		}
+	}
+	return true
+}
+
+// Build 构建键集分页条件。
+// 排序方向一致时生成行值比较 (a, b) > (?, ?)，否则生成展开的 OR 条件。
+func (k Keyset) Build(builder Builder) {
+	if err := k.Validate(); err != nil {
+		builder.AddError(err)
+		return
+	}
+
+	if kb, ok := builder.(KeysetBuilder); ok {
+		kb.BuildKeyset(k)
+		return
+	}
+
+	if k.IsCompound() {
+		k.BuildCompound(builder)
+	} else {
+		k.BuildExpanded(builder)
+	}
+}
+
+// BuildCompound 生成行值比较 (a, b) > (?, ?)，方向以第一个排序字段为准
+func (k Keyset) BuildCompound(builder Builder) {
+	builder.WriteByte('(')
+	for idx, order := range k.OrderBys {
+		if idx > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteQuoted(order.Column)
+	}
+	if k.OrderBys[0].Desc {
+		builder.WriteString(") < (")
+	} else {
+		builder.WriteString(") > (")
+	}
+	for idx, v := range k.Values {
+		if idx > 0 {
+			builder.WriteString(", ")
+		}
+		builder.AddVar(builder, v)
+	}
+	builder.WriteByte(')')
+}
+
+// BuildExpanded 生成展开的 OR 条件
+func (k Keyset) BuildExpanded(builder Builder) {
+	k.Condition().Build(builder)
+}
+
+// Condition 返回由比较表达式组成的展开条件：
+// a > ? OR (a = ? AND b > ?) OR (a = ? AND b = ? AND c > ?) ...
+// 适配器可直接按普通条件转换。排序字段与键值不匹配时返回 nil。
+func (k Keyset) Condition() Expression {
+	if k.Validate() != nil {
+		return nil
+	}
+
+	ors := make([]Expression, 0, len(k.OrderBys))
+	for i, order := range k.OrderBys {
+		ands := make([]Expression, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, Eq{Col: k.OrderBys[j].Column, Val: k.Values[j]})
+		}
+		if order.Desc {
+			ands = append(ands, Lt{Col: order.Column, Val: k.Values[i]})
+		} else {
+			ands = append(ands, Gt{Col: order.Column, Val: k.Values[i]})
+		}
+		ors = append(ors, And(ands...))
+	}
+
+	if len(ors) == 1 {
+		return ors[0]
+	}
+	return Or(ors...)
+}
+
+// cursorValue 是游标中单个键值的编码形式，T 记录值的类型以便解码时还原
+type cursorValue struct {
+	T string `json:"t"`
+	V string `json:"v"`
+}
+
+// EncodeCursor 将键值编码为不透明的游标字符串（URL 安全的 Base64）。
+// 支持整数、浮点数、字符串、布尔值、[]byte、time.Time 和 time.Duration，
+// 指针和 driver.Valuer 取其指向的值或 Value() 的结果，其他类型及 NULL 返回错误。
+func EncodeCursor(values []any) (string, error) {
+	encoded := make([]cursorValue, len(values))
+	for idx, v := range values {
+		cv, err := encodeCursorValue(v)
+		if err != nil {
+			return "", err
+		}
+		encoded[idx] = cv
+	}
+
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor 解析 EncodeCursor 生成的游标，返回键值。
+// 整数解码为 int64/uint64，浮点数为 float64，时间为 time.Time，时长为 time.Duration。
+func DecodeCursor(cursor string) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	var encoded []cursorValue
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	values := make([]any, len(encoded))
+	for idx, cv := range encoded {
+		v, err := decodeCursorValue(cv)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+		}
+		values[idx] = v
+	}
+	return values, nil
+}
+
+func encodeCursorValue(v any) (cursorValue, error) {
+	if valuer, ok := v.(driver.Valuer); ok {
+		val, err := valuer.Value()
+		if err != nil {
+			return cursorValue{}, err
+		}
+		v = val
+	}
+
+	switch v := v.(type) {
+	case string:
+		return cursorValue{T: "s", V: v}, nil
+	case []byte:
+		return cursorValue{T: "x", V: base64.RawURLEncoding.EncodeToString(v)}, nil
+	case bool:
+		return cursorValue{T: "b", V: strconv.FormatBool(v)}, nil
+	case time.Time:
+		return cursorValue{T: "t", V: v.Format(time.RFC3339Nano)}, nil
+	case time.Duration:
+		return cursorValue{T: "d", V: strconv.FormatInt(int64(v), 10)}, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return cursorValue{}, fmt.Errorf("%w: cursor value is null", ErrInvalidCursor)
+		}
+		return encodeCursorValue(rv.Elem().Interface())
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cursorValue{T: "i", V: strconv.FormatInt(rv.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cursorValue{T: "u", V: strconv.FormatUint(rv.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return cursorValue{T: "f", V: strconv.FormatFloat(rv.Float(), 'g', -1, 64)}, nil
+	case reflect.String:
+		return cursorValue{T: "s", V: rv.String()}, nil
+	}
+	return cursorValue{}, fmt.Errorf("%w: unsupported cursor value type %T", ErrInvalidCursor, v)
+}
+
+func decodeCursorValue(cv cursorValue) (any, error) {
+	switch cv.T {
+	case "s":
+		return cv.V, nil
+	case "x":
+		return base64.RawURLEncoding.DecodeString(cv.V)
+	case "b":
+		return strconv.ParseBool(cv.V)
+	case "t":
+		return time.Parse(time.RFC3339Nano, cv.V)
+	case "d":
+		d, err := strconv.ParseInt(cv.V, 10, 64)
+		return time.Duration(d), err
+	case "i":
+		return strconv.ParseInt(cv.V, 10, 64)
+	case "u":
+		return strconv.ParseUint(cv.V, 10, 64)
+	case "f":
+		return strconv.ParseFloat(cv.V, 64)
+	}
+	return nil, fmt.Errorf("unknown cursor value type %q", cv.T)
+}
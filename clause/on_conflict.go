@@ -0,0 +1,83 @@
+package clause
+
+// Assignment 表示 SET 子句中的单个赋值，生成 column = value。
+type Assignment struct {
+	Column string
+	Value  any
+}
+
+func (a Assignment) Build(builder Builder) {
+	builder.WriteQuoted(a.Column)
+	builder.WriteString(" = ")
+	builder.AddVar(builder, a.Value)
+}
+
+// Excluded 引用 INSERT 中因冲突而未插入的新值，用于冲突时的更新赋值。
+// 默认生成 PostgreSQL/SQLite 的 excluded.column，MySQL 方言生成 VALUES(column)。
+type Excluded struct {
+	Column string
+}
+
+func (e Excluded) Build(builder Builder) {
+	builder.WriteString("excluded.")
+	builder.WriteQuoted(e.Column)
+}
+
+// OnConflict 表示 INSERT 的冲突处理子句（UPSERT）。
+//
+// Columns 为冲突目标列（唯一约束）；DoNothing 为 true 时忽略冲突行，
+// 否则按 DoUpdates 更新已存在的行。DoNothing 和 DoUpdates 均为空时不输出任何内容。
+type OnConflict struct {
+	Columns   []string
+	DoNothing bool
+	DoUpdates []Assignment
+}
+
+// OnConflictBuilder 表示支持按方言构建冲突处理子句的 Builder。
+// 如 MySQL 使用 ON DUPLICATE KEY UPDATE 语法，
+// 如果 Builder 实现了此接口，OnConflict.Build 会交由其生成冲突处理子句。
+type OnConflictBuilder interface {
+	BuildOnConflict(c OnConflict)
+}
+
+// IsEmpty 判断是否未设置冲突处理动作
+func (c OnConflict) IsEmpty() bool {
+	return !c.DoNothing && len(c.DoUpdates) == 0
+}
+
+// Build 构建 ON CONFLICT 子句，默认使用 PostgreSQL/SQLite 语法。
+func (c OnConflict) Build(builder Builder) {
+	if c.IsEmpty() {
+		return
+	}
+
+	if cb, ok := builder.(OnConflictBuilder); ok {
+		cb.BuildOnConflict(c)
+		return
+	}
+
+	builder.WriteString(" ON CONFLICT")
+	if len(c.Columns) > 0 {
+		builder.WriteString(" (")
+		for idx, column := range c.Columns {
+			if idx > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteQuoted(column)
+		}
+		builder.WriteByte(')')
+	}
+
+	if c.DoNothing {
+		builder.WriteString(" DO NOTHING")
+		return
+	}
+
+	builder.WriteString(" DO UPDATE SET ")
+	for idx, assignment := range c.DoUpdates {
+		if idx > 0 {
+			builder.WriteString(", ")
+		}
+		assignment.Build(builder)
+	}
+}
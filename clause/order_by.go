@@ -1,13 +1,19 @@
 package clause
 
 // OrderBy 表示排序条件
+// Expr 不为空时按表达式排序（如 Raw("FIELD(status, ?, ?)", "a", "b")），忽略 Column。
 type OrderBy struct {
 	Column string
 	Desc   bool
+	Expr   Expression
 }
 
 func (o OrderBy) Build(builder Builder) {
-	builder.WriteQuoted(o.Column)
+	if o.Expr != nil {
+		o.Expr.Build(builder)
+	} else {
+		builder.WriteQuoted(o.Column)
+	}
 	if o.Desc {
 		builder.WriteString(" DESC")
 	} else {
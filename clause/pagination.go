@@ -2,12 +2,44 @@ package clause
 
 // Pagination 表示分页信息，包含 LIMIT 和 OFFSET。
 // Limit 为指针类型，nil 表示不限制；Offset 默认为 0。
+//
+// After 为键集（游标）分页的键值，即上一页最后一行的排序字段值。
+// 它不在分页子句中输出，而是由查询结合 ORDER BY 生成 Keyset 条件并入 WHERE。
 type Pagination struct {
 	Limit  *int
 	Offset int
+	After  []any
 }
 
+// PaginationBuilder 表示支持按方言构建分页子句的 Builder。
+// 不同数据库的分页语法差异较大（如 SQL Server 的 OFFSET ... FETCH），
+// 如果 Builder 实现了此接口，Pagination.Build 会交由其生成分页子句。
+// ordered 表示当前语句是否已包含 ORDER BY 子句（不含子查询中的 ORDER BY）。
+type PaginationBuilder interface {
+	BuildPagination(p Pagination, ordered bool)
+}
+
+// Keyset 根据排序字段返回键集分页条件，未设置 After 时返回 nil。
+func (p Pagination) Keyset(orders OrderBys) Expression {
+	if len(p.After) == 0 {
+		return nil
+	}
+	return Keyset{OrderBys: orders, Values: p.After}
+}
+
+// Build 构建分页子句，视为语句不包含 ORDER BY，见 BuildOrdered。
 func (p Pagination) Build(builder Builder) {
+	p.BuildOrdered(builder, false)
+}
+
+// BuildOrdered 构建分页子句，ordered 表示当前语句是否已包含 ORDER BY 子句，
+// 供要求分页必须带 ORDER BY 的方言（如 SQL Server）判断是否需要补充排序。
+func (p Pagination) BuildOrdered(builder Builder, ordered bool) {
+	if pb, ok := builder.(PaginationBuilder); ok {
+		pb.BuildPagination(p, ordered)
+		return
+	}
+
 	// 构建 LIMIT 部分
 	if p.Limit != nil && *p.Limit > 0 {
 		builder.WriteString(" LIMIT ")
@@ -20,3 +52,25 @@ func (p Pagination) Build(builder Builder) {
 		builder.AddVar(builder, p.Offset)
 	}
 }
+
+// RowLimitBuilder 表示支持按方言构建 UPDATE/DELETE 行数限制的 Builder。
+// 只有部分数据库支持 UPDATE/DELETE ... LIMIT（如 MySQL），不支持的方言应通过 AddError 记录错误。
+type RowLimitBuilder interface {
+	BuildRowLimit(limit int)
+}
+
+// BuildRowLimit 在 UPDATE/DELETE 语句末尾构建行数限制，只使用 Limit。
+// 默认使用 LIMIT 语法，Builder 实现了 RowLimitBuilder 时交由其生成。
+func (p Pagination) BuildRowLimit(builder Builder) {
+	if p.Limit == nil || *p.Limit <= 0 {
+		return
+	}
+
+	if rb, ok := builder.(RowLimitBuilder); ok {
+		rb.BuildRowLimit(*p.Limit)
+		return
+	}
+
+	builder.WriteString(" LIMIT ")
+	builder.AddVar(builder, *p.Limit)
+}
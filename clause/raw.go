@@ -0,0 +1,125 @@
+package clause
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// ErrRawVars 表示原生 SQL 表达式的占位符与参数不匹配。
+var ErrRawVars = errors.New("raw expression vars do not match placeholders")
+
+// Expr 表示原生 SQL 片段，可用于 WHERE 条件、ORDER BY 和 SELECT 字段列表。
+//
+// SQL 中的 "?" 为位置参数占位符，按顺序绑定 Vars；
+// 当 Vars 中包含 map[string]any 或 sql.NamedArg 时，SQL 中的 "@name" 为命名参数占位符。
+// 参数若为 Expression（如 Column、SubQuery）则就地构建，其余值作为绑定变量写入 Builder。
+//
+// "??" 写为字面量 "?"（如 PostgreSQL jsonb 的 ?、?|、?& 运算符）；
+// 引号（'...'、"..."、`...`）内的内容原样写入，其中的 "?" 和 "@" 不作为占位符。
+//
+// 示例:
+//
+//	Raw("LENGTH(name) > ?", 10)                                  // LENGTH(name) > $1
+//	Raw("created_at > @since", sql.Named("since", t))            // created_at > $1
+//	Raw("age BETWEEN @min AND @max", map[string]any{"min": 18, "max": 30})
+//	Raw("tags ?? ? AND note <> 'what?'", "vip")                  // tags ? $1 AND note <> 'what?'
+type Expr struct {
+	SQL  string
+	Vars []any
+}
+
+// Raw 创建原生 SQL 表达式
+func Raw(sql string, vars ...any) Expr {
+	return Expr{SQL: sql, Vars: vars}
+}
+
+func (e Expr) Build(builder Builder) {
+	named := e.NamedVars()
+
+	idx := 0
+	for i := 0; i < len(e.SQL); i++ {
+		c := e.SQL[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := quotedEnd(e.SQL, i)
+			builder.WriteString(e.SQL[i:end])
+			i = end - 1
+		case c == '?' && i+1 < len(e.SQL) && e.SQL[i+1] == '?':
+			builder.WriteByte('?')
+			i++
+		case c == '?' && named == nil:
+			if idx >= len(e.Vars) {
+				builder.AddError(ErrRawVars)
+				return
+			}
+			builder.AddVar(builder, e.Vars[idx])
+			idx++
+		case c == '@' && named != nil:
+			end := i + 1
+			for end < len(e.SQL) && isNameChar(e.SQL[end]) {
+				end++
+			}
+			if end == i+1 {
+				builder.WriteByte('@')
+				continue
+			}
+
+			val, ok := named[e.SQL[i+1:end]]
+			if !ok {
+				builder.AddError(ErrRawVars)
+				return
+			}
+			builder.AddVar(builder, val)
+			i = end - 1
+		default:
+			builder.WriteByte(c)
+		}
+	}
+
+	if named == nil && idx < len(e.Vars) {
+		builder.AddError(ErrRawVars)
+	}
+}
+
+// quotedEnd 返回从 start 处的引号开始的引用内容的结束位置（不含），
+// 连续两个引号为转义，未闭合时返回 SQL 的长度
+func quotedEnd(sql string, start int) int {
+	quote := sql[start]
+	for i := start + 1; i < len(sql); i++ {
+		if sql[i] != quote {
+			continue
+		}
+		if i+1 < len(sql) && sql[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(sql)
+}
+
+// NamedVars 返回命名参数映射，Vars 中不含命名参数时返回 nil。
+func (e Expr) NamedVars() map[string]any {
+	var named map[string]any
+	for _, v := range e.Vars {
+		switch v := v.(type) {
+		case map[string]any:
+			if named == nil {
+				named = make(map[string]any, len(v))
+			}
+			for name, val := range v {
+				named[name] = val
+			}
+		case sql.NamedArg:
+			if named == nil {
+				named = make(map[string]any)
+			}
+			named[v.Name] = v.Value
+		}
+	}
+	return named
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,62 @@
+package clause
+
+// Returning 表示 INSERT/UPDATE/DELETE 的 RETURNING 子句，返回受影响行的指定列。
+// Columns 为空时不输出任何内容，"*" 表示返回所有列。
+//
+// Table 为 SQL Server OUTPUT 子句引用的伪表：INSERT/UPDATE 为 INSERTED，DELETE 为 DELETED。
+type Returning struct {
+	Columns []string
+	Table   string
+}
+
+// ReturningBuilder 表示支持按方言构建 RETURNING 子句的 Builder。
+//
+// SQL Server 使用 OUTPUT 子句，位于 INSERT 的 VALUES 之前、UPDATE 的 SET 之后和 DELETE 的 WHERE 之前，
+// 与 RETURNING 的位置不同：
+// 查询在 OUTPUT 的位置调用 Returning.Output，在语句末尾调用 Returning.Build，
+// 由 Builder 根据方言决定在哪个位置输出，不支持的方言应通过 AddError 记录错误。
+type ReturningBuilder interface {
+	BuildReturning(r Returning)
+	BuildOutput(r Returning)
+}
+
+// Build 在语句末尾构建 RETURNING 子句，默认使用 PostgreSQL/SQLite 语法。
+func (r Returning) Build(builder Builder) {
+	if len(r.Columns) == 0 {
+		return
+	}
+
+	if rb, ok := builder.(ReturningBuilder); ok {
+		rb.BuildReturning(r)
+		return
+	}
+
+	builder.WriteString(" RETURNING ")
+	r.BuildColumns(builder, "")
+}
+
+// Output 在 SQL Server OUTPUT 子句的位置构建，仅当 Builder 实现了 ReturningBuilder 时输出。
+func (r Returning) Output(builder Builder) {
+	if len(r.Columns) == 0 {
+		return
+	}
+
+	if rb, ok := builder.(ReturningBuilder); ok {
+		rb.BuildOutput(r)
+	}
+}
+
+// BuildColumns 写入以逗号分隔的列，prefix 不为空时作为列的限定前缀（如 "INSERTED."）
+func (r Returning) BuildColumns(builder Builder, prefix string) {
+	for idx, column := range r.Columns {
+		if idx > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(prefix)
+		if column == "*" {
+			builder.WriteByte('*')
+		} else {
+			builder.WriteQuoted(column)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package clause
+
+// AggregateFunc 表示聚合函数名称。
+type AggregateFunc string
+
+// 支持的聚合函数。
+const (
+	FuncCount AggregateFunc = "COUNT"
+	FuncSum   AggregateFunc = "SUM"
+	FuncAvg   AggregateFunc = "AVG"
+	FuncMin   AggregateFunc = "MIN"
+	FuncMax   AggregateFunc = "MAX"
+)
+
+// Aggregate 表示聚合函数表达式，如 COUNT(*)、SUM(amount)。
+// Col 为空或 "*" 时生成 FUNC(*)。
+type Aggregate struct {
+	Func     AggregateFunc
+	Col      string
+	Distinct bool
+}
+
+func (a Aggregate) Build(builder Builder) {
+	builder.WriteString(string(a.Func))
+	builder.WriteByte('(')
+	if a.Distinct {
+		builder.WriteString("DISTINCT ")
+	}
+	if a.Col == "" || a.Col == "*" {
+		builder.WriteByte('*')
+	} else {
+		builder.WriteQuoted(a.Col)
+	}
+	builder.WriteByte(')')
+}
+
+// Count 创建 COUNT 聚合表达式，col 为空或 "*" 时生成 COUNT(*)
+func Count(col string) Aggregate { return Aggregate{Func: FuncCount, Col: col} }
+
+// CountDistinct 创建 COUNT(DISTINCT col) 聚合表达式
+func CountDistinct(col string) Aggregate {
+	return Aggregate{Func: FuncCount, Col: col, Distinct: true}
+}
+
+// Sum 创建 SUM 聚合表达式
+func Sum(col string) Aggregate { return Aggregate{Func: FuncSum, Col: col} }
+
+// Avg 创建 AVG 聚合表达式
+func Avg(col string) Aggregate { return Aggregate{Func: FuncAvg, Col: col} }
+
+// Min 创建 MIN 聚合表达式
+func Min(col string) Aggregate { return Aggregate{Func: FuncMin, Col: col} }
+
+// Max 创建 MAX 聚合表达式
+func Max(col string) Aggregate { return Aggregate{Func: FuncMax, Col: col} }
+
+// Alias 为表达式设置别名，生成 expr AS name。
+type Alias struct {
+	Expr Expression
+	Name string
+}
+
+func (a Alias) Build(builder Builder) {
+	a.Expr.Build(builder)
+	if a.Name != "" {
+		builder.WriteString(" AS ")
+		builder.WriteQuoted(a.Name)
+	}
+}
+
+// As 为表达式设置别名
+//
+// 示例:
+//   - As(Sum("amount"), "total") // SUM(`amount`) AS `total`
+func As(expr Expression, name string) Alias {
+	return Alias{Expr: expr, Name: name}
+}
+
+// Aliases 返回选择列表中别名到表达式的映射。
+// HAVING 条件中可通过别名引用聚合表达式，构建时替换为原表达式，
+// 以兼容不允许在 HAVING 中使用别名的数据库（如 PostgreSQL）。
+func Aliases(fields []Expression) map[string]Expression {
+	aliases := make(map[string]Expression)
+	for _, f := range fields {
+		if alias, ok := f.(Alias); ok && alias.Name != "" {
+			aliases[alias.Name] = alias.Expr
+		}
+	}
+	return aliases
+}
+
+// GroupBy 表示 GROUP BY 子句及其 HAVING 条件。
+type GroupBy struct {
+	Columns []string
+	Having  Where
+}
+
+// Build 构建 GROUP BY 与 HAVING 子句，均为空时不输出任何内容。
+func (g GroupBy) Build(builder Builder) {
+	if len(g.Columns) > 0 {
+		builder.WriteString(" GROUP BY ")
+		for idx, column := range g.Columns {
+			if idx > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteQuoted(column)
+		}
+	}
+
+	g.Having.build(builder, " HAVING ")
+}
@@ -0,0 +1,571 @@
+package clause
+
+import (
+	"errors"
+	"reflect"
+	"time"
+)
+
+// ErrContradiction 表示条件恒为假（如 a = 1 AND a = 2），查询不会返回任何行。
+var ErrContradiction = errors.New("contradictory condition")
+
+// Normalize 返回结构规范化后的等价条件：
+//   - 展平嵌套的同类 AND/OR，去掉只有一个子表达式的分组
+//   - 将 OrWhere 产生的单元素 OR 按 SQL 优先级转换为显式的 OR 分组
+//   - 按 NegationExpressionBuilder 的语义将 NOT 下推（德摩根定律），
+//     如 NOT (a = 1 OR b > 2) 转换为 a <> 1 AND b <= 2；无法取反的表达式保留 NOT
+//
+// 规范化前后构建的 SQL 语义一致。
+func (w Where) Normalize() Where {
+	return toWhere(normalize(expandSeq(w.Exprs, LogicAnd), false))
+}
+
+// Simplify 在 Normalize 的基础上化简条件：
+//   - 移除 AND/OR 中重复的子表达式
+//   - 将 OR 中同一列的等值条件合并为 IN，如 a = 1 OR a = 2 转换为 a IN (1, 2)
+//   - 检测同一 AND 分组内的矛盾，如 a = 1 AND a = 2、a = 1 AND a <> 1、a IS NULL AND a IS NOT NULL，
+//     矛盾的 OR 分支会被移除
+//
+// 整个条件恒为假时返回 ErrContradiction，调用方可以直接返回空结果而无需查询数据库。
+func (w Where) Simplify() (Where, error) {
+	expr, t := simplify(w.Normalize().expr())
+	switch t {
+	case alwaysFalse:
+		return Where{}, ErrContradiction
+	case alwaysTrue:
+		return Where{}, nil
+	}
+	return toWhere(expr), nil
+}
+
+// expr 将 WHERE 条件转换为单个表达式
+func (w Where) expr() Expression {
+	return group(LogicAnd, w.Exprs)
+}
+
+// toWhere 将规范化的表达式转换为 WHERE 条件，顶层的 AND 展开为条件列表
+func toWhere(e Expression) Where {
+	switch e := e.(type) {
+	case nil:
+		return Where{}
+	case AndExpr:
+		return Where{Exprs: e.Exprs}
+	}
+	return Where{Exprs: []Expression{e}}
+}
+
+// expandSeq 按构建 SQL 时的连接方式解析表达式列表。
+// AND 列表中非首个的单元素 OrExpr 以 OR 连接（见 buildExprs），按 SQL 优先级拆分为 OR 分组。
+func expandSeq(exprs []Expression, op LogicalOperator) Expression {
+	if op == LogicOr {
+		terms := make([]Expression, 0, len(exprs))
+		for _, e := range exprs {
+			terms = append(terms, expand(e))
+		}
+		return group(LogicOr, terms)
+	}
+
+	var groups, terms []Expression
+	for idx, e := range exprs {
+		if e == nil {
+			continue
+		}
+		if logical, ok := e.(LogicalExpression); ok && idx > 0 && logical.Operator() == LogicOr && len(logical.SubExprs()) == 1 {
+			groups = append(groups, group(LogicAnd, terms))
+			terms = nil
+		}
+		terms = append(terms, expand(e))
+	}
+	groups = append(groups, group(LogicAnd, terms))
+	return group(LogicOr, groups)
+}
+
+// expand 将表达式转换为语义明确的形式：AndExpr/OrExpr 的子表达式之间只以自身的运算符连接，
+// NotExpr 只包含一个子表达式
+func expand(e Expression) Expression {
+	switch e := e.(type) {
+	case AndExpr:
+		return expandSeq(e.Exprs, LogicAnd)
+	case OrExpr:
+		return expandSeq(e.Exprs, LogicOr)
+	case NotExpr:
+		return expandNot(e)
+	}
+	return e
+}
+
+// expandNot 按 NotExpr.Build 的构建方式解析 NOT 表达式
+func expandNot(not NotExpr) Expression {
+	anyNegationBuilder := false
+	for _, c := range not.Exprs {
+		if _, ok := c.(NegationExpressionBuilder); ok {
+			anyNegationBuilder = true
+			break
+		}
+	}
+
+	// 含可取反的表达式时，逐个取反后以 AND 连接，不可取反的表达式保持原样
+	if anyNegationBuilder {
+		terms := make([]Expression, 0, len(not.Exprs))
+		for _, c := range not.Exprs {
+			if _, ok := c.(NegationExpressionBuilder); ok {
+				terms = append(terms, NotExpr{Exprs: []Expression{c}})
+			} else {
+				terms = append(terms, expand(c))
+			}
+		}
+		return group(LogicAnd, terms)
+	}
+
+	// 否则构建为 NOT (a AND b OR c)，OR 表达式之前以 OR 连接
+	var groups, terms []Expression
+	for idx, c := range not.Exprs {
+		if logical, ok := c.(LogicalExpression); ok && idx > 0 && logical.Operator() == LogicOr {
+			groups = append(groups, group(LogicAnd, terms))
+			terms = nil
+		}
+		terms = append(terms, expand(c))
+	}
+	groups = append(groups, group(LogicAnd, terms))
+
+	inner := group(LogicOr, groups)
+	if inner == nil {
+		return nil
+	}
+	return NotExpr{Exprs: []Expression{inner}}
+}
+
+// normalize 展平逻辑表达式并下推 NOT，negated 表示当前处于取反上下文
+func normalize(e Expression, negated bool) Expression {
+	switch e := e.(type) {
+	case AndExpr:
+		return normalizeGroup(e.Exprs, LogicAnd, negated)
+	case OrExpr:
+		return normalizeGroup(e.Exprs, LogicOr, negated)
+	case NotExpr:
+		if len(e.Exprs) == 1 {
+			return normalize(e.Exprs[0], !negated)
+		}
+		return normalize(expandNot(e), negated)
+	case nil:
+		return nil
+	}
+	if negated {
+		return negate(e)
+	}
+	return e
+}
+
+func normalizeGroup(exprs []Expression, op LogicalOperator, negated bool) Expression {
+	if negated {
+		if op == LogicAnd {
+			op = LogicOr
+		} else {
+			op = LogicAnd
+		}
+	}
+
+	terms := make([]Expression, 0, len(exprs))
+	for _, e := range exprs {
+		terms = append(terms, normalize(e, negated))
+	}
+	return group(op, terms)
+}
+
+// negate 返回比较表达式的取反形式，与其 NegationBuild 构建的 SQL 一致；
+// 没有对应取反类型的表达式（如 ILike、Exists、原生 SQL）以 NOT 包裹
+func negate(e Expression) Expression {
+	switch e := e.(type) {
+	case Eq:
+		return Neq(e)
+	case Neq:
+		return Eq(e)
+	case Gt:
+		return Lte(e)
+	case Gte:
+		return Lt(e)
+	case Lt:
+		return Gte(e)
+	case Lte:
+		return Gt(e)
+	case Like:
+		return NotLike(e)
+	case NotLike:
+		return Like(e)
+	case IN:
+		return NotIn(e)
+	case NotIn:
+		return IN(e)
+	case Between:
+		return NotBetween(e)
+	case NotBetween:
+		return Between(e)
+	case IsNull:
+		return IsNotNull(e)
+	case IsNotNull:
+		return IsNull(e)
+	}
+	return NotExpr{Exprs: []Expression{e}}
+}
+
+// group 以 op 组合表达式，展平同类子分组、忽略 nil，只有一个表达式时直接返回该表达式
+func group(op LogicalOperator, exprs []Expression) Expression {
+	terms := make([]Expression, 0, len(exprs))
+	for _, e := range exprs {
+		if e == nil {
+			continue
+		}
+		switch sub := e.(type) {
+		case AndExpr:
+			if op == LogicAnd {
+				terms = append(terms, sub.Exprs...)
+				continue
+			}
+		case OrExpr:
+			if op == LogicOr {
+				terms = append(terms, sub.Exprs...)
+				continue
+			}
+		}
+		terms = append(terms, e)
+	}
+
+	switch len(terms) {
+	case 0:
+		return nil
+	case 1:
+		return terms[0]
+	}
+	if op == LogicOr {
+		return OrExpr{Exprs: terms}
+	}
+	return AndExpr{Exprs: terms}
+}
+
+// truth 表示化简后表达式的真值
+type truth int
+
+const (
+	unknown truth = iota
+	alwaysTrue
+	alwaysFalse
+)
+
+// simplify 化简规范化后的表达式
+func simplify(e Expression) (Expression, truth) {
+	switch e := e.(type) {
+	case nil:
+		return nil, alwaysTrue
+	case AndExpr:
+		return simplifyAnd(e.Exprs)
+	case OrExpr:
+		return simplifyOr(e.Exprs)
+	case IN:
+		if len(e.Vals) == 0 {
+			// IN (NULL) 恒不成立
+			return nil, alwaysFalse
+		}
+	}
+	return e, unknown
+}
+
+func simplifyAnd(exprs []Expression) (Expression, truth) {
+	terms := make([]Expression, 0, len(exprs))
+	for _, e := range exprs {
+		s, t := simplify(e)
+		switch t {
+		case alwaysFalse:
+			return nil, alwaysFalse
+		case alwaysTrue:
+			continue
+		}
+		terms = appendUnique(LogicAnd, terms, s)
+	}
+
+	expr := group(LogicAnd, terms)
+	if expr == nil {
+		return nil, alwaysTrue
+	}
+	if and, ok := expr.(AndExpr); ok && contradicts(and.Exprs) {
+		return nil, alwaysFalse
+	}
+	return expr, unknown
+}
+
+func simplifyOr(exprs []Expression) (Expression, truth) {
+	terms := make([]Expression, 0, len(exprs))
+	for _, e := range exprs {
+		s, t := simplify(e)
+		switch t {
+		case alwaysTrue:
+			return nil, alwaysTrue
+		case alwaysFalse:
+			continue
+		}
+		terms = appendUnique(LogicOr, terms, s)
+	}
+
+	expr := group(LogicOr, mergeIn(terms))
+	if expr == nil {
+		return nil, alwaysFalse
+	}
+	return expr, unknown
+}
+
+// appendUnique 向 op 分组追加表达式，同类子分组展开后逐个追加，已存在相同的表达式时忽略
+func appendUnique(op LogicalOperator, exprs []Expression, e Expression) []Expression {
+	candidates := []Expression{e}
+	if logical, ok := e.(LogicalExpression); ok && logical.Operator() == op {
+		candidates = logical.SubExprs()
+	}
+
+	for _, c := range candidates {
+		if !containsExpr(exprs, c) {
+			exprs = append(exprs, c)
+		}
+	}
+	return exprs
+}
+
+func containsExpr(exprs []Expression, e Expression) bool {
+	for _, existing := range exprs {
+		if reflect.DeepEqual(existing, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeIn 将 OR 分组中同一列的等值条件和 IN 条件合并为一个 IN 条件
+func mergeIn(exprs []Expression) []Expression {
+	counts := make(map[string]int)
+	for _, e := range exprs {
+		if col, _, ok := inValuesOf(e); ok {
+			counts[col]++
+		}
+	}
+
+	merged := make(map[string]int) // 列名 -> 合并后的 IN 在结果中的下标
+	result := make([]Expression, 0, len(exprs))
+	for _, e := range exprs {
+		col, vals, ok := inValuesOf(e)
+		if !ok || counts[col] < 2 {
+			result = append(result, e)
+			continue
+		}
+
+		idx, exists := merged[col]
+		if !exists {
+			merged[col] = len(result)
+			result = append(result, IN{Col: col})
+			idx = len(result) - 1
+		}
+
+		in := result[idx].(IN)
+		for _, v := range vals {
+			if !containsValue(in.Vals, v) {
+				in.Vals = append(in.Vals, v)
+			}
+		}
+		result[idx] = in
+	}
+
+	// 去重后只剩一个值时还原为等值条件
+	for _, idx := range merged {
+		if in := result[idx].(IN); len(in.Vals) == 1 {
+			result[idx] = Eq{Col: in.Col, Val: in.Vals[0]}
+		}
+	}
+	return result
+}
+
+// inValuesOf 返回可合并为 IN 的条件的列名和值：值为单个非空值的 Eq，或不含子查询的 IN
+func inValuesOf(e Expression) (string, []any, bool) {
+	switch e := e.(type) {
+	case Eq:
+		if isScalar(e.Val) {
+			return e.Col, []any{e.Val}, true
+		}
+	case IN:
+		for _, v := range e.Vals {
+			if !isScalar(v) {
+				return "", nil, false
+			}
+		}
+		return e.Col, e.Vals, true
+	}
+	return "", nil, false
+}
+
+// isScalar 判断值是否为单个非空值（非切片、非表达式）
+func isScalar(v any) bool {
+	if eqNil(v) {
+		return false
+	}
+	if _, ok := v.(Expression); ok {
+		return false
+	}
+	kind := reflect.TypeOf(v).Kind()
+	return kind != reflect.Slice && kind != reflect.Array && kind != reflect.Map
+}
+
+func containsValue(vals []any, v any) bool {
+	for _, existing := range vals {
+		if equal, ok := valueEqual(existing, v); ok && equal {
+			return true
+		}
+	}
+	return false
+}
+
+// columnFacts 记录 AND 分组中同一列上的条件
+type columnFacts struct {
+	eq      []any // 等值条件的值
+	neq     []any // 不等条件的值
+	in      [][]any
+	isNull  bool
+	notNull bool
+}
+
+// contradicts 检测 AND 分组中同一列上相互矛盾的条件
+func contradicts(exprs []Expression) bool {
+	facts := make(map[string]*columnFacts)
+	factsOf := func(col string) *columnFacts {
+		f, ok := facts[col]
+		if !ok {
+			f = &columnFacts{}
+			facts[col] = f
+		}
+		return f
+	}
+
+	for _, e := range exprs {
+		switch e := e.(type) {
+		case Eq:
+			switch {
+			case eqNil(e.Val):
+				factsOf(e.Col).isNull = true
+			case isScalar(e.Val):
+				f := factsOf(e.Col)
+				f.eq = append(f.eq, e.Val)
+				f.notNull = true
+			}
+		case Neq:
+			switch {
+			case eqNil(e.Val):
+				factsOf(e.Col).notNull = true
+			case isScalar(e.Val):
+				f := factsOf(e.Col)
+				f.neq = append(f.neq, e.Val)
+				f.notNull = true
+			}
+		case IN:
+			if _, vals, ok := inValuesOf(e); ok {
+				f := factsOf(e.Col)
+				f.in = append(f.in, vals)
+				f.notNull = true
+			}
+		case IsNull:
+			factsOf(e.Col).isNull = true
+		case IsNotNull:
+			factsOf(e.Col).notNull = true
+		case Gt, Gte, Lt, Lte, Like, Between:
+			factsOf(e.(ComparisonExpression).Column()).notNull = true
+		}
+	}
+
+	for _, f := range facts {
+		if f.isNull && f.notNull {
+			return true
+		}
+		for i, v := range f.eq {
+			for _, other := range f.eq[i+1:] {
+				if equal, ok := valueEqual(v, other); ok && !equal {
+					return true
+				}
+			}
+			for _, other := range f.neq {
+				if equal, ok := valueEqual(v, other); ok && equal {
+					return true
+				}
+			}
+			for _, vals := range f.in {
+				if excludes(vals, v) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// excludes 判断 v 确定不在 vals 中（所有值均可比较且不相等）
+func excludes(vals []any, v any) bool {
+	for _, other := range vals {
+		equal, ok := valueEqual(v, other)
+		if !ok || equal {
+			return false
+		}
+	}
+	return true
+}
+
+// valueEqual 比较两个值是否相等，ok 为 false 表示无法确定（如类型不同且不可比较）。
+// 数值按数值比较，time.Time 按时间点比较。
+func valueEqual(a, b any) (equal bool, ok bool) {
+	if ta, isTime := a.(time.Time); isTime {
+		tb, isTime := b.(time.Time)
+		return isTime && ta.Equal(tb), isTime
+	}
+
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !ra.IsValid() || !rb.IsValid() {
+		return false, false
+	}
+
+	switch {
+	case isInt(ra) && isInt(rb):
+		return ra.Int() == rb.Int(), true
+	case isUint(ra) && isUint(rb):
+		return ra.Uint() == rb.Uint(), true
+	case isNumber(ra) && isNumber(rb):
+		return toFloat(ra) == toFloat(rb), true
+	case ra.Kind() == reflect.String && rb.Kind() == reflect.String:
+		return ra.String() == rb.String(), true
+	case ra.Kind() == reflect.Bool && rb.Kind() == reflect.Bool:
+		return ra.Bool() == rb.Bool(), true
+	case ra.Type() == rb.Type() && ra.Type().Comparable():
+		return a == b, true
+	}
+	return false, false
+}
+
+func isInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUint(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isNumber(v reflect.Value) bool {
+	return isInt(v) || isUint(v) || v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case isInt(v):
+		return float64(v.Int())
+	case isUint(v):
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
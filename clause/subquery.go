@@ -0,0 +1,36 @@
+package clause
+
+// SubQuery 表示作为比较值使用的子查询，构建时以括号包裹。
+// 子查询的参数直接写入外层 Builder，与外层查询的参数按出现顺序合并；
+// 子查询中可通过 Column 引用外层查询的表或别名（关联子查询）。
+//
+// 示例:
+//
+//	Eq{Col: "amount", Val: SubQuery{Expr: maxAmountQuery}} // `amount` = (SELECT ...)
+//	IN{Col: "id", Vals: []any{SubQuery{Expr: idsQuery}}}   // `id` IN (SELECT ...)
+type SubQuery struct {
+	Expr Expression
+}
+
+func (s SubQuery) Build(builder Builder) {
+	builder.WriteByte('(')
+	if s.Expr != nil {
+		s.Expr.Build(builder)
+	}
+	builder.WriteByte(')')
+}
+
+// Exists 表示 EXISTS 子查询条件，在 NOT 上下文中生成 NOT EXISTS。
+type Exists struct {
+	Expr Expression
+}
+
+func (e Exists) Build(builder Builder) {
+	builder.WriteString("EXISTS ")
+	SubQuery(e).Build(builder)
+}
+
+func (e Exists) NegationBuild(builder Builder) {
+	builder.WriteString("NOT EXISTS ")
+	SubQuery(e).Build(builder)
+}
@@ -0,0 +1,127 @@
+package clause
+
+// Node 表示遍历 WHERE 条件时访问到的表达式节点。
+type Node struct {
+	Expr   Expression
+	Parent Expression // 所在的逻辑表达式，顶层条件为 nil
+	Path   []int      // 从顶层条件到该节点在各层子表达式中的下标
+}
+
+// Depth 返回节点的深度，顶层条件为 1，每嵌套一层 AND/OR/NOT 加 1
+func (n Node) Depth() int {
+	return len(n.Path)
+}
+
+// Visitor 表示 WHERE 条件的访问者。
+type Visitor interface {
+	// Enter 在访问子表达式之前调用，返回 false 时跳过该节点的子表达式
+	Enter(n Node) bool
+	// Leave 在访问子表达式之后调用，Enter 返回 false 时同样会调用
+	Leave(n Node)
+}
+
+// Walk 按深度优先顺序只读遍历 WHERE 条件，逻辑表达式（AND/OR/NOT）的子表达式作为其子节点。
+// 与 Where.Map 不同，Walk 不会重建表达式。
+func Walk(where Where, v Visitor) {
+	walkExprs(v, nil, where.Exprs, nil)
+}
+
+func walkExprs(v Visitor, parent Expression, exprs []Expression, path []int) {
+	for idx, expr := range exprs {
+		if expr == nil {
+			continue
+		}
+
+		// 每个节点持有独立的路径，访问者可以安全地保留
+		nodePath := make([]int, len(path)+1)
+		copy(nodePath, path)
+		nodePath[len(path)] = idx
+
+		n := Node{Expr: expr, Parent: parent, Path: nodePath}
+		if v.Enter(n) {
+			if logical, ok := expr.(LogicalExpression); ok {
+				walkExprs(v, expr, logical.SubExprs(), nodePath)
+			}
+		}
+		v.Leave(n)
+	}
+}
+
+// inspector 将函数适配为 Visitor
+type inspector func(n Node) bool
+
+func (f inspector) Enter(n Node) bool { return f(n) }
+func (f inspector) Leave(Node)        {}
+
+// Inspect 按深度优先顺序遍历 WHERE 条件，对每个节点调用 f，f 返回 false 时跳过该节点的子表达式。
+//
+// 示例:
+//
+//	clause.Inspect(where, func(n clause.Node) bool {
+//	    if cmp, ok := n.Expr.(clause.ComparisonExpression); ok {
+//	        fmt.Println(n.Depth(), cmp.Column(), cmp.Operator())
+//	    }
+//	    return true
+//	})
+func Inspect(where Where, f func(n Node) bool) {
+	Walk(where, inspector(f))
+}
+
+// Find 返回 WHERE 条件中满足 pred 的全部表达式（含逻辑表达式），按深度优先顺序排列。
+//
+// 示例:
+//
+//	// 提取租户条件
+//	exprs := clause.Find(where, func(e clause.Expression) bool {
+//	    eq, ok := e.(clause.Eq)
+//	    return ok && eq.Col == "tenant_id"
+//	})
+func Find(where Where, pred func(e Expression) bool) []Expression {
+	var found []Expression
+	Inspect(where, func(n Node) bool {
+		if pred(n.Expr) {
+			found = append(found, n.Expr)
+		}
+		return true
+	})
+	return found
+}
+
+// Columns 返回 WHERE 条件涉及的列名（去重，按首次出现的顺序），
+// 包括比较条件的字段，以及作为比较值或原生 SQL 参数的 Column。
+// 子查询内部的列不包括在内。
+func Columns(where Where) []string {
+	var columns []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			columns = append(columns, name)
+		}
+	}
+	addValue := func(v any) {
+		if col, ok := v.(Column); ok {
+			add(col.Name)
+		}
+	}
+
+	Inspect(where, func(n Node) bool {
+		switch e := n.Expr.(type) {
+		case ComparisonExpression:
+			add(e.Column())
+			if vals, ok := e.Value().([]any); ok {
+				for _, v := range vals {
+					addValue(v)
+				}
+			} else {
+				addValue(e.Value())
+			}
+		case Expr:
+			for _, v := range e.Vars {
+				addValue(v)
+			}
+		}
+		return true
+	})
+	return columns
+}
@@ -1,5 +1,7 @@
 package clause
 
+import "strings"
+
 // 连接字符串常量，用于 Build 时连接多个表达式。
 const (
 	AndWithSpace = " AND "
@@ -20,6 +22,17 @@ const (
 	OpLTE  Operator = "<="
 	OpLIKE Operator = "LIKE"
 	OpIN   Operator = "IN"
+
+	OpBETWEEN    Operator = "BETWEEN"
+	OpNOTBETWEEN Operator = "NOT BETWEEN"
+	OpISNULL     Operator = "IS NULL"
+	OpISNOTNULL  Operator = "IS NOT NULL"
+	OpNOTIN      Operator = "NOT IN"
+	OpNOTLIKE    Operator = "NOT LIKE"
+	OpILIKE      Operator = "ILIKE"
+	OpSTARTSWITH Operator = "STARTS WITH"
+	OpENDSWITH   Operator = "ENDS WITH"
+	OpCONTAINS   Operator = "CONTAINS"
 )
 
 // LogicalOperator 表示逻辑运算符。
@@ -48,16 +61,25 @@ type Where struct {
 
 // Build build where clause
 func (w Where) Build(builder Builder) {
+	w.build(builder, " WHERE ")
+}
+
+// build 以指定关键字（WHERE 或 HAVING）构建条件子句
+func (w Where) build(builder Builder, keyword string) {
 	exprs := w.Exprs
 	if len(exprs) == 1 {
 		if logical, ok := exprs[0].(LogicalExpression); ok && logical.Operator() == LogicAnd {
 			exprs = logical.SubExprs()
 		}
 	}
-	if len(exprs) > 0 {
-		builder.WriteString(" WHERE ")
-		buildExprs(exprs, builder, AndWithSpace)
+	if len(exprs) == 0 {
+		return
+	}
+	if cw, ok := builder.(ConditionWriter); ok && cw.WriteCondition(builder, strings.TrimSpace(keyword), exprs) {
+		return
 	}
+	builder.WriteString(keyword)
+	buildExprs(exprs, builder, AndWithSpace)
 }
 
 func (w *Where) Merge(where Where) *Where {
@@ -0,0 +1,46 @@
+package query
+
+type genericColumnFilter[Q any] interface {
+	Omit(columns ...string) Q
+	Only(columns ...string) Q
+}
+
+var _ genericColumnFilter[*InsertQuery] = (*columnFilter[*InsertQuery])(nil)
+
+// columnFilter 是一个通用的字段过滤器，用于 INSERT 和 UPDATE 查询，
+// 在构建时排除或只保留指定的字段，对 map 和结构体形式的值均生效。
+type columnFilter[Q any] struct {
+	Parent Q
+	omit   map[string]bool
+	only   map[string]bool
+}
+
+// Omit 排除指定的字段，如自增主键、由数据库生成的时间戳等
+func (f *columnFilter[Q]) Omit(columns ...string) Q {
+	if f.omit == nil {
+		f.omit = make(map[string]bool, len(columns))
+	}
+	for _, column := range columns {
+		f.omit[column] = true
+	}
+	return f.Parent
+}
+
+// Only 只保留指定的字段，其余字段被忽略
+func (f *columnFilter[Q]) Only(columns ...string) Q {
+	if f.only == nil {
+		f.only = make(map[string]bool, len(columns))
+	}
+	for _, column := range columns {
+		f.only[column] = true
+	}
+	return f.Parent
+}
+
+// allowed 判断字段是否需要写入 SQL
+func (f *columnFilter[Q]) allowed(column string) bool {
+	if f.omit[column] {
+		return false
+	}
+	return f.only == nil || f.only[column]
+}
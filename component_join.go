@@ -0,0 +1,94 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/epkgs/query/clause"
+)
+
+type genericJoiner[Q any] interface {
+	JoinExpr() clause.Joins
+	Join(table string, on ...clause.Expression) Q
+	LeftJoin(table string, on ...clause.Expression) Q
+	RightJoin(table string, on ...clause.Expression) Q
+	CrossJoin(table string) Q
+}
+
+var _ genericJoiner[*Query] = (*joins[*Query])(nil)
+var _ clause.Expression = (*joins[*Query])(nil)
+
+// joins 是一个通用的 JOIN 构建器
+type joins[Q any] struct {
+	Parent Q
+	Value  clause.Joins
+}
+
+// JoinExpr 返回当前的 JOIN 表达式
+func (j *joins[Q]) JoinExpr() clause.Joins {
+	return j.Value
+}
+
+// Join 添加 INNER JOIN
+//
+// 参数:
+//   - table: 表名，可带别名，如 "orders"、"orders o"、"orders AS o"
+//   - on: ON 条件，多个条件之间以 AND 连接；列与列之间的比较使用 clause.Column 作为值
+//
+// 示例:
+//   - q.Join("orders o", clause.Eq{Col: "o.user_id", Val: clause.Column{Name: "u.id"}})
+func (j *joins[Q]) Join(table string, on ...clause.Expression) Q {
+	return j.join(clause.InnerJoin, table, on)
+}
+
+// LeftJoin 添加 LEFT JOIN，参数同 Join
+func (j *joins[Q]) LeftJoin(table string, on ...clause.Expression) Q {
+	return j.join(clause.LeftJoin, table, on)
+}
+
+// RightJoin 添加 RIGHT JOIN，参数同 Join
+func (j *joins[Q]) RightJoin(table string, on ...clause.Expression) Q {
+	return j.join(clause.RightJoin, table, on)
+}
+
+// CrossJoin 添加 CROSS JOIN
+func (j *joins[Q]) CrossJoin(table string) Q {
+	return j.join(clause.CrossJoin, table, nil)
+}
+
+func (j *joins[Q]) join(typ clause.JoinType, table string, on []clause.Expression) Q {
+	j.Value = append(j.Value, clause.Join{
+		Type:  typ,
+		Table: parseTable(table),
+		On:    clause.Where{Exprs: on},
+	})
+	return j.Parent
+}
+
+// Build 构建 JOIN 子句
+func (j *joins[Q]) Build(builder clause.Builder) {
+	j.Value.Build(builder)
+}
+
+// parseTable 解析带别名的表名
+// 支持以下形式：
+// 1. "users"
+// 2. "users u"
+// 3. "users AS u"
+func parseTable(table string) clause.Table {
+	fields := strings.Fields(table)
+	switch {
+	case len(fields) == 2:
+		return clause.Table{Name: fields[0], Alias: fields[1]}
+	case len(fields) == 3 && strings.EqualFold(fields[1], "as"):
+		return clause.Table{Name: fields[0], Alias: fields[2]}
+	}
+	return clause.Table{Name: table}
+}
+
+// On 创建列与列相等的 ON 条件
+//
+// 示例:
+//   - On("o.user_id", "u.id") // `o`.`user_id` = `u`.`id`
+func On(column, other string) clause.Expression {
+	return clause.Eq{Col: column, Val: clause.Column{Name: other}}
+}
@@ -50,6 +50,7 @@ func (o *orderbys[Q]) OrderByExpr() clause.OrderBys {
 //   - q.OrderBy("age asc")                  // age ASC
 //   - q.OrderBy(clause.OrderBy{Column: "name", Desc: true})  // 使用clause.Expression
 //   - q.OrderBy([]clause.OrderBy{ {Column: "name", Desc: true}, {Column: "age", Desc: false} }) // 多个排序子句
+//   - q.OrderBy(clause.Raw("FIELD(status, ?, ?)", "paid", "new"), "desc") // 按原生 SQL 表达式排序
 func (o *orderbys[Q]) OrderBy(field any, orders ...any) Q {
 
 	switch f := field.(type) {
@@ -97,6 +98,18 @@ func (o *orderbys[Q]) OrderBy(field any, orders ...any) Q {
 	case clause.OrderBys:
 		// 处理clause.OrderBys集合
 		o.Value = append(o.Value, f...)
+	case clause.Expr:
+		// 处理原生 SQL 表达式，可选第二个参数为排序方向
+		desc := false
+		if len(orders) > 0 {
+			ord, ok := orders[0].(string)
+			if !ok {
+				o.Parent.setError(ErrInvalidOrderBy)
+				break
+			}
+			desc = strings.ToLower(ord) == "desc"
+		}
+		o.Value = append(o.Value, &clause.OrderBy{Expr: f, Desc: desc})
 	default:
 		o.Parent.setError(ErrInvalidOrderBy)
 	}
@@ -1,12 +1,19 @@
 package query
 
-import "github.com/epkgs/query/clause"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/epkgs/query/clause"
+)
 
 type genericPaginator[Q any] interface {
 	PaginationExpr() clause.Pagination
 	Limit(limit int) Q
 	Offset(offset int) Q
 	Paginate(page int, pageSize int) Q
+	After(cursor string) Q
+	AfterValues(values ...any) Q
 }
 
 // Pagination 是用户可见的分页参数。
@@ -20,7 +27,7 @@ type Pagination struct {
 var _ genericPaginator[*Query] = (*pagination[*Query])(nil)
 var _ clause.Expression = (*pagination[*Query])(nil)
 
-type pagination[Q any] struct {
+type pagination[Q errorRecorder] struct {
 	Parent Q
 	Value  clause.Pagination
 }
@@ -53,6 +60,68 @@ func (p *pagination[Q]) Paginate(page int, pageSize int) Q {
 	return p.Parent
 }
 
+// After 设置键集（游标）分页的游标，查询位于该游标之后的数据。
+// 游标由 Cursor 方法根据上一页最后一行生成，解析失败时记录 clause.ErrInvalidCursor 错误。
+// 键集分页与 OFFSET 互斥，设置游标后通常只需配合 Limit 使用。
+//
+// 示例:
+//
+//	q.OrderBy("created_at", "desc").Asc("id").After(cursor).Limit(20)
+//	// WHERE (created_at < ? OR (created_at = ? AND id > ?)) ORDER BY created_at DESC, id ASC LIMIT 20
+func (p *pagination[Q]) After(cursor string) Q {
+	if cursor == "" {
+		p.Value.After = nil
+		return p.Parent
+	}
+
+	values, err := clause.DecodeCursor(cursor)
+	if err != nil {
+		p.Parent.setError(err)
+		return p.Parent
+	}
+	p.Value.After = values
+	return p.Parent
+}
+
+// AfterValues 直接以上一页最后一行的排序字段值设置键集分页，
+// 值的顺序与 ORDER BY 字段一一对应。
+func (p *pagination[Q]) AfterValues(values ...any) Q {
+	p.Value.After = values
+	return p.Parent
+}
+
 func (p *pagination[Q]) Build(builder clause.Builder) {
 	p.Value.Build(builder)
 }
+
+// cursorOf 按排序字段从行数据（map 或结构体）中取出键值并编码为游标。
+// 带表名限定的字段（如 "users.id"）在行中找不到时按不带限定的列名查找。
+func cursorOf(orders clause.OrderBys, row any) (string, error) {
+	if len(orders) == 0 {
+		return "", fmt.Errorf("%w: no order by", clause.ErrInvalidCursor)
+	}
+
+	lookup, ok := rowLookup(row)
+	if !ok {
+		return "", fmt.Errorf("%w: unsupported row type %T", clause.ErrInvalidCursor, row)
+	}
+
+	keys := make([]any, len(orders))
+	for idx, order := range orders {
+		if order == nil || order.Expr != nil {
+			return "", fmt.Errorf("%w: keyset requires column order by", clause.ErrInvalidCursor)
+		}
+		val, ok := lookup(order.Column)
+		if !ok {
+			if i := strings.LastIndexByte(order.Column, '.'); i >= 0 {
+				val, ok = lookup(order.Column[i+1:])
+			}
+		}
+		if !ok {
+			return "", fmt.Errorf("%w: column %q not found in row", clause.ErrInvalidCursor, order.Column)
+		}
+		keys[idx] = val
+	}
+
+	return clause.EncodeCursor(keys)
+}
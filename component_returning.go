@@ -0,0 +1,44 @@
+package query
+
+import "github.com/epkgs/query/clause"
+
+type genericReturner[Q any] interface {
+	ReturningExpr() clause.Returning
+	Returning(columns ...string) Q
+}
+
+var _ genericReturner[*InsertQuery] = (*returning[*InsertQuery])(nil)
+var _ clause.Expression = (*returning[*InsertQuery])(nil)
+
+// returning 是一个通用的 RETURNING 子句构建器，用于 INSERT、UPDATE 和 DELETE 查询
+type returning[Q any] struct {
+	Parent Q
+	Value  clause.Returning
+}
+
+// ReturningExpr 返回当前的 RETURNING 表达式
+func (r *returning[Q]) ReturningExpr() clause.Returning {
+	return r.Value
+}
+
+// Returning 设置需要返回的受影响行的列，"*" 表示所有列。
+// 支持 PostgreSQL、SQLite 3.35+ 的 RETURNING 和 SQL Server 的 OUTPUT，
+// 其他方言构建时会返回 dialect.ErrUnsupported 错误。
+//
+// 示例:
+//   - q.Insert("name", "John").Returning("id", "created_at")
+//   - q.Eq("id", 1).Delete().Returning("*")
+func (r *returning[Q]) Returning(columns ...string) Q {
+	r.Value.Columns = append(r.Value.Columns, columns...)
+	return r.Parent
+}
+
+// Build 在语句末尾构建 RETURNING 子句
+func (r *returning[Q]) Build(builder clause.Builder) {
+	r.Value.Build(builder)
+}
+
+// Output 在 SQL Server OUTPUT 子句的位置构建
+func (r *returning[Q]) Output(builder clause.Builder) {
+	r.Value.Output(builder)
+}
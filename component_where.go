@@ -2,6 +2,7 @@ package query
 
 import (
 	"reflect"
+	"strings"
 
 	"github.com/epkgs/query/clause"
 )
@@ -22,6 +23,18 @@ type genericWherer[Q any] interface {
 	Neq(column string, value any) Q
 	Not(query Wherer) Q
 	Or(querys ...Wherer) Q
+	Exists(query *SelectQuery) Q
+	NotExists(query *SelectQuery) Q
+	Between(column string, from, to any) Q
+	NotBetween(column string, from, to any) Q
+	IsNull(column string) Q
+	IsNotNull(column string) Q
+	NotIn(column string, values ...any) Q
+	NotLike(column string, value any) Q
+	ILike(column string, value any) Q
+	StartsWith(column string, value string) Q
+	EndsWith(column string, value string) Q
+	Contains(column string, value string) Q
 }
 
 var _ genericWherer[*Query] = (*where[*Query])(nil)
@@ -60,6 +73,7 @@ func (w *where[Q]) WhereExpr() clause.Where {
 // 示例:
 //   - q.Where("name", "John")
 //   - q.Where("age", ">", 18)
+//   - q.Where("age", "between", 18, 30)
 //   - q.Where(clause.Eq{Col: "name", Val: "John"})
 //   - q.Where(func(w Wherer) Wherer {  w.Where("name", "John"); return w.Parent })
 func (w *where[Q]) Where(field any, args ...any) Q {
@@ -142,7 +156,9 @@ func (w *where[Q]) Build(builder clause.Builder) {
 //
 // 功能说明:
 //   - 根据输入类型构建不同的条件表达式
-//   - 支持字符串字段名 + 操作符 + 值的格式
+//   - 支持字符串字段名 + 操作符 + 值的格式，操作符不区分大小写：
+//     =、!=、<>、>、>=、<、<=、LIKE、NOT LIKE、ILIKE、IN、NOT IN、
+//     BETWEEN、NOT BETWEEN、STARTS WITH、ENDS WITH、CONTAINS
 //   - 支持直接传入clause.Expression接口实现
 //   - 支持传入[]clause.Expression数组
 //   - 支持IN条件（当第二个参数是数组时）
@@ -183,7 +199,7 @@ func buildCondition(column any, args ...any) ([]clause.Expression, error) {
 				return []clause.Expression{clause.IN{Col: c, Vals: arr}}, nil
 			} else {
 				// 如果不是数组，构建=条件
-				return []clause.Expression{clause.Eq{Col: c, Val: args[0]}}, nil
+				return []clause.Expression{clause.Eq{Col: c, Val: subQueryValue(args[0])}}, nil
 			}
 		}
 
@@ -194,24 +210,46 @@ func buildCondition(column any, args ...any) ([]clause.Expression, error) {
 			return nil, ErrInvalidOperator
 		}
 
-		// 根据操作符构建不同的比较条件
-		switch op {
+		// 根据操作符构建不同的比较条件，操作符不区分大小写
+		operator := strings.ToUpper(strings.Join(strings.Fields(op), " "))
+		switch operator {
 		case "=":
-			return []clause.Expression{clause.Eq{Col: c, Val: args[1]}}, nil
-		case "!=":
-			return []clause.Expression{clause.Neq{Col: c, Val: args[1]}}, nil
+			return []clause.Expression{clause.Eq{Col: c, Val: subQueryValue(args[1])}}, nil
+		case "!=", "<>":
+			return []clause.Expression{clause.Neq{Col: c, Val: subQueryValue(args[1])}}, nil
 		case ">":
-			return []clause.Expression{clause.Gt{Col: c, Val: args[1]}}, nil
+			return []clause.Expression{clause.Gt{Col: c, Val: subQueryValue(args[1])}}, nil
 		case ">=":
-			return []clause.Expression{clause.Gte{Col: c, Val: args[1]}}, nil
+			return []clause.Expression{clause.Gte{Col: c, Val: subQueryValue(args[1])}}, nil
 		case "<":
-			return []clause.Expression{clause.Lt{Col: c, Val: args[1]}}, nil
+			return []clause.Expression{clause.Lt{Col: c, Val: subQueryValue(args[1])}}, nil
 		case "<=":
-			return []clause.Expression{clause.Lte{Col: c, Val: args[1]}}, nil
-		case "LIKE", "like":
-			return []clause.Expression{clause.Like{Col: c, Val: args[1]}}, nil
-		case "IN", "in":
-			return []clause.Expression{clause.IN{Col: c, Vals: toAnySlice(args[1])}}, nil
+			return []clause.Expression{clause.Lte{Col: c, Val: subQueryValue(args[1])}}, nil
+		case "LIKE":
+			return []clause.Expression{clause.Like{Col: c, Val: subQueryValue(args[1])}}, nil
+		case "NOT LIKE":
+			return []clause.Expression{clause.NotLike{Col: c, Val: args[1]}}, nil
+		case "ILIKE":
+			return []clause.Expression{clause.ILike{Col: c, Val: args[1]}}, nil
+		case "IN":
+			return []clause.Expression{clause.IN{Col: c, Vals: toAnySlice(subQueryValue(args[1]))}}, nil
+		case "NOT IN":
+			return []clause.Expression{clause.NotIn{Col: c, Vals: toAnySlice(subQueryValue(args[1]))}}, nil
+		case "BETWEEN", "NOT BETWEEN":
+			from, to, err := betweenValues(args[1:])
+			if err != nil {
+				return nil, err
+			}
+			if operator == "NOT BETWEEN" {
+				return []clause.Expression{clause.NotBetween{Col: c, From: from, To: to}}, nil
+			}
+			return []clause.Expression{clause.Between{Col: c, From: from, To: to}}, nil
+		case "STARTS WITH", "STARTSWITH":
+			return []clause.Expression{clause.StartsWith{Col: c, Val: args[1]}}, nil
+		case "ENDS WITH", "ENDSWITH":
+			return []clause.Expression{clause.EndsWith{Col: c, Val: args[1]}}, nil
+		case "CONTAINS":
+			return []clause.Expression{clause.Contains{Col: c, Val: args[1]}}, nil
 		default:
 			// 不支持的操作符
 			return nil, ErrInvalidOperator
@@ -223,6 +261,20 @@ func buildCondition(column any, args ...any) ([]clause.Expression, error) {
 	return nil, ErrInvalidCondition
 }
 
+// betweenValues 解析 BETWEEN 的上下界，
+// 支持 Where("age", "between", 18, 30) 和 Where("age", "between", []int{18, 30}) 两种形式
+func betweenValues(args []any) (from, to any, err error) {
+	switch len(args) {
+	case 1:
+		if vals := toAnySlice(args[0]); len(vals) == 2 {
+			return vals[0], vals[1], nil
+		}
+	case 2:
+		return args[0], args[1], nil
+	}
+	return nil, nil, ErrInvalidCondition
+}
+
 func toAnySlice(value any) []any {
 
 	if value == nil {
@@ -360,61 +412,151 @@ func (w *where[Q]) Not(q Wherer) Q {
 
 // Eq 添加等于条件
 func (w *where[Q]) Eq(column string, value any) Q {
-	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Eq{Col: column, Val: value}}})
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Eq{Col: column, Val: subQueryValue(value)}}})
 	return w.Parent
 }
 
 // Neq 添加不等于条件
 func (w *where[Q]) Neq(column string, value any) Q {
-	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Neq{Col: column, Val: value}}})
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Neq{Col: column, Val: subQueryValue(value)}}})
 	return w.Parent
 }
 
 // Gt 添加大于条件
 func (w *where[Q]) Gt(column string, value any) Q {
-	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Gt{Col: column, Val: value}}})
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Gt{Col: column, Val: subQueryValue(value)}}})
 	return w.Parent
 }
 
 // Gte 添加大于等于条件
 func (w *where[Q]) Gte(column string, value any) Q {
-	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Gte{Col: column, Val: value}}})
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Gte{Col: column, Val: subQueryValue(value)}}})
 	return w.Parent
 }
 
 // Lt 添加小于条件
 func (w *where[Q]) Lt(column string, value any) Q {
-	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Lt{Col: column, Val: value}}})
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Lt{Col: column, Val: subQueryValue(value)}}})
 	return w.Parent
 }
 
 // Lte 添加小于等于条件
 func (w *where[Q]) Lte(column string, value any) Q {
-	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Lte{Col: column, Val: value}}})
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Lte{Col: column, Val: subQueryValue(value)}}})
 	return w.Parent
 }
 
 // Like 添加 LIKE 条件
 func (w *where[Q]) Like(column string, value any) Q {
-	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Like{Col: column, Val: value}}})
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Like{Col: column, Val: subQueryValue(value)}}})
 	return w.Parent
 }
 
 // In 添加 IN 条件
-// 支持传入展开值或切片/数组，内部会自动转换为 IN 条件
+// 支持传入展开值、切片/数组或子查询，内部会自动转换为 IN 条件
 //
 // 示例：
 //   - In("city", "London", "Paris", "Berlin")
 //   - In("id", []int{1, 2, 3})
+//   - In("id", Table("orders").Eq("status", "paid").Select("user_id"))
 func (w *where[Q]) In(column string, values ...any) Q {
 	if len(values) == 1 {
-		w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.IN{Col: column, Vals: toAnySlice(values[0])}}})
+		w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.IN{Col: column, Vals: toAnySlice(subQueryValue(values[0]))}}})
 	} else if len(values) > 1 {
 		w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.IN{Col: column, Vals: values}}})
 	}
 	return w.Parent
 }
 
+// NotIn 添加 NOT IN 条件，参数同 In
+func (w *where[Q]) NotIn(column string, values ...any) Q {
+	if len(values) == 1 {
+		w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.NotIn{Col: column, Vals: toAnySlice(subQueryValue(values[0]))}}})
+	} else if len(values) > 1 {
+		w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.NotIn{Col: column, Vals: values}}})
+	}
+	return w.Parent
+}
+
+// Between 添加 BETWEEN 条件，包含边界值
+func (w *where[Q]) Between(column string, from, to any) Q {
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Between{Col: column, From: from, To: to}}})
+	return w.Parent
+}
+
+// NotBetween 添加 NOT BETWEEN 条件
+func (w *where[Q]) NotBetween(column string, from, to any) Q {
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.NotBetween{Col: column, From: from, To: to}}})
+	return w.Parent
+}
+
+// IsNull 添加 IS NULL 条件
+func (w *where[Q]) IsNull(column string) Q {
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.IsNull{Col: column}}})
+	return w.Parent
+}
+
+// IsNotNull 添加 IS NOT NULL 条件
+func (w *where[Q]) IsNotNull(column string) Q {
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.IsNotNull{Col: column}}})
+	return w.Parent
+}
+
+// NotLike 添加 NOT LIKE 条件
+func (w *where[Q]) NotLike(column string, value any) Q {
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.NotLike{Col: column, Val: value}}})
+	return w.Parent
+}
+
+// ILike 添加不区分大小写的 LIKE 条件
+func (w *where[Q]) ILike(column string, value any) Q {
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.ILike{Col: column, Val: value}}})
+	return w.Parent
+}
+
+// StartsWith 添加前缀匹配条件，value 中的 % 和 _ 按字面匹配
+func (w *where[Q]) StartsWith(column string, value string) Q {
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.StartsWith{Col: column, Val: value}}})
+	return w.Parent
+}
+
+// EndsWith 添加后缀匹配条件，value 中的 % 和 _ 按字面匹配
+func (w *where[Q]) EndsWith(column string, value string) Q {
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.EndsWith{Col: column, Val: value}}})
+	return w.Parent
+}
+
+// Contains 添加包含子串的匹配条件，value 中的 % 和 _ 按字面匹配
+func (w *where[Q]) Contains(column string, value string) Q {
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Contains{Col: column, Val: value}}})
+	return w.Parent
+}
+
+// Exists 添加 EXISTS 子查询条件
+// 子查询中可通过 clause.Column 引用外层查询的表或别名
+//
+// 示例：
+//   - Table("users u").Exists(Table("orders o").Where(On("o.user_id", "u.id")).Select("o.id"))
+func (w *where[Q]) Exists(query *SelectQuery) Q {
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Exists{Expr: query}}})
+	return w.Parent
+}
+
+// NotExists 添加 NOT EXISTS 子查询条件，参数同 Exists
+func (w *where[Q]) NotExists(query *SelectQuery) Q {
+	w.Value.Merge(clause.Where{Exprs: []clause.Expression{clause.Not(clause.Exists{Expr: query})}})
+	return w.Parent
+}
+
+// subQueryValue 将作为值传入的 *SelectQuery 包装为 clause.SubQuery，
+// 构建时以括号包裹，其余值原样返回
+func subQueryValue(value any) any {
+	if q, ok := value.(*SelectQuery); ok {
+		return clause.SubQuery{Expr: q}
+	}
+	return value
+}
+
 // ========== 全局逻辑组合函数 ==========
 
 // Or 组合多个 WHERE 条件为 OR
@@ -478,3 +620,63 @@ func Like(column string, value any) *Query {
 func In(column string, values ...any) *Query {
 	return newQuery("").In(column, values...)
 }
+
+// Exists 创建 EXISTS 子查询条件
+func Exists(query *SelectQuery) *Query {
+	return newQuery("").Exists(query)
+}
+
+// NotExists 创建 NOT EXISTS 子查询条件
+func NotExists(query *SelectQuery) *Query {
+	return newQuery("").NotExists(query)
+}
+
+// NotIn 创建 NOT IN 条件
+func NotIn(column string, values ...any) *Query {
+	return newQuery("").NotIn(column, values...)
+}
+
+// Between 创建 BETWEEN 条件
+func Between(column string, from, to any) *Query {
+	return newQuery("").Between(column, from, to)
+}
+
+// NotBetween 创建 NOT BETWEEN 条件
+func NotBetween(column string, from, to any) *Query {
+	return newQuery("").NotBetween(column, from, to)
+}
+
+// IsNull 创建 IS NULL 条件
+func IsNull(column string) *Query {
+	return newQuery("").IsNull(column)
+}
+
+// IsNotNull 创建 IS NOT NULL 条件
+func IsNotNull(column string) *Query {
+	return newQuery("").IsNotNull(column)
+}
+
+// NotLike 创建 NOT LIKE 条件
+func NotLike(column string, value any) *Query {
+	return newQuery("").NotLike(column, value)
+}
+
+// ILike 创建不区分大小写的 LIKE 条件
+func ILike(column string, value any) *Query {
+	return newQuery("").ILike(column, value)
+}
+
+// StartsWith 创建前缀匹配条件
+func StartsWith(column string, value string) *Query {
+	return newQuery("").StartsWith(column, value)
+}
+
+// EndsWith 创建后缀匹配条件
+func EndsWith(column string, value string) *Query {
+	return newQuery("").EndsWith(column, value)
+}
+
+// Contains 创建包含子串的匹配条件
+func Contains(column string, value string) *Query {
+	return newQuery("").Contains(column, value)
+}
@@ -0,0 +1,234 @@
+package dialect
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/epkgs/query/clause"
+)
+
+var _ clause.Builder = (*Builder)(nil)
+var _ clause.PaginationBuilder = (*Builder)(nil)
+var _ clause.OnConflictBuilder = (*Builder)(nil)
+var _ clause.ReturningBuilder = (*Builder)(nil)
+var _ clause.KeysetBuilder = (*Builder)(nil)
+var _ clause.RowLimitBuilder = (*Builder)(nil)
+var _ clause.TargetTableBuilder = (*Builder)(nil)
+
+// Errors 是构建过程中收集到的多个错误。
+type Errors []error
+
+func (errs Errors) Error() string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is 判断是否有任一错误与 target 匹配，供 errors.Is 使用（Go 1.20 之前不支持 Unwrap() []error）。
+func (errs Errors) Is(target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As 查找第一个可赋值给 target 的错误，供 errors.As 使用。
+func (errs Errors) As(target any) bool {
+	for _, err := range errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap 返回所有错误，供 Go 1.20 及以上版本的 errors.Is/errors.As 逐个匹配。
+func (errs Errors) Unwrap() []error {
+	return errs
+}
+
+// Builder 是 clause.Builder 的标准 SQL 实现。
+// 它按照 Dialect 的规则引用标识符、生成占位符，并收集构建过程中的错误。
+type Builder struct {
+	dialect Dialect
+	sql     strings.Builder
+	vars    []any
+	errs    Errors
+}
+
+// NewBuilder 创建一个使用指定方言的 Builder。
+func NewBuilder(d Dialect) *Builder {
+	return &Builder{dialect: d}
+}
+
+// Build 使用指定方言构建表达式，返回 SQL 语句、参数列表和构建过程中的错误。
+//
+// 示例：
+//
+//	sql, args, err := dialect.Build(dialect.MySQL, query.Table("users").Eq("id", 1).Delete())
+//	// sql:  DELETE FROM `users` WHERE `id` = ?
+//	// args: [1]
+func Build(d Dialect, expr clause.Expression) (string, []any, error) {
+	b := NewBuilder(d)
+	b.Build(expr)
+	return b.SQL(), b.Vars(), b.Err()
+}
+
+// Build 将表达式写入 Builder。
+func (b *Builder) Build(expr clause.Expression) {
+	if expr == nil {
+		return
+	}
+	expr.Build(b)
+}
+
+// Dialect 返回 Builder 使用的方言。
+func (b *Builder) Dialect() Dialect {
+	return b.dialect
+}
+
+// WriteByte 写入单个字节，实现 clause.Writer 接口。
+func (b *Builder) WriteByte(c byte) error {
+	return b.sql.WriteByte(c)
+}
+
+// WriteString 写入字符串，实现 clause.Writer 接口。
+func (b *Builder) WriteString(s string) (int, error) {
+	return b.sql.WriteString(s)
+}
+
+// WriteQuoted 写入带引号的标识符。
+// 字符串中的 "." 视为限定符分隔（如 "users.id" -> "users"."id"），"*" 不加引号。
+func (b *Builder) WriteQuoted(field interface{}) {
+	switch v := field.(type) {
+	case string:
+		b.quoteTo(b, v)
+	case clause.Expression:
+		v.Build(b)
+	default:
+		b.quoteTo(b, fmt.Sprint(v))
+	}
+}
+
+func (b *Builder) quoteTo(w clause.Writer, field string) {
+	for i, part := range strings.Split(field, ".") {
+		if i > 0 {
+			w.WriteByte('.')
+		}
+		if part == "*" {
+			w.WriteByte('*')
+			continue
+		}
+		b.dialect.QuoteIdent(w, part)
+	}
+}
+
+// AddVar 添加参数并写入对应的占位符，多个参数之间以 "," 分隔。
+//   - clause.Expression 类型的参数会直接构建到语句中；
+//   - []interface{} 类型的参数会展开为 "(?,?,...)"。
+func (b *Builder) AddVar(writer clause.Writer, vars ...interface{}) {
+	for idx, v := range vars {
+		if idx > 0 {
+			writer.WriteByte(',')
+		}
+
+		switch v := v.(type) {
+		case clause.Expression:
+			v.Build(b)
+		case []interface{}:
+			writer.WriteByte('(')
+			if len(v) > 0 {
+				b.AddVar(writer, v...)
+			} else {
+				writer.WriteString("NULL")
+			}
+			writer.WriteByte(')')
+		default:
+			b.vars = append(b.vars, v)
+			b.dialect.BindVar(writer, len(b.vars))
+		}
+	}
+}
+
+// AddError 记录构建过程中的错误，nil 会被忽略。
+func (b *Builder) AddError(err error) error {
+	if err != nil {
+		b.errs = append(b.errs, err)
+	}
+	return err
+}
+
+// BuildPagination 按方言生成分页子句，实现 clause.PaginationBuilder 接口。
+func (b *Builder) BuildPagination(p clause.Pagination, ordered bool) {
+	b.dialect.Paginate(b, p, ordered)
+}
+
+// BuildRowLimit 按方言生成 UPDATE/DELETE 的行数限制，实现 clause.RowLimitBuilder 接口。
+func (b *Builder) BuildRowLimit(limit int) {
+	b.dialect.RowLimit(b, limit)
+}
+
+// BuildTargetTable 按方言生成 UPDATE/DELETE 的目标表，实现 clause.TargetTableBuilder 接口。
+func (b *Builder) BuildTargetTable(table clause.Table) {
+	b.dialect.TargetTable(b, table)
+}
+
+// BuildOnConflict 按方言生成 INSERT 冲突处理子句，实现 clause.OnConflictBuilder 接口。
+func (b *Builder) BuildOnConflict(c clause.OnConflict) {
+	b.dialect.OnConflict(b, c)
+}
+
+// BuildReturning 按方言在语句末尾生成 RETURNING 子句，实现 clause.ReturningBuilder 接口。
+func (b *Builder) BuildReturning(r clause.Returning) {
+	b.dialect.Returning(b, r)
+}
+
+// BuildOutput 按方言生成 OUTPUT 子句，实现 clause.ReturningBuilder 接口。
+func (b *Builder) BuildOutput(r clause.Returning) {
+	b.dialect.Output(b, r)
+}
+
+// BuildKeyset 按方言生成键集分页条件，实现 clause.KeysetBuilder 接口。
+func (b *Builder) BuildKeyset(k clause.Keyset) {
+	b.dialect.Keyset(b, k)
+}
+
+// SQL 返回已构建的 SQL 语句。
+func (b *Builder) SQL() string {
+	return b.sql.String()
+}
+
+// String 返回已构建的 SQL 语句。
+func (b *Builder) String() string {
+	return b.sql.String()
+}
+
+// Vars 返回已绑定的参数列表。
+func (b *Builder) Vars() []any {
+	return b.vars
+}
+
+// Err 返回构建过程中的错误。
+// 没有错误时返回 nil；只有一个错误时直接返回该错误；否则返回 Errors。
+func (b *Builder) Err() error {
+	switch len(b.errs) {
+	case 0:
+		return nil
+	case 1:
+		return b.errs[0]
+	default:
+		return b.errs
+	}
+}
+
+// Reset 清空已构建的语句、参数和错误，以便复用 Builder。
+func (b *Builder) Reset() {
+	b.sql.Reset()
+	b.vars = nil
+	b.errs = nil
+}
@@ -0,0 +1,340 @@
+// Package dialect 提供了 clause.Builder 的标准 SQL 实现。
+//
+// 不同数据库在标识符引用、占位符和分页语法上存在差异，
+// 该包通过 Dialect 接口描述这些差异，并内置了 MySQL、PostgreSQL、
+// SQLite 和 SQL Server 四种方言。Builder 会根据方言生成
+// 可直接交给 database/sql 执行的 SQL 语句和参数列表。
+//
+// 使用方式：
+//
+//	q := query.Table("users").Eq("name", "John").Limit(10).Select("id", "name")
+//	sql, args, err := dialect.Build(dialect.PostgreSQL, q)
+//	// sql:  SELECT "id", "name" FROM "users" WHERE "name" = $1 LIMIT $2
+//	// args: [John 10]
+package dialect
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/epkgs/query/clause"
+)
+
+// Dialect 描述了一种 SQL 方言的语法差异。
+type Dialect interface {
+	// Name 返回方言名称，如 "mysql"、"postgres"。
+	Name() string
+	// QuoteIdent 将单个标识符（不含 "."）加上引号后写入 w。
+	QuoteIdent(w clause.Writer, ident string)
+	// BindVar 将第 n 个（从 1 开始）参数的占位符写入 w。
+	BindVar(w clause.Writer, n int)
+	// Paginate 将分页子句写入 Builder，ordered 表示语句是否已包含 ORDER BY 子句。
+	Paginate(b *Builder, p clause.Pagination, ordered bool)
+	// RowLimit 将 UPDATE/DELETE 的行数限制写入 Builder，不支持时记录 ErrUnsupported 错误。
+	RowLimit(b *Builder, limit int)
+	// TargetTable 将 UPDATE/DELETE 的目标表写入 Builder，不支持目标表别名时记录 ErrUnsupported 错误。
+	TargetTable(b *Builder, t clause.Table)
+	// OnConflict 将 INSERT 冲突处理子句写入 Builder，不支持时记录 ErrUnsupported 错误。
+	OnConflict(b *Builder, c clause.OnConflict)
+	// Returning 在语句末尾写入 RETURNING 子句，不支持时记录 ErrUnsupported 错误。
+	Returning(b *Builder, r clause.Returning)
+	// Output 在 OUTPUT 子句的位置写入返回列，仅 SQL Server 输出。
+	Output(b *Builder, r clause.Returning)
+	// Keyset 将键集分页条件写入 Builder，不支持行值比较时使用展开的 OR 条件。
+	Keyset(b *Builder, k clause.Keyset)
+}
+
+// ErrUnsupported 表示方言不支持当前语法。
+var ErrUnsupported = errors.New("unsupported by dialect")
+
+// 内置方言。
+var (
+	MySQL      Dialect = mysql{}
+	PostgreSQL Dialect = postgres{}
+	SQLite     Dialect = sqlite{}
+	SQLServer  Dialect = sqlserver{}
+)
+
+// quoteIdent 使用 open/close 包裹标识符，标识符内部的 close 字符会被转义为两个。
+func quoteIdent(w clause.Writer, ident string, open, close byte) {
+	w.WriteByte(open)
+	w.WriteString(strings.ReplaceAll(ident, string(close), string(close)+string(close)))
+	w.WriteByte(close)
+}
+
+// limitOffset 生成通用的 LIMIT/OFFSET 分页子句。
+// noLimit 为仅设置 OFFSET 时需要补齐的 LIMIT 值，为空表示可以省略 LIMIT。
+func limitOffset(b *Builder, p clause.Pagination, noLimit string) {
+	hasLimit := p.Limit != nil && *p.Limit > 0
+
+	if hasLimit {
+		b.WriteString(" LIMIT ")
+		b.AddVar(b, *p.Limit)
+	} else if p.Offset > 0 && noLimit != "" {
+		b.WriteString(" LIMIT ")
+		b.WriteString(noLimit)
+	}
+
+	if p.Offset > 0 {
+		b.WriteString(" OFFSET ")
+		b.AddVar(b, p.Offset)
+	}
+}
+
+// onConflict 生成 PostgreSQL/SQLite 的 ON CONFLICT 子句。
+// DO UPDATE 必须指定冲突列，未指定时记录 ErrUnsupported 错误。
+func onConflict(b *Builder, c clause.OnConflict) {
+	if len(c.Columns) == 0 && !c.DoNothing {
+		b.AddError(fmt.Errorf("%s: %w: ON CONFLICT DO UPDATE without conflict columns", b.dialect.Name(), ErrUnsupported))
+	}
+
+	b.WriteString(" ON CONFLICT")
+	if len(c.Columns) > 0 {
+		b.WriteString(" (")
+		for idx, column := range c.Columns {
+			if idx > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteQuoted(column)
+		}
+		b.WriteByte(')')
+	}
+
+	if c.DoNothing {
+		b.WriteString(" DO NOTHING")
+		return
+	}
+
+	b.WriteString(" DO UPDATE SET ")
+	for idx, assignment := range c.DoUpdates {
+		if idx > 0 {
+			b.WriteString(", ")
+		}
+		assignment.Build(b)
+	}
+}
+
+// returning 生成 PostgreSQL/SQLite 的 RETURNING 子句。
+func returning(b *Builder, r clause.Returning) {
+	b.WriteString(" RETURNING ")
+	r.BuildColumns(b, "")
+}
+
+// keyset 生成键集分页条件，排序方向一致时使用行值比较，否则展开为 OR 条件。
+func keyset(b *Builder, k clause.Keyset) {
+	if k.IsCompound() {
+		k.BuildCompound(b)
+	} else {
+		k.BuildExpanded(b)
+	}
+}
+
+type mysql struct{}
+
+func (mysql) Name() string { return "mysql" }
+
+func (mysql) QuoteIdent(w clause.Writer, ident string) { quoteIdent(w, ident, '`', '`') }
+
+func (mysql) BindVar(w clause.Writer, n int) { w.WriteByte('?') }
+
+// Paginate MySQL 不支持单独的 OFFSET，需要以最大值补齐 LIMIT。
+func (mysql) Paginate(b *Builder, p clause.Pagination, ordered bool) {
+	limitOffset(b, p, "18446744073709551615")
+}
+
+func (mysql) RowLimit(b *Builder, limit int) {
+	b.WriteString(" LIMIT ")
+	b.AddVar(b, limit)
+}
+
+func (mysql) TargetTable(b *Builder, t clause.Table) {
+	t.Build(b)
+}
+
+// OnConflict MySQL 使用 ON DUPLICATE KEY UPDATE 语法，冲突目标由表的唯一索引决定，
+// clause.Excluded 生成 VALUES(column)；DoNothing 通过将第一个冲突列赋值为自身实现。
+func (mysql) OnConflict(b *Builder, c clause.OnConflict) {
+	assignments := c.DoUpdates
+	if c.DoNothing {
+		if len(c.Columns) == 0 {
+			b.AddError(fmt.Errorf("mysql: %w: DO NOTHING without conflict columns", ErrUnsupported))
+			return
+		}
+		assignments = []clause.Assignment{{Column: c.Columns[0], Value: clause.Column{Name: c.Columns[0]}}}
+	}
+
+	b.WriteString(" ON DUPLICATE KEY UPDATE ")
+	for idx, assignment := range assignments {
+		if idx > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteQuoted(assignment.Column)
+		b.WriteString(" = ")
+		if excluded, ok := assignment.Value.(clause.Excluded); ok {
+			b.WriteString("VALUES(")
+			b.WriteQuoted(excluded.Column)
+			b.WriteByte(')')
+		} else {
+			b.AddVar(b, assignment.Value)
+		}
+	}
+}
+
+func (mysql) Returning(b *Builder, r clause.Returning) {
+	b.AddError(fmt.Errorf("mysql: %w: RETURNING", ErrUnsupported))
+}
+
+func (mysql) Output(b *Builder, r clause.Returning) {}
+
+func (mysql) Keyset(b *Builder, k clause.Keyset) {
+	keyset(b, k)
+}
+
+type postgres struct{}
+
+func (postgres) Name() string { return "postgres" }
+
+func (postgres) QuoteIdent(w clause.Writer, ident string) { quoteIdent(w, ident, '"', '"') }
+
+func (postgres) BindVar(w clause.Writer, n int) {
+	w.WriteByte('$')
+	w.WriteString(strconv.Itoa(n))
+}
+
+func (postgres) Paginate(b *Builder, p clause.Pagination, ordered bool) {
+	limitOffset(b, p, "")
+}
+
+func (postgres) RowLimit(b *Builder, limit int) {
+	b.AddError(fmt.Errorf("postgres: %w: UPDATE/DELETE LIMIT", ErrUnsupported))
+}
+
+func (postgres) TargetTable(b *Builder, t clause.Table) {
+	t.Build(b)
+}
+
+func (postgres) OnConflict(b *Builder, c clause.OnConflict) {
+	onConflict(b, c)
+}
+
+func (postgres) Returning(b *Builder, r clause.Returning) {
+	returning(b, r)
+}
+
+func (postgres) Output(b *Builder, r clause.Returning) {}
+
+func (postgres) Keyset(b *Builder, k clause.Keyset) {
+	keyset(b, k)
+}
+
+type sqlite struct{}
+
+func (sqlite) Name() string { return "sqlite" }
+
+func (sqlite) QuoteIdent(w clause.Writer, ident string) { quoteIdent(w, ident, '"', '"') }
+
+func (sqlite) BindVar(w clause.Writer, n int) { w.WriteByte('?') }
+
+// Paginate SQLite 不支持单独的 OFFSET，使用 LIMIT -1 表示不限制。
+func (sqlite) Paginate(b *Builder, p clause.Pagination, ordered bool) {
+	limitOffset(b, p, "-1")
+}
+
+// RowLimit SQLite 只有在编译时启用 SQLITE_ENABLE_UPDATE_DELETE_LIMIT 才支持 UPDATE/DELETE ... LIMIT，
+// 默认构建不支持，记录 ErrUnsupported 错误。
+func (sqlite) RowLimit(b *Builder, limit int) {
+	b.AddError(fmt.Errorf("sqlite: %w: UPDATE/DELETE LIMIT", ErrUnsupported))
+}
+
+func (sqlite) TargetTable(b *Builder, t clause.Table) {
+	t.Build(b)
+}
+
+func (sqlite) OnConflict(b *Builder, c clause.OnConflict) {
+	onConflict(b, c)
+}
+
+// Returning SQLite 3.35 起支持 RETURNING。
+func (sqlite) Returning(b *Builder, r clause.Returning) {
+	returning(b, r)
+}
+
+func (sqlite) Output(b *Builder, r clause.Returning) {}
+
+// Keyset SQLite 3.15 起支持行值比较。
+func (sqlite) Keyset(b *Builder, k clause.Keyset) {
+	keyset(b, k)
+}
+
+type sqlserver struct{}
+
+func (sqlserver) Name() string { return "sqlserver" }
+
+func (sqlserver) QuoteIdent(w clause.Writer, ident string) { quoteIdent(w, ident, '[', ']') }
+
+func (sqlserver) BindVar(w clause.Writer, n int) {
+	w.WriteString("@p")
+	w.WriteString(strconv.Itoa(n))
+}
+
+// Paginate SQL Server 使用 OFFSET ... ROWS FETCH NEXT ... ROWS ONLY 语法，
+// 该语法要求语句必须包含 ORDER BY，缺失时补充 ORDER BY (SELECT NULL)。
+func (sqlserver) Paginate(b *Builder, p clause.Pagination, ordered bool) {
+	hasLimit := p.Limit != nil && *p.Limit > 0
+	if !hasLimit && p.Offset <= 0 {
+		return
+	}
+
+	if !ordered {
+		b.WriteString(" ORDER BY (SELECT NULL)")
+	}
+
+	b.WriteString(" OFFSET ")
+	b.AddVar(b, p.Offset)
+	b.WriteString(" ROWS")
+
+	if hasLimit {
+		b.WriteString(" FETCH NEXT ")
+		b.AddVar(b, *p.Limit)
+		b.WriteString(" ROWS ONLY")
+	}
+}
+
+// RowLimit SQL Server 使用 UPDATE TOP (n)/DELETE TOP (n) 限制行数，不支持语句末尾的 LIMIT，记录 ErrUnsupported 错误。
+func (sqlserver) RowLimit(b *Builder, limit int) {
+	b.AddError(fmt.Errorf("sqlserver: %w: UPDATE/DELETE LIMIT", ErrUnsupported))
+}
+
+// TargetTable SQL Server 的 UPDATE/DELETE 不能直接为目标表指定别名（需写为 UPDATE u ... FROM users AS u），
+// 目标表带别名时记录 ErrUnsupported 错误。
+func (sqlserver) TargetTable(b *Builder, t clause.Table) {
+	if t.Alias != "" {
+		b.AddError(fmt.Errorf("sqlserver: %w: UPDATE/DELETE target table alias", ErrUnsupported))
+	}
+	t.Build(b)
+}
+
+// OnConflict SQL Server 没有 INSERT 冲突处理语法（需使用 MERGE），记录 ErrUnsupported 错误。
+func (sqlserver) OnConflict(b *Builder, c clause.OnConflict) {
+	b.AddError(fmt.Errorf("sqlserver: %w: ON CONFLICT", ErrUnsupported))
+}
+
+// Returning SQL Server 的返回列由 Output 在 OUTPUT 子句中输出，语句末尾不输出。
+func (sqlserver) Returning(b *Builder, r clause.Returning) {}
+
+// Output SQL Server 使用 OUTPUT INSERTED.column 或 OUTPUT DELETED.column 返回受影响的行。
+func (sqlserver) Output(b *Builder, r clause.Returning) {
+	table := r.Table
+	if table == "" {
+		table = "INSERTED"
+	}
+	b.WriteString(" OUTPUT ")
+	r.BuildColumns(b, table+".")
+}
+
+// Keyset SQL Server 不支持行值比较，始终展开为 OR 条件。
+func (sqlserver) Keyset(b *Builder, k clause.Keyset) {
+	k.BuildExpanded(b)
+}
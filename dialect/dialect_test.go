@@ -0,0 +1,679 @@
+package dialect_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/epkgs/query"
+	"github.com/epkgs/query/clause"
+	"github.com/epkgs/query/dialect"
+)
+
+// TestBuild_Dialects 测试不同方言的标识符引用与占位符
+func TestBuild_Dialects(t *testing.T) {
+	tests := []struct {
+		name         string
+		dialect      dialect.Dialect
+		expected     string
+		expectedVars []any
+	}{
+		{
+			name:         "MySQL",
+			dialect:      dialect.MySQL,
+			expected:     "SELECT `id`, `name` FROM `users` WHERE `name` = ? AND `age` IN (?,?) LIMIT ? OFFSET ?",
+			expectedVars: []any{"John", 18, 19, 10, 20},
+		},
+		{
+			name:         "PostgreSQL",
+			dialect:      dialect.PostgreSQL,
+			expected:     `SELECT "id", "name" FROM "users" WHERE "name" = $1 AND "age" IN ($2,$3) LIMIT $4 OFFSET $5`,
+			expectedVars: []any{"John", 18, 19, 10, 20},
+		},
+		{
+			name:         "SQLite",
+			dialect:      dialect.SQLite,
+			expected:     `SELECT "id", "name" FROM "users" WHERE "name" = ? AND "age" IN (?,?) LIMIT ? OFFSET ?`,
+			expectedVars: []any{"John", 18, 19, 10, 20},
+		},
+		{
+			name:         "SQLServer",
+			dialect:      dialect.SQLServer,
+			expected:     "SELECT [id], [name] FROM [users] WHERE [name] = @p1 AND [age] IN (@p2,@p3) ORDER BY (SELECT NULL) OFFSET @p4 ROWS FETCH NEXT @p5 ROWS ONLY",
+			expectedVars: []any{"John", 18, 19, 20, 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := query.Table("users").
+				Eq("name", "John").
+				In("age", 18, 19).
+				Limit(10).
+				Offset(20).
+				Select("id", "name")
+
+			sql, vars, err := dialect.Build(tt.dialect, q)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if sql != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, sql)
+			}
+
+			if !reflect.DeepEqual(vars, tt.expectedVars) {
+				t.Errorf("expected vars: %v, got: %v", tt.expectedVars, vars)
+			}
+		})
+	}
+}
+
+// TestBuild_OffsetWithoutLimit 测试仅设置 OFFSET 时各方言的分页语法
+func TestBuild_OffsetWithoutLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  dialect.Dialect
+		expected string
+	}{
+		{
+			name:     "MySQL",
+			dialect:  dialect.MySQL,
+			expected: "SELECT * FROM `users` ORDER BY `id` ASC LIMIT 18446744073709551615 OFFSET ?",
+		},
+		{
+			name:     "PostgreSQL",
+			dialect:  dialect.PostgreSQL,
+			expected: `SELECT * FROM "users" ORDER BY "id" ASC OFFSET $1`,
+		},
+		{
+			name:     "SQLite",
+			dialect:  dialect.SQLite,
+			expected: `SELECT * FROM "users" ORDER BY "id" ASC LIMIT -1 OFFSET ?`,
+		},
+		{
+			name:     "SQLServer",
+			dialect:  dialect.SQLServer,
+			expected: "SELECT * FROM [users] ORDER BY [id] ASC OFFSET @p1 ROWS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := query.Table("users").Asc("id").Offset(5).Select()
+
+			sql, vars, err := dialect.Build(tt.dialect, q)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if sql != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, sql)
+			}
+
+			if !reflect.DeepEqual(vars, []any{5}) {
+				t.Errorf("expected vars: [5], got: %v", vars)
+			}
+		})
+	}
+}
+
+// TestBuild_Statements 测试 INSERT、UPDATE、DELETE 语句的构建
+func TestBuild_Statements(t *testing.T) {
+	tests := []struct {
+		name         string
+		expr         clause.Expression
+		expected     string
+		expectedVars []any
+	}{
+		{
+			name:         "Insert",
+			expr:         query.Table("users").Insert(map[string]any{"name": "John", "age": 30}),
+			expected:     `INSERT INTO "users" ("age", "name") VALUES ($1, $2)`,
+			expectedVars: []any{30, "John"},
+		},
+		{
+			name:         "Update",
+			expr:         query.Table("users").Eq("id", 1).Update("name", "John"),
+			expected:     `UPDATE "users" SET "name" = $1 WHERE "id" = $2`,
+			expectedVars: []any{"John", 1},
+		},
+		{
+			name:         "Delete",
+			expr:         query.Table("users").Eq("id", 1).Or(query.Eq("id", 2)).Delete(),
+			expected:     `DELETE FROM "users" WHERE "id" = $1 OR "id" = $2`,
+			expectedVars: []any{1, 2},
+		},
+		{
+			name:         "Not and null",
+			expr:         query.Table("users").Not(query.Eq("status", "banned")).Eq("deleted_at", nil).Select(),
+			expected:     `SELECT * FROM "users" WHERE "status" <> $1 AND "deleted_at" IS NULL`,
+			expectedVars: []any{"banned"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, vars, err := dialect.Build(dialect.PostgreSQL, tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if sql != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, sql)
+			}
+
+			if !reflect.DeepEqual(vars, tt.expectedVars) {
+				t.Errorf("expected vars: %v, got: %v", tt.expectedVars, vars)
+			}
+		})
+	}
+}
+
+// TestBuilder_WriteQuoted 测试标识符引用与转义
+func TestBuilder_WriteQuoted(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  dialect.Dialect
+		field    any
+		expected string
+	}{
+		{name: "MySQL qualified", dialect: dialect.MySQL, field: "users.id", expected: "`users`.`id`"},
+		{name: "MySQL escape", dialect: dialect.MySQL, field: "we`ird", expected: "`we``ird`"},
+		{name: "PostgreSQL star", dialect: dialect.PostgreSQL, field: "users.*", expected: `"users".*`},
+		{name: "PostgreSQL escape", dialect: dialect.PostgreSQL, field: `we"ird`, expected: `"we""ird"`},
+		{name: "SQLServer escape", dialect: dialect.SQLServer, field: "we]ird", expected: "[we]]ird]"},
+		{name: "non-string", dialect: dialect.SQLite, field: 1, expected: `"1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := dialect.NewBuilder(tt.dialect)
+			b.WriteQuoted(tt.field)
+
+			if b.SQL() != tt.expected {
+				t.Errorf("expected: %s, got: %s", tt.expected, b.SQL())
+			}
+		})
+	}
+}
+
+// TestBuilder_AddVar 测试参数绑定
+func TestBuilder_AddVar(t *testing.T) {
+	b := dialect.NewBuilder(dialect.PostgreSQL)
+	b.AddVar(b, 1, []any{2, 3}, []any{})
+
+	expected := "$1,($2,$3),(NULL)"
+	if b.SQL() != expected {
+		t.Errorf("expected: %s, got: %s", expected, b.SQL())
+	}
+
+	if !reflect.DeepEqual(b.Vars(), []any{1, 2, 3}) {
+		t.Errorf("expected vars: [1 2 3], got: %v", b.Vars())
+	}
+}
+
+// TestBuilder_Errors 测试错误收集
+func TestBuilder_Errors(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+
+	b := dialect.NewBuilder(dialect.MySQL)
+	if b.Err() != nil {
+		t.Fatalf("expected nil error, got: %v", b.Err())
+	}
+
+	b.AddError(nil)
+	b.AddError(errA)
+	if b.Err() != errA {
+		t.Errorf("expected error a, got: %v", b.Err())
+	}
+
+	b.AddError(errB)
+	if !errors.Is(b.Err(), errB) {
+		t.Errorf("expected error to wrap b, got: %v", b.Err())
+	}
+	if b.Err().Error() != "a; b" {
+		t.Errorf("expected message 'a; b', got: %s", b.Err().Error())
+	}
+
+	// 直接调用 Is/As，不依赖 Go 1.20 起 errors 包对 Unwrap() []error 的支持
+	errs, ok := b.Err().(dialect.Errors)
+	if !ok {
+		t.Fatalf("expected dialect.Errors, got: %T", b.Err())
+	}
+	if !errs.Is(errB) || errs.Is(errors.New("c")) {
+		t.Errorf("expected Is to match members only")
+	}
+	b.AddError(&query.FieldError{Field: "age", Err: query.ErrUnknownField})
+	var fieldErr *query.FieldError
+	if !b.Err().(dialect.Errors).As(&fieldErr) || fieldErr.Field != "age" {
+		t.Errorf("expected As to find *query.FieldError, got: %v", fieldErr)
+	}
+
+	b.Reset()
+	if b.Err() != nil || b.SQL() != "" || len(b.Vars()) != 0 {
+		t.Error("expected builder to be empty after Reset")
+	}
+}
+
+// TestBuild_QueryError 测试查询构建错误通过 AddError 传递
+func TestBuild_QueryError(t *testing.T) {
+	q := query.Table("users").Where("age", "~", 18).Select()
+
+	_, _, err := dialect.Build(dialect.MySQL, q)
+	if !errors.Is(err, query.ErrInvalidOperator) {
+		t.Errorf("expected ErrInvalidOperator, got: %v", err)
+	}
+}
+
+// TestBuild_Join 测试JOIN与限定列名的引用
+func TestBuild_Join(t *testing.T) {
+	q := query.Table("users u").
+		LeftJoin("orders o", query.On("o.user_id", "u.id")).
+		Eq("o.status", "paid").
+		Select("u.id", "o.*")
+
+	sql, vars, err := dialect.Build(dialect.MySQL, q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "SELECT `u`.`id`, `o`.* FROM `users` AS `u` LEFT JOIN `orders` AS `o` ON `o`.`user_id` = `u`.`id` WHERE `o`.`status` = ?"
+	if sql != expected {
+		t.Errorf("expected SQL: %s, got: %s", expected, sql)
+	}
+
+	if !reflect.DeepEqual(vars, []any{"paid"}) {
+		t.Errorf("expected vars: [paid], got: %v", vars)
+	}
+}
+
+// TestBuild_GroupBy 测试 HAVING 中的别名在 PostgreSQL 下替换为聚合表达式
+func TestBuild_GroupBy(t *testing.T) {
+	q := query.Table("orders").
+		Select("user_id").
+		SelectExpr(clause.As(clause.Count("*"), "cnt")).
+		GroupBy("user_id").
+		Having("cnt", ">=", 2)
+
+	sql, vars, err := dialect.Build(dialect.PostgreSQL, q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `SELECT "user_id", COUNT(*) AS "cnt" FROM "orders" GROUP BY "user_id" HAVING COUNT(*) >= $1`
+	if sql != expected {
+		t.Errorf("expected SQL: %s, got: %s", expected, sql)
+	}
+
+	if !reflect.DeepEqual(vars, []any{2}) {
+		t.Errorf("expected vars: [2], got: %v", vars)
+	}
+}
+
+// TestBuild_SubQuery 测试子查询参数与外层参数合并编号
+func TestBuild_SubQuery(t *testing.T) {
+	orders := query.Table("orders o").
+		Where(query.On("o.user_id", "u.id")).
+		Gt("o.amount", 100).
+		Select("o.id")
+
+	q := query.Table("users u").
+		Eq("u.status", "active").
+		Exists(orders).
+		In("u.team_id", query.Table("teams").Eq("region", "eu").Select("id")).
+		Select("u.id")
+
+	sql, vars, err := dialect.Build(dialect.PostgreSQL, q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `SELECT "u"."id" FROM "users" AS "u" WHERE "u"."status" = $1 AND EXISTS (SELECT "o"."id" FROM "orders" AS "o" WHERE "o"."user_id" = "u"."id" AND "o"."amount" > $2) AND "u"."team_id" IN (SELECT "id" FROM "teams" WHERE "region" = $3)`
+	if sql != expected {
+		t.Errorf("expected SQL: %s, got: %s", expected, sql)
+	}
+
+	if !reflect.DeepEqual(vars, []any{"active", 100, "eu"}) {
+		t.Errorf("expected vars: [active 100 eu], got: %v", vars)
+	}
+}
+
+// TestBuild_SQLServerOrderedSubQuery 测试子查询或原生 SQL 中的 ORDER BY 不影响外层分页补充排序
+func TestBuild_SQLServerOrderedSubQuery(t *testing.T) {
+	latest := query.Table("orders").Desc("id").Limit(10).Select("user_id")
+
+	tests := []struct {
+		name     string
+		expr     clause.Expression
+		expected string
+	}{
+		{
+			name:     "subquery",
+			expr:     query.Table("users").In("id", latest).Limit(5).Offset(10).Select(),
+			expected: "SELECT * FROM [users] WHERE [id] IN (SELECT [user_id] FROM [orders] ORDER BY [id] DESC OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY) ORDER BY (SELECT NULL) OFFSET @p3 ROWS FETCH NEXT @p4 ROWS ONLY",
+		},
+		{
+			name:     "raw",
+			expr:     query.Table("users").Where(clause.Raw("id IN (SELECT TOP 10 user_id FROM orders ORDER BY id DESC)")).Limit(5).Select(),
+			expected: "SELECT * FROM [users] WHERE id IN (SELECT TOP 10 user_id FROM orders ORDER BY id DESC) ORDER BY (SELECT NULL) OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY",
+		},
+		{
+			name:     "ordered",
+			expr:     query.Table("users").In("id", latest).Asc("name").Limit(5).Select(),
+			expected: "SELECT * FROM [users] WHERE [id] IN (SELECT [user_id] FROM [orders] ORDER BY [id] DESC OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY) ORDER BY [name] ASC OFFSET @p3 ROWS FETCH NEXT @p4 ROWS ONLY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, _, err := dialect.Build(dialect.SQLServer, tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sql != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, sql)
+			}
+		})
+	}
+}
+
+// TestBuild_ExtendedOperators 测试扩展操作符在不同方言下的输出
+func TestBuild_ExtendedOperators(t *testing.T) {
+	q := query.Table("users").
+		Between("age", 18, 30).
+		IsNull("deleted_at").
+		NotIn("id", 1, 2).
+		ILike("name", "jo%").
+		StartsWith("code", "A_").
+		Select("id")
+
+	sql, vars, err := dialect.Build(dialect.PostgreSQL, q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `SELECT "id" FROM "users" WHERE "age" BETWEEN $1 AND $2 AND "deleted_at" IS NULL AND "id" NOT IN ($3,$4) AND LOWER("name") LIKE LOWER($5) AND "code" LIKE $6 ESCAPE '!'`
+	if sql != expected {
+		t.Errorf("expected SQL: %s, got: %s", expected, sql)
+	}
+
+	if !reflect.DeepEqual(vars, []any{18, 30, 1, 2, "jo%", "A!_%"}) {
+		t.Errorf("unexpected vars: %v", vars)
+	}
+}
+
+// TestBuild_Raw 测试原生 SQL 表达式的占位符按方言生成
+func TestBuild_Raw(t *testing.T) {
+	q := query.Table("users").
+		Where(clause.Raw("age BETWEEN @min AND @max", map[string]any{"min": 18, "max": 30})).
+		Where(clause.Raw("status IN ?", []any{"a", "b"})).
+		OrderBy(clause.Raw("FIELD(level, ?)", 1)).
+		Select("id")
+
+	sql, vars, err := dialect.Build(dialect.PostgreSQL, q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `SELECT "id" FROM "users" WHERE age BETWEEN $1 AND $2 AND status IN ($3,$4) ORDER BY FIELD(level, $5) ASC`
+	if sql != expected {
+		t.Errorf("expected SQL: %s, got: %s", expected, sql)
+	}
+
+	if !reflect.DeepEqual(vars, []any{18, 30, "a", "b", 1}) {
+		t.Errorf("unexpected vars: %v", vars)
+	}
+
+	_, _, err = dialect.Build(dialect.MySQL, query.Table("users").Where(clause.Raw("a = ? AND b = ?", 1)).Select())
+	if !errors.Is(err, clause.ErrRawVars) {
+		t.Errorf("expected ErrRawVars, got: %v", err)
+	}
+}
+
+// TestBuild_OnConflict 测试 INSERT 冲突处理在不同方言下的输出
+func TestBuild_OnConflict(t *testing.T) {
+	insert := func() *query.InsertQuery {
+		return query.Table("users").Insert("email", "a@x.com", "name", "A")
+	}
+
+	tests := []struct {
+		name     string
+		dialect  dialect.Dialect
+		expr     clause.Expression
+		expected string
+	}{
+		{
+			name:     "PostgreSQL do update",
+			dialect:  dialect.PostgreSQL,
+			expr:     insert().OnConflict("email").DoUpdateColumns("name"),
+			expected: `INSERT INTO "users" ("email", "name") VALUES ($1, $2) ON CONFLICT ("email") DO UPDATE SET "name" = excluded."name"`,
+		},
+		{
+			name:     "SQLite do nothing",
+			dialect:  dialect.SQLite,
+			expr:     insert().OnConflict("email").DoNothing(),
+			expected: `INSERT INTO "users" ("email", "name") VALUES (?, ?) ON CONFLICT ("email") DO NOTHING`,
+		},
+		{
+			name:     "MySQL do update",
+			dialect:  dialect.MySQL,
+			expr:     insert().OnConflict().DoUpdate(map[string]any{"name": clause.Excluded{Column: "name"}, "score": 1}),
+			expected: "INSERT INTO `users` (`email`, `name`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`), `score` = ?",
+		},
+		{
+			name:     "MySQL do nothing",
+			dialect:  dialect.MySQL,
+			expr:     insert().OnConflict("email").DoNothing(),
+			expected: "INSERT INTO `users` (`email`, `name`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `email` = `email`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, _, err := dialect.Build(tt.dialect, tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sql != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, sql)
+			}
+		})
+	}
+
+	for _, d := range []dialect.Dialect{dialect.SQLServer, dialect.MySQL} {
+		_, _, err := dialect.Build(d, insert().OnConflict().DoNothing())
+		if !errors.Is(err, dialect.ErrUnsupported) {
+			t.Errorf("%s: expected ErrUnsupported, got: %v", d.Name(), err)
+		}
+	}
+
+	// ON CONFLICT DO UPDATE 必须指定冲突列，DO NOTHING 可以省略
+	for _, d := range []dialect.Dialect{dialect.PostgreSQL, dialect.SQLite} {
+		_, _, err := dialect.Build(d, insert().OnConflict().DoUpdateColumns("name"))
+		if !errors.Is(err, dialect.ErrUnsupported) {
+			t.Errorf("%s: expected ErrUnsupported, got: %v", d.Name(), err)
+		}
+		if _, _, err := dialect.Build(d, insert().OnConflict().DoNothing()); err != nil {
+			t.Errorf("%s: unexpected error: %v", d.Name(), err)
+		}
+	}
+}
+
+// TestBuild_Returning 测试 RETURNING 与 SQL Server OUTPUT 子句
+func TestBuild_Returning(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  dialect.Dialect
+		expr     clause.Expression
+		expected string
+	}{
+		{
+			name:     "PostgreSQL insert",
+			dialect:  dialect.PostgreSQL,
+			expr:     query.Table("users").Insert("name", "John").Returning("id"),
+			expected: `INSERT INTO "users" ("name") VALUES ($1) RETURNING "id"`,
+		},
+		{
+			name:     "SQLite delete",
+			dialect:  dialect.SQLite,
+			expr:     query.Table("users").Eq("id", 1).Delete().Returning("*"),
+			expected: `DELETE FROM "users" WHERE "id" = ? RETURNING *`,
+		},
+		{
+			name:     "SQL Server insert",
+			dialect:  dialect.SQLServer,
+			expr:     query.Table("users").Insert("name", "John").Returning("id", "name"),
+			expected: `INSERT INTO [users] ([name]) OUTPUT INSERTED.[id], INSERTED.[name] VALUES (@p1)`,
+		},
+		{
+			name:     "SQL Server update",
+			dialect:  dialect.SQLServer,
+			expr:     query.Table("users").Eq("id", 1).Update("name", "John").Returning("name"),
+			expected: `UPDATE [users] SET [name] = @p1 OUTPUT INSERTED.[name] WHERE [id] = @p2`,
+		},
+		{
+			name:     "SQL Server delete",
+			dialect:  dialect.SQLServer,
+			expr:     query.Table("users").Eq("id", 1).Delete().Returning("*"),
+			expected: `DELETE FROM [users] OUTPUT DELETED.* WHERE [id] = @p1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, _, err := dialect.Build(tt.dialect, tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sql != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, sql)
+			}
+		})
+	}
+
+	_, _, err := dialect.Build(dialect.MySQL, query.Table("users").Eq("id", 1).Delete().Returning("id"))
+	if !errors.Is(err, dialect.ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got: %v", err)
+	}
+}
+
+// TestBuild_RowLimit 测试 UPDATE/DELETE 的行数限制，只有 MySQL 支持，其余方言返回 ErrUnsupported
+func TestBuild_RowLimit(t *testing.T) {
+	update := query.Table("users").Eq("status", "banned").Limit(10).Update("active", false)
+	del := query.Table("users").Eq("status", "banned").Limit(10).Delete()
+
+	sql, vars, err := dialect.Build(dialect.MySQL, update)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "UPDATE `users` SET `active` = ? WHERE `status` = ? LIMIT ?"; sql != expected {
+		t.Errorf("expected SQL: %s, got: %s", expected, sql)
+	}
+	if !reflect.DeepEqual(vars, []any{false, "banned", 10}) {
+		t.Errorf("expected vars: [false banned 10], got: %v", vars)
+	}
+
+	sql, _, err = dialect.Build(dialect.MySQL, del)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "DELETE FROM `users` WHERE `status` = ? LIMIT ?"; sql != expected {
+		t.Errorf("expected SQL: %s, got: %s", expected, sql)
+	}
+
+	for _, d := range []dialect.Dialect{dialect.PostgreSQL, dialect.SQLite, dialect.SQLServer} {
+		for _, expr := range []clause.Expression{update, del} {
+			if _, _, err := dialect.Build(d, expr); !errors.Is(err, dialect.ErrUnsupported) {
+				t.Errorf("%s: expected ErrUnsupported, got: %v", d.Name(), err)
+			}
+		}
+	}
+
+	// 未设置 Limit 时不输出行数限制
+	if _, _, err := dialect.Build(dialect.PostgreSQL, query.Table("users").Offset(5).Delete()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestBuild_TargetTableAlias 测试 UPDATE/DELETE 目标表的别名
+func TestBuild_TargetTableAlias(t *testing.T) {
+	update := query.Table("users u").Eq("u.id", 1).Update("name", "John")
+	del := query.Table("users AS u").Eq("u.id", 1).Delete()
+
+	tests := []struct {
+		dialect dialect.Dialect
+		update  string
+		delete  string
+	}{
+		{dialect.MySQL, "UPDATE `users` AS `u` SET `name` = ? WHERE `u`.`id` = ?", "DELETE FROM `users` AS `u` WHERE `u`.`id` = ?"},
+		{dialect.PostgreSQL, `UPDATE "users" AS "u" SET "name" = $1 WHERE "u"."id" = $2`, `DELETE FROM "users" AS "u" WHERE "u"."id" = $1`},
+		{dialect.SQLite, `UPDATE "users" AS "u" SET "name" = ? WHERE "u"."id" = ?`, `DELETE FROM "users" AS "u" WHERE "u"."id" = ?`},
+	}
+	for _, tt := range tests {
+		for expr, expected := range map[clause.Expression]string{update: tt.update, del: tt.delete} {
+			sql, _, err := dialect.Build(tt.dialect, expr)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.dialect.Name(), err)
+			}
+			if sql != expected {
+				t.Errorf("%s: expected SQL: %s, got: %s", tt.dialect.Name(), expected, sql)
+			}
+		}
+	}
+
+	// SQL Server 不能直接为目标表指定别名
+	for _, expr := range []clause.Expression{update, del} {
+		if _, _, err := dialect.Build(dialect.SQLServer, expr); !errors.Is(err, dialect.ErrUnsupported) {
+			t.Errorf("sqlserver: expected ErrUnsupported, got: %v", err)
+		}
+	}
+	sql, _, err := dialect.Build(dialect.SQLServer, query.Table("users").Eq("id", 1).Delete())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "DELETE FROM [users] WHERE [id] = @p1"; sql != expected {
+		t.Errorf("expected SQL: %s, got: %s", expected, sql)
+	}
+}
+
+func TestBuild_Keyset(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  dialect.Dialect
+		expr     clause.Expression
+		expected string
+	}{
+		{
+			name:     "PostgreSQL compound",
+			dialect:  dialect.PostgreSQL,
+			expr:     query.Table("users").Desc("age").Desc("id").Limit(10).Select("id").AfterValues(18, 100),
+			expected: `SELECT "id" FROM "users" WHERE ("age", "id") < ($1, $2) ORDER BY "age" DESC, "id" DESC LIMIT $3`,
+		},
+		{
+			name:     "MySQL mixed directions",
+			dialect:  dialect.MySQL,
+			expr:     query.Table("users").Desc("age").Asc("id").Select("id").AfterValues(18, 100),
+			expected: "SELECT `id` FROM `users` WHERE (`age` < ? OR (`age` = ? AND `id` > ?)) ORDER BY `age` DESC, `id` ASC",
+		},
+		{
+			name:     "SQL Server expanded",
+			dialect:  dialect.SQLServer,
+			expr:     query.Table("users").Asc("age").Asc("id").Limit(10).Select("id").AfterValues(18, 100),
+			expected: `SELECT [id] FROM [users] WHERE ([age] > @p1 OR ([age] = @p2 AND [id] > @p3)) ORDER BY [age] ASC, [id] ASC OFFSET @p4 ROWS FETCH NEXT @p5 ROWS ONLY`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, _, err := dialect.Build(tt.dialect, tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sql != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, sql)
+			}
+		})
+	}
+}
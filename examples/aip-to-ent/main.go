@@ -40,26 +40,28 @@ func (r AIPRequest) GetPageToken() string {
 	return r.pageToken
 }
 
-// paginationRequest 定义分页请求接口
-type paginationRequest interface {
-	GetPageSize() int32
-	GetPageToken() string
-}
-
-// parsePagination 将分页请求转换为 clause.Pagination
-func parsePagination(pageReq paginationRequest) clause.Pagination {
-	pageSize := int(pageReq.GetPageSize())
-	if pageSize <= 0 {
-		pageSize = 10 // 默认值
+// parsePagination 将分页请求转换为 clause.Pagination。
+// page_token 由 aip.ParsePageToken 解析，校验签名以及 filter/order_by 是否与生成令牌时一致。
+// 实际项目中签名密钥应从配置或密钥管理服务中读取。
+func parsePagination(pageReq aip.PageRequest) clause.Pagination {
+	token, err := aip.ParsePageToken(pageReq, []byte("page-token-secret"))
+	if err != nil {
+		log.Fatalf("Error parsing page token: %v", err)
 	}
 
-	// 简单实现：将 pageToken 转换为 offset
-	offset := 0
+	pageSize, err := aip.PageSize(pageReq, 10, 100)
+	if err != nil {
+		log.Fatalf("Error parsing page size: %v", err)
+	}
 
-	return clause.Pagination{
-		Limit:  &pageSize,
-		Offset: offset,
+	// 实际项目在查询结果满一页时，将下一页令牌作为 next_page_token 返回
+	next, err := token.Next(pageSize).Encode()
+	if err != nil {
+		log.Fatalf("Error encoding next page token: %v", err)
 	}
+	fmt.Printf("✓ Next page token: %s\n", next)
+
+	return token.Pagination(pageSize)
 }
 
 // createFilterDeclarations 创建过滤字段声明
@@ -102,7 +104,7 @@ func parseAIPRequests(req AIPRequest, filterDecls *filtering.Declarations) (filt
 }
 
 // convertToClauseObjects 将 AIP 请求转换为 clause 对象
-func convertToClauseObjects(filter filtering.Filter, orderBy ordering.OrderBy, pageReq paginationRequest) (clause.Where, clause.OrderBys, clause.Pagination) {
+func convertToClauseObjects(filter filtering.Filter, orderBy ordering.OrderBy, pageReq aip.PageRequest) (clause.Where, clause.OrderBys, clause.Pagination) {
 	fmt.Println("Step 3: Converting to clause objects...")
 
 	// 转换过滤条件
@@ -41,24 +41,28 @@ func (r AIPRequest) GetPageToken() string {
 	return r.pageToken
 }
 
-type paginationRequest interface {
-	GetPageSize() int32
-	GetPageToken() string
-}
-
-// parsePagination 将 pagination.Request 转换为 clause.Pagination
-func parsePagination(pageReq paginationRequest) clause.Pagination {
-	pageSize := int(pageReq.GetPageSize())
+// parsePagination 将分页请求转换为 clause.Pagination。
+// page_token 由 aip.ParsePageToken 解析，校验签名以及 filter/order_by 是否与生成令牌时一致。
+// 实际项目中签名密钥应从配置或密钥管理服务中读取。
+func parsePagination(pageReq aip.PageRequest) clause.Pagination {
+	token, err := aip.ParsePageToken(pageReq, []byte("page-token-secret"))
+	if err != nil {
+		log.Fatalf("Error parsing page token: %v", err)
+	}
 
-	// 将 pageToken 转换为 offset
-	// 这里直接指定 offset 为 0
-	// 实际项目需要根据 pagination token 解析 offset
-	offset := 0
+	pageSize, err := aip.PageSize(pageReq, 10, 100)
+	if err != nil {
+		log.Fatalf("Error parsing page size: %v", err)
+	}
 
-	return clause.Pagination{
-		Limit:  &pageSize,
-		Offset: offset,
+	// 实际项目在查询结果满一页时，将下一页令牌作为 next_page_token 返回
+	next, err := token.Next(pageSize).Encode()
+	if err != nil {
+		log.Fatalf("Error encoding next page token: %v", err)
 	}
+	fmt.Printf("✓ Next page token: %s\n", next)
+
+	return token.Pagination(pageSize)
 }
 
 // createFilterDeclarations 创建过滤字段声明
@@ -101,7 +105,7 @@ func parseAIPRequests(req AIPRequest, filterDecls *filtering.Declarations) (filt
 }
 
 // convertToClauseObjects 将 AIP 请求转换为 clause 对象
-func convertToClauseObjects(filter filtering.Filter, orderBy ordering.OrderBy, pageReq paginationRequest) (clause.Where, clause.OrderBys, clause.Pagination) {
+func convertToClauseObjects(filter filtering.Filter, orderBy ordering.OrderBy, pageReq aip.PageRequest) (clause.Where, clause.OrderBys, clause.Pagination) {
 	fmt.Println("Step 3: Converting to clause objects...")
 
 	// 转换过滤条件
@@ -128,15 +132,15 @@ func convertToGormScopes(whereClause clause.Where, orderBys clause.OrderBys, pag
 	fmt.Println("Step 4: Converting to GORM scopes...")
 
 	// 转换过滤条件
-	whereScope := qgorm.Where(whereClause)
+	whereScope := qgorm.WhereScope(whereClause)
 	fmt.Println("✓ Filter converted to GORM where scope")
 
 	// 转换排序条件
-	orderScope := qgorm.OrderBy(orderBys)
+	orderScope := qgorm.OrderByScope(orderBys)
 	fmt.Println("✓ Order by converted to GORM order scope")
 
 	// 转换分页条件
-	paginationScope := qgorm.Pagination(paginationClause)
+	paginationScope := qgorm.PaginationScope(paginationClause)
 	fmt.Println("✓ Pagination converted to GORM pagination scope")
 	fmt.Println()
 
@@ -0,0 +1,73 @@
+package query
+
+import "github.com/epkgs/query/clause"
+
+// String 返回查询的可读文本，按 SELECT * 渲染，参数值以 SQL 字面量的形式内联，
+// 用于日志和测试失败信息，格式见 clause.String。
+//
+// 示例:
+//
+//	query.Table("users").Eq("name", "O'Brien").Limit(10).String()
+//	// SELECT * FROM users WHERE name = 'O''Brien' LIMIT 10
+func (q *Query) String() string {
+	return q.Select().String()
+}
+
+// Explain 返回查询的多行可读文本，WHERE/HAVING 条件按 AND/OR/NOT 分组逐层缩进，格式见 clause.Explain。
+//
+// 示例:
+//
+//	query.Table("users").Eq("status", "active").
+//	    Where(clause.Or(clause.Gt{Col: "age", Val: 18}, clause.Eq{Col: "role", Val: "admin"})).
+//	    Limit(10).Explain()
+//	// SELECT * FROM users
+//	// WHERE
+//	//   status = 'active'
+//	//   AND (
+//	//     age > 18
+//	//     OR role = 'admin'
+//	//   )
+//	// LIMIT 10
+func (q *Query) Explain() string {
+	return q.Select().Explain()
+}
+
+// String 返回 SELECT 语句的可读文本，见 Query.String
+func (q *SelectQuery) String() string {
+	return clause.String(q)
+}
+
+// Explain 返回 SELECT 语句的多行可读文本，见 Query.Explain
+func (q *SelectQuery) Explain() string {
+	return clause.Explain(q)
+}
+
+// String 返回 INSERT 语句的可读文本，见 Query.String
+func (q *InsertQuery) String() string {
+	return clause.String(q)
+}
+
+// Explain 返回 INSERT 语句的多行可读文本，见 Query.Explain
+func (q *InsertQuery) Explain() string {
+	return clause.Explain(q)
+}
+
+// String 返回 UPDATE 语句的可读文本，见 Query.String
+func (q *UpdateQuery) String() string {
+	return clause.String(q)
+}
+
+// Explain 返回 UPDATE 语句的多行可读文本，见 Query.Explain
+func (q *UpdateQuery) Explain() string {
+	return clause.Explain(q)
+}
+
+// String 返回 DELETE 语句的可读文本，见 Query.String
+func (q *DeleteQuery) String() string {
+	return clause.String(q)
+}
+
+// Explain 返回 DELETE 语句的多行可读文本，见 Query.Explain
+func (q *DeleteQuery) Explain() string {
+	return clause.Explain(q)
+}
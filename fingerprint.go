@@ -0,0 +1,162 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/epkgs/query/clause"
+)
+
+// Canonical 返回查询的规范文本，语义等价的查询（如 AND 条件的顺序不同）返回相同的文本，
+// 可用作列表结果的缓存键。格式见 clause.Canonical。
+//
+// 示例:
+//
+//	q1 := query.Table("users").Eq("status", "active").Gte("age", 18).Limit(10)
+//	q2 := query.Table("users").Gte("age", 18).Eq("status", "active").Limit(10)
+//	q1.Canonical() == q2.Canonical() // true
+//	// FROM users WHERE age >= 18 AND status = "active" LIMIT 10
+func (q *Query) Canonical() string {
+	return canonicalQuery(q.canonicalParts(), false)
+}
+
+// Shape 返回查询的结构文本，参数值以 ? 代替，见 clause.Shape
+func (q *Query) Shape() string {
+	return canonicalQuery(q.canonicalParts(), true)
+}
+
+// Fingerprint 返回查询规范文本的指纹，与 AND 条件的顺序和 map 的遍历顺序无关
+func (q *Query) Fingerprint() string {
+	return clause.Fingerprint(q.Canonical())
+}
+
+// ShapeFingerprint 返回查询结构文本的指纹，与参数值无关，可用于按查询结构统计指标
+func (q *Query) ShapeFingerprint() string {
+	return clause.Fingerprint(q.Shape())
+}
+
+func (q *Query) canonicalParts() canonicalParts {
+	return canonicalParts{
+		table:      q.table,
+		joins:      q.joins.Value,
+		where:      q.where.Value,
+		orderBys:   q.orderbys.Value,
+		pagination: q.pagination.Value,
+	}
+}
+
+// Canonical 返回 SELECT 查询的规范文本，见 Query.Canonical
+func (q *SelectQuery) Canonical() string {
+	return canonicalQuery(q.canonicalParts(), false)
+}
+
+// Shape 返回 SELECT 查询的结构文本，参数值以 ? 代替
+func (q *SelectQuery) Shape() string {
+	return canonicalQuery(q.canonicalParts(), true)
+}
+
+// Fingerprint 返回 SELECT 查询规范文本的指纹
+func (q *SelectQuery) Fingerprint() string {
+	return clause.Fingerprint(q.Canonical())
+}
+
+// ShapeFingerprint 返回 SELECT 查询结构文本的指纹，与参数值无关
+func (q *SelectQuery) ShapeFingerprint() string {
+	return clause.Fingerprint(q.Shape())
+}
+
+func (q *SelectQuery) canonicalParts() canonicalParts {
+	fields := q.fields
+	if len(fields) == 0 {
+		fields = []clause.Expression{clause.Column{Name: "*"}}
+	}
+	return canonicalParts{
+		fields:     fields,
+		table:      q.table,
+		joins:      q.joins.Value,
+		where:      q.where.Value,
+		groupBy:    q.GroupByExpr(),
+		orderBys:   q.orderbys.Value,
+		pagination: q.pagination.Value,
+	}
+}
+
+// canonicalParts 为生成规范文本所需的查询组成部分
+type canonicalParts struct {
+	fields     []clause.Expression
+	table      string
+	joins      clause.Joins
+	where      clause.Where
+	groupBy    clause.GroupBy
+	orderBys   clause.OrderBys
+	pagination clause.Pagination
+}
+
+// canonicalQuery 按 SQL 子句的顺序生成规范文本，各子句之间以空格分隔；
+// 字段、JOIN、GROUP BY 和 ORDER BY 的顺序影响结果，保持原样
+func canonicalQuery(p canonicalParts, shape bool) string {
+	text := clause.Canonical
+	if shape {
+		text = clause.Shape
+	}
+
+	var parts []string
+	if len(p.fields) > 0 {
+		fields := make([]string, len(p.fields))
+		for i, f := range p.fields {
+			fields[i] = text(f)
+		}
+		parts = append(parts, "SELECT "+strings.Join(fields, ", "))
+	}
+
+	if p.table != "" {
+		parts = append(parts, "FROM "+text(parseTable(p.table)))
+	}
+
+	for _, j := range p.joins {
+		join := string(j.Type) + " JOIN " + text(j.Table)
+		if j.Type != clause.CrossJoin && len(j.On.Exprs) > 0 {
+			join += " ON " + text(j.On)
+		}
+		parts = append(parts, join)
+	}
+
+	// 键集分页条件与 WHERE 条件以 AND 组合
+	where := clause.Where{Exprs: p.where.Exprs}
+	if keyset := p.pagination.Keyset(p.orderBys); keyset != nil {
+		where = clause.Where{Exprs: append(append([]clause.Expression{}, p.where.Exprs...), keyset)}
+	}
+	if len(where.Exprs) > 0 {
+		parts = append(parts, "WHERE "+text(where))
+	}
+
+	if len(p.groupBy.Columns) > 0 {
+		parts = append(parts, "GROUP BY "+strings.Join(p.groupBy.Columns, ", "))
+		if len(p.groupBy.Having.Exprs) > 0 {
+			parts = append(parts, "HAVING "+text(p.groupBy.Having))
+		}
+	}
+
+	if len(p.orderBys) > 0 {
+		orders := make([]string, 0, len(p.orderBys))
+		for _, o := range p.orderBys {
+			if o == nil {
+				continue
+			}
+			order := o.Column
+			if o.Expr != nil {
+				order = text(o.Expr)
+			}
+			if o.Desc {
+				order += " DESC"
+			}
+			orders = append(orders, order)
+		}
+		parts = append(parts, "ORDER BY "+strings.Join(orders, ", "))
+	}
+
+	if pagination := strings.TrimSpace(text(clause.Pagination{Limit: p.pagination.Limit, Offset: p.pagination.Offset})); pagination != "" {
+		parts = append(parts, pagination)
+	}
+
+	return strings.Join(parts, " ")
+}
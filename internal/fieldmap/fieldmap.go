@@ -0,0 +1,189 @@
+// Package fieldmap 解析结构体字段与数据库列名之间的映射关系。
+//
+// 列名按以下优先级从结构体标签中读取：
+//   - db:"name,omitempty"
+//   - gorm:"column:name"
+//   - json:"name,omitempty"
+//
+// 未设置标签时使用字段名的蛇形命名（如 CreatedAt -> created_at）。
+// 标签值为 "-" 的字段会被忽略；匿名嵌入的结构体字段会被展开（实现了 driver.Valuer 的除外）。
+package fieldmap
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Field 描述一个映射到数据库列的结构体字段。
+type Field struct {
+	Name      string // 结构体字段名
+	Column    string // 数据库列名
+	Index     []int  // 用于 reflect.Value.FieldByIndex 的索引路径
+	OmitEmpty bool   // 标签中声明了 omitempty
+}
+
+// Struct 描述一个结构体类型的全部列映射。
+type Struct struct {
+	Fields []*Field
+
+	byColumn map[string]*Field
+	byLower  map[string]*Field
+}
+
+// Lookup 根据列名查找字段，优先精确匹配，其次忽略大小写匹配。
+func (s *Struct) Lookup(column string) (*Field, bool) {
+	if f, ok := s.byColumn[column]; ok {
+		return f, true
+	}
+	f, ok := s.byLower[strings.ToLower(column)]
+	return f, ok
+}
+
+var cache sync.Map // map[reflect.Type]*Struct
+
+// Of 返回结构体类型的列映射，结果会被缓存。
+// t 可以是结构体或指向结构体的指针类型。
+func Of(t reflect.Type) *Struct {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if s, ok := cache.Load(t); ok {
+		return s.(*Struct)
+	}
+
+	s := &Struct{
+		byColumn: make(map[string]*Field),
+		byLower:  make(map[string]*Field),
+	}
+	collect(s, t, nil)
+
+	actual, _ := cache.LoadOrStore(t, s)
+	return actual.(*Struct)
+}
+
+func collect(s *Struct, t reflect.Type, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		column, omitEmpty, tagged := parseTag(sf)
+		if column == "-" {
+			continue
+		}
+
+		idx := make([]int, len(index)+1)
+		copy(idx, index)
+		idx[len(index)] = i
+
+		// 未设置列名的匿名结构体字段展开处理，实现了 driver.Valuer 的结构体作为单列处理
+		if sf.Anonymous && !tagged && sf.Type.Kind() == reflect.Struct && !isValuer(sf.Type) {
+			collect(s, sf.Type, idx)
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		if column == "" {
+			column = SnakeCase(sf.Name)
+		}
+
+		f := &Field{
+			Name:      sf.Name,
+			Column:    column,
+			Index:     idx,
+			OmitEmpty: omitEmpty,
+		}
+
+		// 外层字段优先于嵌入结构体中的同名字段
+		if exist, ok := s.byColumn[column]; ok {
+			if len(exist.Index) > len(f.Index) {
+				*exist = *f
+			}
+			continue
+		}
+
+		s.Fields = append(s.Fields, f)
+		s.byColumn[column] = f
+		if _, ok := s.byLower[strings.ToLower(column)]; !ok {
+			s.byLower[strings.ToLower(column)] = f
+		}
+	}
+}
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+func isValuer(t reflect.Type) bool {
+	return t.Implements(valuerType) || reflect.PtrTo(t).Implements(valuerType)
+}
+
+// parseTag 解析字段标签，返回列名、是否声明 omitempty 以及是否显式设置了列名。
+func parseTag(sf reflect.StructField) (column string, omitEmpty bool, tagged bool) {
+	if tag, ok := sf.Tag.Lookup("db"); ok {
+		column, omitEmpty = splitTag(tag)
+		return column, omitEmpty, column != ""
+	}
+
+	if tag, ok := sf.Tag.Lookup("gorm"); ok {
+		for _, setting := range strings.Split(tag, ";") {
+			setting = strings.TrimSpace(setting)
+			if setting == "-" || strings.HasPrefix(setting, "-:") {
+				return "-", false, true
+			}
+			if strings.HasPrefix(strings.ToLower(setting), "column:") {
+				column = strings.TrimSpace(setting[len("column:"):])
+				return column, false, column != ""
+			}
+		}
+	}
+
+	if tag, ok := sf.Tag.Lookup("json"); ok {
+		column, omitEmpty = splitTag(tag)
+		return column, omitEmpty, column != ""
+	}
+
+	return "", false, false
+}
+
+func splitTag(tag string) (name string, omitEmpty bool) {
+	parts := strings.Split(tag, ",")
+	for _, opt := range parts[1:] {
+		if strings.TrimSpace(opt) == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return strings.TrimSpace(parts[0]), omitEmpty
+}
+
+// SnakeCase 将驼峰命名转换为蛇形命名，连续的大写字母视为一个单词。
+//
+// 示例：
+//   - CreatedAt -> created_at
+//   - UserID    -> user_id
+//   - HTTPCode  -> http_code
+func SnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	b.Grow(len(name) + 4)
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				prev := runes[i-1]
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+					b.WriteByte('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,114 @@
+package fieldmap
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+// TestSnakeCase 测试驼峰命名转换为蛇形命名
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Name":      "name",
+		"CreatedAt": "created_at",
+		"UserID":    "user_id",
+		"HTTPCode":  "http_code",
+		"Address2":  "address2",
+		"ID":        "id",
+	}
+
+	for input, expected := range tests {
+		if got := SnakeCase(input); got != expected {
+			t.Errorf("SnakeCase(%q): expected %q, got %q", input, expected, got)
+		}
+	}
+}
+
+// TestOf 测试结构体字段与列名的映射
+func TestOf(t *testing.T) {
+	type Model struct {
+		ID   int
+		Name string `db:"model_name"`
+	}
+
+	type User struct {
+		Model
+		Name     string `db:"name,omitempty"`
+		Age      int    `gorm:"column:user_age;not null"`
+		Email    string `json:"email"`
+		Skip     string `db:"-"`
+		GormSkip string `gorm:"-"`
+		Meta     Model  `db:"meta"`
+		private  string
+	}
+
+	s := Of(reflect.TypeOf(&User{}))
+
+	var columns []string
+	for _, f := range s.Fields {
+		columns = append(columns, f.Column)
+	}
+
+	expected := []string{"id", "model_name", "name", "user_age", "email", "meta"}
+	if !reflect.DeepEqual(columns, expected) {
+		t.Errorf("expected columns: %v, got: %v", expected, columns)
+	}
+
+	f, ok := s.Lookup("NAME")
+	if !ok || f.Name != "Name" || !f.OmitEmpty || !reflect.DeepEqual(f.Index, []int{1}) {
+		t.Errorf("unexpected field for NAME: %+v", f)
+	}
+
+	f, ok = s.Lookup("id")
+	if !ok || !reflect.DeepEqual(f.Index, []int{0, 0}) {
+		t.Errorf("unexpected field for id: %+v", f)
+	}
+
+	if _, ok := s.Lookup("skip"); ok {
+		t.Error("expected skipped field not to be mapped")
+	}
+}
+
+// TestOf_Shadowing 测试外层字段覆盖嵌入结构体中的同名列
+func TestOf_Shadowing(t *testing.T) {
+	type Base struct {
+		Name string
+	}
+
+	type User struct {
+		Base
+		Name string
+	}
+
+	s := Of(reflect.TypeOf(User{}))
+	if len(s.Fields) != 1 {
+		t.Fatalf("expected 1 field, got %d", len(s.Fields))
+	}
+
+	f, _ := s.Lookup("name")
+	if !reflect.DeepEqual(f.Index, []int{1}) {
+		t.Errorf("expected outer field to win, got index %v", f.Index)
+	}
+}
+
+type Money struct{ Cents int64 }
+
+func (m Money) Value() (driver.Value, error) { return m.Cents, nil }
+
+// TestOf_Valuer 测试实现了 driver.Valuer 的嵌入结构体作为单列处理
+func TestOf_Valuer(t *testing.T) {
+	type Order struct {
+		Money
+		Name string
+	}
+
+	s := Of(reflect.TypeOf(Order{}))
+	if _, ok := s.Lookup("cents"); ok {
+		t.Error("expected embedded valuer not to be expanded")
+	}
+
+	f, ok := s.Lookup("money")
+	if !ok || !reflect.DeepEqual(f.Index, []int{0}) {
+		t.Errorf("expected embedded valuer to be mapped as a column, got %+v", f)
+	}
+}
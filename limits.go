@@ -0,0 +1,176 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/epkgs/query/clause"
+)
+
+// ErrLimitExceeded 表示查询超出了 Limits 设置的复杂度限制。
+var ErrLimitExceeded = errors.New("query limit exceeded")
+
+// 复杂度限制的名称，用于 LimitError.Limit
+const (
+	LimitDepth            = "depth"
+	LimitPredicates       = "predicates"
+	LimitInValues         = "in_values"
+	LimitLeadingWildcards = "leading_wildcards"
+	LimitPageSize         = "page_size"
+	LimitOrderBys         = "order_bys"
+)
+
+// LimitError 表示查询超出了某项复杂度限制，可通过 errors.Is(err, ErrLimitExceeded) 判断，
+// 通常映射为 HTTP 400 / gRPC InvalidArgument。
+type LimitError struct {
+	Limit  string // 超出的限制，如 LimitDepth
+	Field  string // 相关的字段（IN 列表、LIKE 条件），其他限制为空
+	Max    int    // 允许的最大值
+	Actual int    // 实际值
+}
+
+func (e *LimitError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("%v: %s of field %q is %d, max %d", ErrLimitExceeded, e.Limit, e.Field, e.Actual, e.Max)
+	}
+	return fmt.Sprintf("%v: %s is %d, max %d", ErrLimitExceeded, e.Limit, e.Actual, e.Max)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// Limits 描述查询的复杂度限制，用于保护数据库免受来自客户端的复杂查询（如任意 AIP 过滤）的影响。
+// 各项为 0 时表示不限制。
+//
+// 示例:
+//
+//	limits := query.Limits{MaxDepth: 4, MaxPredicates: 20, MaxInValues: 100, MaxLeadingWildcards: 1, MaxPageSize: 100}
+//	if err := limits.Where(whereClause); err != nil {
+//	    var limitErr *query.LimitError
+//	    errors.As(err, &limitErr) // 400 Bad Request
+//	}
+type Limits struct {
+	// MaxDepth 为条件的最大嵌套深度。顶层条件深度为 1，每嵌套一层 AND/OR/NOT 加 1
+	MaxDepth int
+	// MaxPredicates 为叶子条件（比较条件、原生 SQL 等）的最大数量
+	MaxPredicates int
+	// MaxInValues 为单个 IN/NOT IN 列表的最大长度
+	MaxInValues int
+	// MaxLeadingWildcards 为以通配符开头的 LIKE 条件（含 EndsWith、Contains）的最大数量
+	MaxLeadingWildcards int
+	// MaxPageSize 为每页的最大条数，未设置 Limit 时不检查
+	MaxPageSize int
+	// MaxOrderBys 为排序字段的最大数量
+	MaxOrderBys int
+}
+
+// Where 检查 WHERE 条件是否超出限制，超出时返回 *LimitError
+func (l Limits) Where(where clause.Where) error {
+	c := &limitCounter{limits: l}
+	return c.check(where.Exprs, 1)
+}
+
+// OrderBys 检查排序条件是否超出限制，超出时返回 *LimitError
+func (l Limits) OrderBys(orders clause.OrderBys) error {
+	if l.MaxOrderBys > 0 && len(orders) > l.MaxOrderBys {
+		return &LimitError{Limit: LimitOrderBys, Max: l.MaxOrderBys, Actual: len(orders)}
+	}
+	return nil
+}
+
+// Pagination 检查分页是否超出限制，超出时返回 *LimitError
+func (l Limits) Pagination(p clause.Pagination) error {
+	if l.MaxPageSize > 0 && p.Limit != nil && *p.Limit > l.MaxPageSize {
+		return &LimitError{Limit: LimitPageSize, Max: l.MaxPageSize, Actual: *p.Limit}
+	}
+	return nil
+}
+
+// Check 检查 Query 的 WHERE 条件、排序和分页是否超出限制
+func (l Limits) Check(q *Query) error {
+	if err := l.Where(q.where.Value); err != nil {
+		return err
+	}
+	if err := l.OrderBys(q.orderbys.Value); err != nil {
+		return err
+	}
+	return l.Pagination(q.pagination.Value)
+}
+
+// limitCounter 遍历条件时累计计数
+type limitCounter struct {
+	limits     Limits
+	predicates int
+	wildcards  int
+}
+
+func (c *limitCounter) check(exprs []clause.Expression, depth int) error {
+	for _, expr := range exprs {
+		if expr == nil {
+			continue
+		}
+
+		if c.limits.MaxDepth > 0 && depth > c.limits.MaxDepth {
+			return &LimitError{Limit: LimitDepth, Max: c.limits.MaxDepth, Actual: depth}
+		}
+
+		if logical, ok := expr.(clause.LogicalExpression); ok {
+			if err := c.check(logical.SubExprs(), depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+
+		c.predicates++
+		if c.limits.MaxPredicates > 0 && c.predicates > c.limits.MaxPredicates {
+			return &LimitError{Limit: LimitPredicates, Max: c.limits.MaxPredicates, Actual: c.predicates}
+		}
+
+		cmp, ok := expr.(clause.ComparisonExpression)
+		if !ok {
+			continue
+		}
+
+		if n := inValues(cmp); c.limits.MaxInValues > 0 && n > c.limits.MaxInValues {
+			return &LimitError{Limit: LimitInValues, Field: cmp.Column(), Max: c.limits.MaxInValues, Actual: n}
+		}
+
+		if leadingWildcard(cmp) {
+			c.wildcards++
+			if c.limits.MaxLeadingWildcards > 0 && c.wildcards > c.limits.MaxLeadingWildcards {
+				return &LimitError{Limit: LimitLeadingWildcards, Field: cmp.Column(), Max: c.limits.MaxLeadingWildcards, Actual: c.wildcards}
+			}
+		}
+	}
+	return nil
+}
+
+// inValues 返回 IN 列表的长度，Eq/Neq 的切片值同样按 IN 处理
+func inValues(cmp clause.ComparisonExpression) int {
+	switch cmp.Operator() {
+	case clause.OpIN, clause.OpNOTIN, clause.OpEQ, clause.OpNEQ:
+	default:
+		return 0
+	}
+
+	rv := reflect.ValueOf(cmp.Value())
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return 0
+	}
+	return rv.Len()
+}
+
+// leadingWildcard 判断 LIKE 条件是否以通配符开头，此类条件无法使用索引
+func leadingWildcard(cmp clause.ComparisonExpression) bool {
+	switch cmp.Operator() {
+	case clause.OpENDSWITH, clause.OpCONTAINS:
+		return true
+	case clause.OpLIKE, clause.OpNOTLIKE, clause.OpILIKE:
+		pattern, ok := cmp.Value().(string)
+		return ok && (strings.HasPrefix(pattern, "%") || strings.HasPrefix(pattern, "_"))
+	}
+	return false
+}
@@ -46,10 +46,14 @@ var (
 	ErrInvalidOperator     = errors.New("invalid operator")
 	ErrInvalidCondition    = errors.New("invalid condition")
 	ErrInvalidInsertValues = errors.New("invalid insert values")
+	ErrInvalidUpdateValues = errors.New("invalid update values")
 	ErrInvalidOrderBy      = errors.New("invalid order by")
+	// ErrJoinNotAllowed 表示 UPDATE/DELETE 查询中包含 JOIN，各数据库的多表更新/删除语法不通用，不予支持
+	ErrJoinNotAllowed = errors.New("join not allowed in update or delete")
 )
 
 var _ genericWherer[*Query] = (*Query)(nil)
+var _ genericJoiner[*Query] = (*Query)(nil)
 
 // Query 是查询构建器的基础结构体。
 // 通过 Table() 函数创建实例，然后调用链式方法构建 WHERE 条件、
@@ -58,6 +62,7 @@ type Query struct {
 	table string
 
 	errorRecord
+	*joins[*Query]
 	*where[*Query]
 	*orderbys[*Query]
 	*pagination[*Query]
@@ -68,6 +73,10 @@ func newQuery(tableName string) *Query {
 		table: tableName,
 	}
 
+	q.joins = &joins[*Query]{
+		Parent: q,
+	}
+
 	q.where = &where[*Query]{
 		Parent: q,
 		Value:  clause.Where{},
@@ -179,16 +188,36 @@ func Paginate(page, pageSize int) *Query {
 }
 
 // Table 设置查询的表名
+// 表名可带别名，如 "users u" 或 "users AS u"，别名仅在 SELECT 查询中生效
 func (q *Query) Table(tableName string) *Query {
 	q.table = tableName
 	return q
 }
 
+// TableExpr 返回当前的表名表达式，包含别名
+func (q *Query) TableExpr() clause.Table {
+	return parseTable(q.table)
+}
+
+// Cursor 根据排序字段从行数据（map 或结构体）中取出键值，生成下一页的游标。
+// 游标可通过 After 方法用于键集分页，适配器的 QueryScope/Query 会将其转换为 WHERE 条件。
+//
+// 示例:
+//
+//	q := query.Table("users").Desc("created_at").Asc("id").Limit(20)
+//	token, err := q.Cursor(users[len(users)-1])
+//	// 下一页
+//	q.After(token)
+func (q *Query) Cursor(row any) (string, error) {
+	return cursorOf(q.orderbys.Value, row)
+}
+
 // Select 将查询转换为 SELECT 查询并指定要查询的字段。
-// 此方法将 *Query 转换为 *SelectQuery，继承当前查询的 WHERE 条件、
+// 此方法将 *Query 转换为 *SelectQuery，继承当前查询的 JOIN、WHERE 条件、
 // ORDER BY 排序和分页参数。
 //
 // 如果不指定字段，将默认选择所有字段 (*)。
+// 字段可带别名，如 "name AS n"；聚合表达式请使用 SelectQuery.SelectExpr。
 //
 // 示例:
 //
@@ -198,8 +227,11 @@ func (q *Query) Select(fields ...string) *SelectQuery {
 	sq := &SelectQuery{
 		table:       q.table,
 		errorRecord: q.errorRecord,
+	}
 
-		fields: fields,
+	sq.joins = &joins[*SelectQuery]{
+		Parent: sq,
+		Value:  q.joins.Value,
 	}
 
 	sq.where = &where[*SelectQuery]{
@@ -216,7 +248,13 @@ func (q *Query) Select(fields ...string) *SelectQuery {
 		Parent: sq,
 		Value:  q.orderbys.Value,
 	}
-	return sq
+
+	sq.having = &where[*SelectQuery]{
+		Parent: sq,
+		Value:  clause.Where{},
+	}
+
+	return sq.Select(fields...)
 }
 
 // Insert 设置INSERT查询的插入值
@@ -230,6 +268,11 @@ func (q *Query) Insert(field any, args ...any) *InsertQuery {
 		errorRecord: q.errorRecord,
 		values:      make([]map[string]any, 0),
 	}
+	query.returning = &returning[*InsertQuery]{
+		Parent: query,
+		Value:  clause.Returning{Table: "INSERTED"},
+	}
+	query.columnFilter = &columnFilter[*InsertQuery]{Parent: query}
 	return query.Insert(field, args...)
 }
 
@@ -237,12 +280,17 @@ func (q *Query) Insert(field any, args ...any) *InsertQuery {
 // 支持两种调用方式：
 // 1. Update("field", value) - 设置单个字段值
 // 2. Update(map[string]any) - 设置多个字段值
+//
+// 查询中包含 JOIN 时记录 ErrJoinNotAllowed 错误。
 func (q *Query) Update(column any, value ...any) *UpdateQuery {
 	query := &UpdateQuery{
 		table:       q.table,
 		errorRecord: q.errorRecord,
 		values:      make(map[string]any),
 	}
+	if len(q.joins.Value) > 0 && query.Error == nil {
+		query.Error = ErrJoinNotAllowed
+	}
 
 	query.where = &where[*UpdateQuery]{
 		Parent: query,
@@ -254,20 +302,42 @@ func (q *Query) Update(column any, value ...any) *UpdateQuery {
 		Value:  q.pagination.Value,
 	}
 
+	query.returning = &returning[*UpdateQuery]{
+		Parent: query,
+		Value:  clause.Returning{Table: "INSERTED"},
+	}
+
+	query.columnFilter = &columnFilter[*UpdateQuery]{Parent: query}
+
 	return query.Update(column, value...)
 }
 
 // Delete 将查询转换为DELETE查询
+//
+// 查询中包含 JOIN 时记录 ErrJoinNotAllowed 错误。
 func (q *Query) Delete() *DeleteQuery {
 	query := &DeleteQuery{
 		table:       q.table,
 		errorRecord: q.errorRecord,
 	}
+	if len(q.joins.Value) > 0 && query.Error == nil {
+		query.Error = ErrJoinNotAllowed
+	}
 
 	query.where = &where[*DeleteQuery]{
 		Parent: query,
 		Value:  q.where.Value,
 	}
 
+	query.pagination = &pagination[*DeleteQuery]{
+		Parent: query,
+		Value:  q.pagination.Value,
+	}
+
+	query.returning = &returning[*DeleteQuery]{
+		Parent: query,
+		Value:  clause.Returning{Table: "DELETED"},
+	}
+
 	return query
 }
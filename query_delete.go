@@ -3,19 +3,39 @@ package query
 import "github.com/epkgs/query/clause"
 
 // DeleteQuery 是 DELETE 查询结构体。
-// 包含表名和 WHERE 条件，通过 Build 方法将完整的 DELETE 语句写入 Builder。
+// 包含表名、WHERE 条件、行数限制和 RETURNING 子句，通过 Build 方法将完整的 DELETE 语句写入 Builder。
 type DeleteQuery struct {
 	table string
 	errorRecord
 	*where[*DeleteQuery]
+	*pagination[*DeleteQuery]
+	*returning[*DeleteQuery]
+}
+
+// TableExpr 返回当前的表名表达式
+func (q *DeleteQuery) TableExpr() clause.Table {
+	return parseTable(q.table)
 }
 
 // Build 构建DELETE查询的SQL语句
 func (q *DeleteQuery) Build(builder clause.Builder) {
+	if q.Error != nil {
+		builder.AddError(q.Error)
+	}
+
 	// 构建 DELETE 部分
 	builder.WriteString("DELETE FROM ")
-	builder.WriteQuoted(q.table)
+	q.TableExpr().BuildTarget(builder)
+
+	// 构建 OUTPUT 部分（SQL Server）
+	q.returning.Output(builder)
 
 	// 构建 WHERE 部分
 	q.where.Build(builder)
+
+	// 构建行数限制部分，只有部分方言支持
+	q.pagination.Value.BuildRowLimit(builder)
+
+	// 构建 RETURNING 部分
+	q.returning.Build(builder)
 }
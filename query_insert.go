@@ -1,42 +1,46 @@
 package query
 
-import "github.com/epkgs/query/clause"
+import (
+	"reflect"
+	"sort"
+
+	"github.com/epkgs/query/clause"
+)
 
 // InsertQuery 是 INSERT 查询结构体。
-// 包含表名和要插入的数据行，通过 Build 方法可将查询写入 Builder。
+// 包含表名、要插入的数据行、冲突处理和 RETURNING 子句，通过 Build 方法可将查询写入 Builder。
 type InsertQuery struct {
 	table string
 	errorRecord
+	*returning[*InsertQuery]
+	*columnFilter[*InsertQuery]
 
-	values []map[string]any
+	values   []map[string]any
+	conflict clause.OnConflict
 }
 
 // Insert 设置INSERT查询的插入值
-// 支持三种调用方式：
+// 支持以下调用方式：
 // 1. Insert("field", value) - 设置单行单个字段值
 // 2. Insert(map[string]any) - 插入单行数据
 // 3. Insert(map1, map2) - 插入多行数据
+// 4. Insert(&user) / Insert(user1, user2) - 以结构体插入，列名按 db、gorm（column:）、json 标签映射
+// 5. Insert([]User{...}) / Insert([]map[string]any{...}) - 以切片批量插入
+//
+// 结构体中声明了 omitempty 的字段为空值时会被忽略；不支持的行类型会记录 ErrInvalidInsertValues 错误。
+//
+// 多行插入时列为各行字段的并集，某行缺少的列（包括 omitempty 忽略的空值字段）写为 NULL，
+// 会覆盖该列的 DEFAULT。依赖列默认值时，请按字段集合分组分别插入，或用 Omit 排除该列。
 func (q *InsertQuery) Insert(field any, args ...any) *InsertQuery {
 
-	// 处理 Insert(map[string]any) 或 Insert(map1, map2) 形式
-	if m, ok := field.(map[string]any); ok {
-		q.values = append(q.values, m)
-		// 检查是否是多行插入
-		if len(args) > 0 {
-			// Insert(map1, map2) 形式
-			for _, arg := range args {
-				if row, ok := arg.(map[string]any); ok {
-					q.values = append(q.values, row)
-				}
-			}
+	// 处理 Insert("field", value) 形式
+	if _, ok := field.(string); ok {
+		allArgs := append([]any{field}, args...)
+		if len(allArgs)%2 != 0 {
+			q.Error = ErrInvalidInsertValues
+			return q
 		}
-		return q
-	}
-
-	allArgs := append([]any{field}, args...)
 
-	// 处理 Insert("field", value) 形式
-	if len(allArgs)%2 == 0 {
 		row := make(map[string]any)
 		for i := 0; i < len(allArgs); i += 2 {
 			if field, ok := allArgs[i].(string); ok {
@@ -46,18 +50,142 @@ func (q *InsertQuery) Insert(field any, args ...any) *InsertQuery {
 		if len(row) > 0 {
 			q.values = append(q.values, row)
 		}
-	} else {
-		q.Error = ErrInvalidInsertValues
+		return q
+	}
+
+	// 处理 map、结构体及其切片形式
+	for _, arg := range append([]any{field}, args...) {
+		if !q.addRows(arg) {
+			q.Error = ErrInvalidInsertValues
+			break
+		}
 	}
 
 	return q
 }
 
+// addRows 添加单行或切片形式的多行数据
+func (q *InsertQuery) addRows(arg any) bool {
+	rv := reflect.ValueOf(arg)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		for i := 0; i < rv.Len(); i++ {
+			row, ok := rowValues(rv.Index(i).Interface())
+			if !ok {
+				return false
+			}
+			q.values = append(q.values, row)
+		}
+		return true
+	}
+
+	row, ok := rowValues(arg)
+	if !ok {
+		return false
+	}
+	q.values = append(q.values, row)
+	return true
+}
+
+// TableExpr 返回当前的表名表达式
+func (q *InsertQuery) TableExpr() clause.Table {
+	return parseTable(q.table)
+}
+
+// ValuesExpr 返回要插入的数据行，各行的字段已按 Omit/Only 过滤
+func (q *InsertQuery) ValuesExpr() []map[string]any {
+	rows := make([]map[string]any, 0, len(q.values))
+	for _, row := range q.values {
+		values := make(map[string]any, len(row))
+		for field, value := range row {
+			if q.columnFilter.allowed(field) {
+				values[field] = value
+			}
+		}
+		rows = append(rows, values)
+	}
+	return rows
+}
+
+// OnConflict 设置冲突目标列（唯一约束），需继续调用 DoNothing 或 DoUpdate 设置冲突处理动作。
+// MySQL 的冲突目标由表的唯一索引决定，冲突列仅用于 DoNothing。
+//
+// 示例:
+//   - q.OnConflict("email").DoNothing()
+//   - q.OnConflict("email").DoUpdate("name", "John")
+//   - q.OnConflict("email").DoUpdateColumns("name", "age") // 使用待插入的新值更新
+func (q *InsertQuery) OnConflict(columns ...string) *ConflictQuery {
+	q.conflict = clause.OnConflict{Columns: columns}
+	return &ConflictQuery{Parent: q}
+}
+
+// OnConflictExpr 返回当前的冲突处理表达式
+func (q *InsertQuery) OnConflictExpr() clause.OnConflict {
+	return q.conflict
+}
+
+// ConflictQuery 是 INSERT 冲突处理构建器，由 InsertQuery.OnConflict 创建。
+type ConflictQuery struct {
+	Parent *InsertQuery
+}
+
+// DoNothing 冲突时忽略待插入的行
+func (c *ConflictQuery) DoNothing() *InsertQuery {
+	c.Parent.conflict.DoNothing = true
+	c.Parent.conflict.DoUpdates = nil
+	return c.Parent
+}
+
+// DoUpdate 冲突时更新已存在的行
+// 支持两种调用方式：
+// 1. DoUpdate("field", value) - 设置单个字段值
+// 2. DoUpdate(map[string]any) - 设置多个字段值，按字段名排序
+//
+// 值可以是 clause.Excluded，引用待插入的新值。
+func (c *ConflictQuery) DoUpdate(column any, value ...any) *InsertQuery {
+	conflict := &c.Parent.conflict
+	conflict.DoNothing = false
+
+	switch v := column.(type) {
+	case string:
+		if len(value) > 0 {
+			conflict.DoUpdates = append(conflict.DoUpdates, clause.Assignment{Column: v, Value: value[0]})
+		}
+	case map[string]any:
+		fields := make([]string, 0, len(v))
+		for field := range v {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			conflict.DoUpdates = append(conflict.DoUpdates, clause.Assignment{Column: field, Value: v[field]})
+		}
+	default:
+		c.Parent.Error = ErrInvalidInsertValues
+	}
+
+	return c.Parent
+}
+
+// DoUpdateColumns 冲突时使用待插入的新值更新指定字段，
+// 生成 column = excluded.column（MySQL 为 column = VALUES(column)）
+func (c *ConflictQuery) DoUpdateColumns(columns ...string) *InsertQuery {
+	conflict := &c.Parent.conflict
+	conflict.DoNothing = false
+	for _, column := range columns {
+		conflict.DoUpdates = append(conflict.DoUpdates, clause.Assignment{Column: column, Value: clause.Excluded{Column: column}})
+	}
+	return c.Parent
+}
+
 // Build 构建INSERT查询的SQL语句
 func (q *InsertQuery) Build(builder clause.Builder) {
+	if q.Error != nil {
+		builder.AddError(q.Error)
+	}
+
 	// 构建 INSERT 部分
 	builder.WriteString("INSERT INTO ")
-	builder.WriteQuoted(q.table)
+	builder.WriteQuoted(parseTable(q.table).Name)
 
 	// 构建 VALUES 部分
 	if len(q.values) > 0 {
@@ -66,7 +194,7 @@ func (q *InsertQuery) Build(builder clause.Builder) {
 		fieldMap := make(map[string]bool)
 		for _, row := range q.values {
 			for field := range row {
-				if !fieldMap[field] {
+				if !fieldMap[field] && q.columnFilter.allowed(field) {
 					fieldMap[field] = true
 					allFields = append(allFields, field)
 				}
@@ -90,7 +218,12 @@ func (q *InsertQuery) Build(builder clause.Builder) {
 			}
 			builder.WriteQuoted(field)
 		}
-		builder.WriteString(") VALUES ")
+		builder.WriteString(")")
+
+		// 构建 OUTPUT 部分（SQL Server）
+		q.returning.Output(builder)
+
+		builder.WriteString(" VALUES ")
 
 		// 写入值
 		for i, row := range q.values {
@@ -112,4 +245,10 @@ func (q *InsertQuery) Build(builder clause.Builder) {
 			builder.WriteString(")")
 		}
 	}
+
+	// 构建 ON CONFLICT 部分
+	q.conflict.Build(builder)
+
+	// 构建 RETURNING 部分
+	q.returning.Build(builder)
 }
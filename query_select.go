@@ -1,28 +1,89 @@
 package query
 
-import "github.com/epkgs/query/clause"
+import (
+	"strings"
+
+	"github.com/epkgs/query/clause"
+)
 
 // SelectQuery 是 SELECT 查询结构体。
-// 继承 WHERE 条件、ORDER BY 排序和分页参数，
+// 继承 JOIN、WHERE 条件、ORDER BY 排序和分页参数，
 // 通过 Build 方法将完整的 SELECT 语句写入 Builder。
 type SelectQuery struct {
 	table string
 	errorRecord
-	fields []string
+	fields  []clause.Expression
+	groupBy []string
 
+	*joins[*SelectQuery]
 	*orderbys[*SelectQuery]
 	*pagination[*SelectQuery]
 	*where[*SelectQuery]
+
+	having *where[*SelectQuery]
 }
 
 // Select 设置SELECT查询的字段
+// 字段可带别名，如 "name AS n"
 func (q *SelectQuery) Select(fields ...string) *SelectQuery {
-	q.fields = append(q.fields, fields...)
+	for _, field := range fields {
+		q.fields = append(q.fields, parseField(field))
+	}
+	return q
+}
+
+// SelectExpr 以表达式形式添加查询字段，用于聚合函数和别名
+//
+// 示例:
+//   - q.SelectExpr(clause.Count("*"))                        // COUNT(*)
+//   - q.SelectExpr(clause.As(clause.Sum("amount"), "total")) // SUM(`amount`) AS `total`
+func (q *SelectQuery) SelectExpr(exprs ...clause.Expression) *SelectQuery {
+	q.fields = append(q.fields, exprs...)
 	return q
 }
 
+// TableExpr 返回当前的表名表达式，包含别名
+func (q *SelectQuery) TableExpr() clause.Table {
+	return parseTable(q.table)
+}
+
+// FieldsExpr 返回当前的查询字段表达式
+func (q *SelectQuery) FieldsExpr() []clause.Expression {
+	return q.fields
+}
+
+// GroupBy 添加 GROUP BY 分组字段
+func (q *SelectQuery) GroupBy(columns ...string) *SelectQuery {
+	q.groupBy = append(q.groupBy, columns...)
+	return q
+}
+
+// GroupByExpr 返回当前的 GROUP BY 表达式，包含 HAVING 条件
+func (q *SelectQuery) GroupByExpr() clause.GroupBy {
+	return clause.GroupBy{Columns: q.groupBy, Having: q.having.Value}
+}
+
+// Having 添加 HAVING 条件，参数格式与 Where 相同。
+// 字段名可以是 SelectExpr 中设置的别名，构建时替换为对应的聚合表达式。
+//
+// 示例:
+//   - q.SelectExpr(clause.As(clause.Count("*"), "cnt")).GroupBy("city").Having("cnt", ">", 10)
+//   - q.Having(clause.Gte{Col: "total", Val: 100})
+func (q *SelectQuery) Having(field any, args ...any) *SelectQuery {
+	return q.having.Where(field, args...)
+}
+
+// HavingExpr 返回当前的 HAVING 条件
+func (q *SelectQuery) HavingExpr() clause.Where {
+	return q.having.Value
+}
+
 // Build 构建SELECT查询的SQL语句
 func (q *SelectQuery) Build(builder clause.Builder) {
+	if q.Error != nil {
+		builder.AddError(q.Error)
+	}
+
 	// 构建 SELECT 部分
 	builder.WriteString("SELECT ")
 	if len(q.fields) > 0 {
@@ -30,7 +91,7 @@ func (q *SelectQuery) Build(builder clause.Builder) {
 			if i > 0 {
 				builder.WriteString(", ")
 			}
-			builder.WriteQuoted(field)
+			field.Build(builder)
 		}
 	} else {
 		builder.WriteString("*")
@@ -39,15 +100,78 @@ func (q *SelectQuery) Build(builder clause.Builder) {
 	// 构建 FROM 部分
 	if q.table != "" {
 		builder.WriteString(" FROM ")
-		builder.WriteQuoted(q.table)
+		parseTable(q.table).Build(builder)
 	}
 
-	// 构建 WHERE 部分
-	q.where.Build(builder)
+	// 构建 JOIN 部分
+	q.joins.Build(builder)
+
+	// 构建 WHERE 部分，键集分页条件与 WHERE 条件以 AND 组合
+	q.whereWithKeyset().Build(builder)
+
+	// 构建 GROUP BY 与 HAVING 部分
+	q.GroupByExpr().Build(&aliasBuilder{
+		Builder: builder,
+		aliases: clause.Aliases(q.fields),
+	})
 
 	// 构建 ORDER BY 部分
 	q.orderbys.Build(builder)
 
 	// 构建 Pagination 部分
-	q.pagination.Build(builder)
+	q.pagination.Value.BuildOrdered(builder, len(q.orderbys.Value) > 0)
+}
+
+// Cursor 根据排序字段从行数据（map 或结构体）中取出键值，生成下一页的游标，
+// 通常传入当前页的最后一行，再通过 After 查询下一页。
+func (q *SelectQuery) Cursor(row any) (string, error) {
+	return cursorOf(q.orderbys.Value, row)
+}
+
+// whereWithKeyset 返回合并了键集分页条件的 WHERE 条件，不修改查询本身的条件
+func (q *SelectQuery) whereWithKeyset() clause.Where {
+	keyset := q.pagination.Value.Keyset(q.orderbys.Value)
+	if keyset == nil {
+		return q.where.Value
+	}
+
+	where := clause.Where{}
+	where.Merge(q.where.Value).Merge(clause.Where{Exprs: []clause.Expression{keyset}})
+	return where
+}
+
+// parseField 解析带别名的字段名
+// 支持以下形式：
+// 1. "name"
+// 2. "name AS n"
+func parseField(field string) clause.Expression {
+	fields := strings.Fields(field)
+	if len(fields) == 3 && strings.EqualFold(fields[1], "as") {
+		return clause.As(clause.Column{Name: fields[0]}, fields[2])
+	}
+	return clause.Column{Name: field}
+}
+
+// aliasBuilder 在写入列名时将别名替换为对应的表达式
+type aliasBuilder struct {
+	clause.Builder
+	aliases map[string]clause.Expression
+}
+
+func (b *aliasBuilder) WriteQuoted(field interface{}) {
+	if name, ok := field.(string); ok {
+		if expr, ok := b.aliases[name]; ok {
+			expr.Build(b.Builder)
+			return
+		}
+	}
+	b.Builder.WriteQuoted(field)
+}
+
+// WriteCondition 在内层 Builder 以自定义格式写入条件时（如 Explain）转发，条件中的列名仍按别名替换
+func (b *aliasBuilder) WriteCondition(leaf clause.Builder, keyword string, exprs []clause.Expression) bool {
+	if cw, ok := b.Builder.(clause.ConditionWriter); ok {
+		return cw.WriteCondition(leaf, keyword, exprs)
+	}
+	return false
 }
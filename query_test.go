@@ -2,8 +2,13 @@ package query
 
 import (
 	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/epkgs/query/clause"
 )
@@ -26,10 +31,12 @@ func (m *mockBuilder) WriteQuoted(field interface{}) {
 
 func (m *mockBuilder) AddVar(writer clause.Writer, vars ...interface{}) {
 	for _, v := range vars {
-		m.vars = append(m.vars, v)
-		if w, ok := writer.(*mockBuilder); ok {
-			w.WriteString(fmt.Sprintf("$%d", len(w.vars)))
+		if expr, ok := v.(clause.Expression); ok {
+			expr.Build(m)
+			continue
 		}
+		m.vars = append(m.vars, v)
+		writer.WriteString(fmt.Sprintf("$%d", len(m.vars)))
 	}
 }
 
@@ -206,6 +213,33 @@ func TestQuery_BuildInsertDifferentFields(t *testing.T) {
 	}
 }
 
+// TestQuery_BuildInsertTableAlias 测试INSERT忽略表别名
+func TestQuery_BuildInsertTableAlias(t *testing.T) {
+	q := Table("users AS u").Insert("name", "John")
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL := "INSERT INTO `users` (`name`) VALUES ($1)"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+}
+
+// TestQuery_BuildInsertOmitemptyBatch 测试批量插入时 omitempty 忽略的字段在其他行设置后写为 NULL
+func TestQuery_BuildInsertOmitemptyBatch(t *testing.T) {
+	q := Table("users").Insert([]testUser{{ID: 1, Name: "John"}, {Name: "Jane"}}).Only("id", "user_name")
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL := "INSERT INTO `users` (`id`, `user_name`) VALUES ($1, $2), ($3, $4)"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+	if fmt.Sprint(builder.vars) != "[1 John <nil> Jane]" {
+		t.Errorf("unexpected vars: %v", builder.vars)
+	}
+}
+
 // TestQuery_BuildInsertWithFieldValuePairs 测试使用字段值对的INSERT操作
 func TestQuery_BuildInsertWithFieldValuePairs(t *testing.T) {
 	q := Table("users").Insert("name", "John", "age", 30)
@@ -1415,3 +1449,830 @@ func TestFluentAPI_MultipleOrderByClauses(t *testing.T) {
 		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
 	}
 }
+
+// TestQuery_BuildSelectWithJoin 测试带JOIN的SELECT查询
+func TestQuery_BuildSelectWithJoin(t *testing.T) {
+	q := Table("users u").
+		Join("orders AS o", On("o.user_id", "u.id"), clause.Gt{Col: "o.amount", Val: 100}).
+		Eq("u.status", "active").
+		Select("u.id", "o.amount")
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL := "SELECT `u.id`, `o.amount` FROM `users` AS `u` INNER JOIN `orders` AS `o` ON `o.user_id` = `u.id` AND `o.amount` > $1 WHERE `u.status` = $2"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	if len(builder.vars) != 2 || builder.vars[0] != 100 || builder.vars[1] != "active" {
+		t.Errorf("expected vars: [100 active], got: %v", builder.vars)
+	}
+}
+
+// TestQuery_BuildSelectWithJoinTypes 测试不同类型的JOIN
+func TestQuery_BuildSelectWithJoinTypes(t *testing.T) {
+	q := Table("users").
+		LeftJoin("profiles", On("profiles.user_id", "users.id")).
+		RightJoin("teams t", On("t.id", "users.team_id")).
+		CrossJoin("regions").
+		Select()
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL := "SELECT * FROM `users` LEFT JOIN `profiles` ON `profiles.user_id` = `users.id` RIGHT JOIN `teams` AS `t` ON `t.id` = `users.team_id` CROSS JOIN `regions`"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+}
+
+// TestQuery_JoinOnSelectQuery 测试在SelectQuery上添加JOIN
+func TestQuery_JoinOnSelectQuery(t *testing.T) {
+	q := Table("users").Select("users.name").
+		Join("orders", clause.Or(On("orders.user_id", "users.id"), On("orders.owner_id", "users.id")))
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL := "SELECT `users.name` FROM `users` INNER JOIN `orders` ON (`orders.user_id` = `users.id` OR `orders.owner_id` = `users.id`)"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	if len(q.JoinExpr()) != 1 || q.JoinExpr()[0].Type != clause.InnerJoin {
+		t.Errorf("expected one INNER JOIN, got: %v", q.JoinExpr())
+	}
+}
+
+// TestQuery_BuildSelectWithGroupBy 测试聚合字段、GROUP BY 与 HAVING
+func TestQuery_BuildSelectWithGroupBy(t *testing.T) {
+	q := Table("orders").
+		Eq("status", "paid").
+		Desc("total").
+		Select("user_id", "city AS c").
+		SelectExpr(clause.Count("*"), clause.As(clause.Sum("amount"), "total")).
+		GroupBy("user_id", "city").
+		Having("total", ">", 100)
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL := "SELECT `user_id`, `city` AS `c`, COUNT(*), SUM(`amount`) AS `total` FROM `orders` WHERE `status` = $1 GROUP BY `user_id`, `city` HAVING SUM(`amount`) > $2 ORDER BY `total` DESC"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	if len(builder.vars) != 2 || builder.vars[0] != "paid" || builder.vars[1] != 100 {
+		t.Errorf("expected vars: [paid 100], got: %v", builder.vars)
+	}
+
+	if len(q.FieldsExpr()) != 4 || len(q.GroupByExpr().Columns) != 2 || len(q.HavingExpr().Exprs) != 1 {
+		t.Errorf("unexpected select expressions: %v, %v", q.FieldsExpr(), q.GroupByExpr())
+	}
+}
+
+// TestQuery_HavingError 测试 HAVING 条件错误记录到查询中
+func TestQuery_HavingError(t *testing.T) {
+	q := Table("orders").Select("user_id").GroupBy("user_id").Having("cnt", "~", 1)
+
+	if q.Error != ErrInvalidOperator {
+		t.Errorf("expected ErrInvalidOperator, got: %v", q.Error)
+	}
+}
+
+// TestQuery_SubQuery 测试子查询作为 IN、比较条件的值
+func TestQuery_SubQuery(t *testing.T) {
+	paid := Table("orders").Eq("status", "paid").Select("user_id")
+	maxAge := Table("users").Eq("city", "London").Select().SelectExpr(clause.Max("age"))
+
+	q := Table("users").
+		Eq("active", true).
+		In("id", paid).
+		Where("age", ">=", maxAge).
+		Select("id")
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL := "SELECT `id` FROM `users` WHERE `active` = $1 AND `id` IN (SELECT `user_id` FROM `orders` WHERE `status` = $2) AND `age` >= (SELECT MAX(`age`) FROM `users` WHERE `city` = $3)"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	if len(builder.vars) != 3 || builder.vars[0] != true || builder.vars[1] != "paid" || builder.vars[2] != "London" {
+		t.Errorf("expected vars: [true paid London], got: %v", builder.vars)
+	}
+}
+
+// TestQuery_Exists 测试 EXISTS / NOT EXISTS 关联子查询
+func TestQuery_Exists(t *testing.T) {
+	orders := Table("orders o").Where(On("o.user_id", "u.id")).Gt("o.amount", 100).Select("o.id")
+	bans := Table("bans b").Where(On("b.user_id", "u.id")).Select("b.id")
+
+	q := Table("users u").Exists(orders).NotExists(bans).Select("u.id")
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL := "SELECT `u.id` FROM `users` AS `u` WHERE EXISTS (SELECT `o.id` FROM `orders` AS `o` WHERE `o.user_id` = `u.id` AND `o.amount` > $1) AND NOT EXISTS (SELECT `b.id` FROM `bans` AS `b` WHERE `b.user_id` = `u.id`)"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	if len(builder.vars) != 1 || builder.vars[0] != 100 {
+		t.Errorf("expected vars: [100], got: %v", builder.vars)
+	}
+}
+
+// TestQuery_SubQueryError 测试子查询的构建错误传递到外层查询
+func TestQuery_SubQueryError(t *testing.T) {
+	inner := Table("orders").Where("amount", "~", 1).Select("user_id")
+	q := Table("users").In("id", inner).Select()
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	if len(builder.errors) != 1 || builder.errors[0] != ErrInvalidOperator {
+		t.Errorf("expected ErrInvalidOperator, got: %v", builder.errors)
+	}
+}
+
+// TestQuery_ExtendedOperators 测试扩展的比较操作符流畅 API
+func TestQuery_ExtendedOperators(t *testing.T) {
+	q := Table("users").
+		Between("age", 18, 30).
+		NotBetween("score", 0, 10).
+		IsNull("deleted_at").
+		IsNotNull("email").
+		NotIn("status", "banned", "locked").
+		NotLike("name", "test%").
+		ILike("city", "lon%").
+		StartsWith("code", "A_").
+		EndsWith("email", "@example.com").
+		Contains("bio", "100%").
+		Select("id")
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL := "SELECT `id` FROM `users` WHERE `age` BETWEEN $1 AND $2 AND `score` NOT BETWEEN $3 AND $4 AND `deleted_at` IS NULL AND `email` IS NOT NULL AND `status` NOT IN ($5$6) AND `name` NOT LIKE $7 AND LOWER(`city`) LIKE LOWER($8) AND `code` LIKE $9 ESCAPE '!' AND `email` LIKE $10 ESCAPE '!' AND `bio` LIKE $11 ESCAPE '!'"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	expectedVars := []any{18, 30, 0, 10, "banned", "locked", "test%", "lon%", "A!_%", "%@example.com", "%100!%%"}
+	if fmt.Sprint(builder.vars) != fmt.Sprint(expectedVars) {
+		t.Errorf("expected vars: %v, got: %v", expectedVars, builder.vars)
+	}
+}
+
+// TestQuery_WhereStringOperators 测试 Where 中的字符串操作符
+func TestQuery_WhereStringOperators(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []any
+		expected string
+	}{
+		{name: "between", args: []any{"age", "between", 18, 30}, expected: " WHERE `age` BETWEEN $1 AND $2"},
+		{name: "between slice", args: []any{"age", "BETWEEN", []int{18, 30}}, expected: " WHERE `age` BETWEEN $1 AND $2"},
+		{name: "not between", args: []any{"age", "Not  Between", 18, 30}, expected: " WHERE `age` NOT BETWEEN $1 AND $2"},
+		{name: "not in", args: []any{"id", "not in", []int{1, 2}}, expected: " WHERE `id` NOT IN ($1$2)"},
+		{name: "not like", args: []any{"name", "NOT LIKE", "a%"}, expected: " WHERE `name` NOT LIKE $1"},
+		{name: "ilike", args: []any{"name", "ilike", "a%"}, expected: " WHERE LOWER(`name`) LIKE LOWER($1)"},
+		{name: "starts with", args: []any{"name", "starts with", "a"}, expected: " WHERE `name` LIKE $1 ESCAPE '!'"},
+		{name: "ends with", args: []any{"name", "endswith", "a"}, expected: " WHERE `name` LIKE $1 ESCAPE '!'"},
+		{name: "contains", args: []any{"name", "contains", "a"}, expected: " WHERE `name` LIKE $1 ESCAPE '!'"},
+		{name: "<>", args: []any{"name", "<>", "a"}, expected: " WHERE `name` <> $1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := Table("users").Where(tt.args[0], tt.args[1:]...)
+			if q.Error != nil {
+				t.Fatalf("unexpected error: %v", q.Error)
+			}
+
+			builder := &mockBuilder{}
+			q.WhereExpr().Build(builder)
+			if builder.String() != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, builder.String())
+			}
+		})
+	}
+
+	q := Table("users").Where("age", "between", 18)
+	if q.Error != ErrInvalidCondition {
+		t.Errorf("expected ErrInvalidCondition, got: %v", q.Error)
+	}
+}
+
+// TestQuery_Raw 测试原生 SQL 表达式用于查询字段、条件和排序
+func TestQuery_Raw(t *testing.T) {
+	q := Table("users").
+		Eq("active", true).
+		Where(clause.Raw("LENGTH(name) > ?", 3)).
+		OrderBy(clause.Raw("FIELD(status, ?, ?)", "vip", "normal"), "desc").
+		OrderBy("id").
+		Select("id").
+		SelectExpr(clause.As(clause.Raw("UPPER(name)"), "upper_name"))
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL := "SELECT `id`, UPPER(name) AS `upper_name` FROM `users` WHERE `active` = $1 AND LENGTH(name) > $2 ORDER BY FIELD(status, $3, $4) DESC, `id` ASC"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	expectedVars := []any{true, 3, "vip", "normal"}
+	if fmt.Sprint(builder.vars) != fmt.Sprint(expectedVars) {
+		t.Errorf("expected vars: %v, got: %v", expectedVars, builder.vars)
+	}
+
+	invalid := Table("users").OrderBy(clause.Raw("RANDOM()"), 1)
+	if invalid.Error != ErrInvalidOrderBy {
+		t.Errorf("expected ErrInvalidOrderBy, got: %v", invalid.Error)
+	}
+}
+
+// TestQuery_BuildInsertOnConflict 测试 INSERT 冲突处理
+func TestQuery_BuildInsertOnConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    *InsertQuery
+		expected string
+		vars     int
+	}{
+		{
+			name:     "do nothing",
+			query:    Table("users").Insert("email", "a@x.com", "name", "A").OnConflict("email").DoNothing(),
+			expected: "INSERT INTO `users` (`email`, `name`) VALUES ($1, $2) ON CONFLICT (`email`) DO NOTHING",
+			vars:     2,
+		},
+		{
+			name:     "do update",
+			query:    Table("users").Insert("email", "a@x.com", "name", "A").OnConflict("email").DoUpdate(map[string]any{"score": 0, "name": clause.Excluded{Column: "name"}}),
+			expected: "INSERT INTO `users` (`email`, `name`) VALUES ($1, $2) ON CONFLICT (`email`) DO UPDATE SET `name` = excluded.`name`, `score` = $3",
+			vars:     3,
+		},
+		{
+			name:     "do update columns",
+			query:    Table("users").Insert("email", "a@x.com", "name", "A").OnConflict("email").DoUpdateColumns("name"),
+			expected: "INSERT INTO `users` (`email`, `name`) VALUES ($1, $2) ON CONFLICT (`email`) DO UPDATE SET `name` = excluded.`name`",
+			vars:     2,
+		},
+		{
+			name:     "without action",
+			query:    func() *InsertQuery { q := Table("users").Insert("email", "a@x.com"); q.OnConflict("email"); return q }(),
+			expected: "INSERT INTO `users` (`email`) VALUES ($1)",
+			vars:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &mockBuilder{}
+			tt.query.Build(builder)
+
+			if builder.String() != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, builder.String())
+			}
+			if len(builder.vars) != tt.vars {
+				t.Errorf("expected %d vars, got: %v", tt.vars, builder.vars)
+			}
+		})
+	}
+
+	q := Table("users").Insert("email", "a@x.com").OnConflict("email").DoUpdate(1)
+	if q.Error != ErrInvalidInsertValues {
+		t.Errorf("expected ErrInvalidInsertValues, got: %v", q.Error)
+	}
+}
+
+// TestQuery_Returning 测试 INSERT、UPDATE、DELETE 的 RETURNING 子句
+func TestQuery_Returning(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    clause.Expression
+		expected string
+	}{
+		{
+			name:     "insert",
+			query:    Table("users").Insert("name", "John").Returning("id", "created_at"),
+			expected: "INSERT INTO `users` (`name`) VALUES ($1) RETURNING `id`, `created_at`",
+		},
+		{
+			name:     "insert on conflict",
+			query:    Table("users").Insert("name", "John").Returning("id").OnConflict("name").DoNothing(),
+			expected: "INSERT INTO `users` (`name`) VALUES ($1) ON CONFLICT (`name`) DO NOTHING RETURNING `id`",
+		},
+		{
+			name:     "update",
+			query:    Table("users").Eq("id", 1).Update("name", "John").Returning("*"),
+			expected: "UPDATE `users` SET `name` = $1 WHERE `id` = $2 RETURNING *",
+		},
+		{
+			name:     "delete",
+			query:    Table("users").Eq("id", 1).Delete().Returning("id"),
+			expected: "DELETE FROM `users` WHERE `id` = $1 RETURNING `id`",
+		},
+		{
+			name:     "without returning",
+			query:    Table("users").Eq("id", 1).Delete(),
+			expected: "DELETE FROM `users` WHERE `id` = $1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &mockBuilder{}
+			tt.query.Build(builder)
+
+			if builder.String() != tt.expected {
+				t.Errorf("expected SQL: %s, got: %s", tt.expected, builder.String())
+			}
+		})
+	}
+}
+
+type testAudit struct {
+	CreatedBy string `db:"created_by,omitempty"`
+}
+
+type testUser struct {
+	ID       int    `db:"id,omitempty"`
+	Name     string `gorm:"column:user_name"`
+	Email    string `json:"email,omitempty"`
+	Age      int
+	Nickname sql.NullString `db:"nickname,omitempty"`
+	Status   testStatus     `db:"status"`
+	Secret   string         `db:"-"`
+	testAudit
+}
+
+// testStatus 以指针接收者实现 driver.Valuer
+type testStatus struct{ code int }
+
+func (s *testStatus) Value() (driver.Value, error) {
+	if s.code == 0 {
+		return nil, nil
+	}
+	return int64(s.code), nil
+}
+
+// TestQuery_BuildInsertStruct 测试以结构体插入
+func TestQuery_BuildInsertStruct(t *testing.T) {
+	q := Table("users").Insert(&testUser{Name: "John", Age: 30, Secret: "x", Status: testStatus{code: 1}})
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL := "INSERT INTO `users` (`age`, `status`, `user_name`) VALUES ($1, $2, $3)"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	if len(builder.vars) != 3 || builder.vars[0] != 30 || builder.vars[2] != "John" {
+		t.Errorf("unexpected vars: %v", builder.vars)
+	}
+	if valuer, ok := builder.vars[1].(driver.Valuer); !ok {
+		t.Errorf("expected driver.Valuer for status, got: %T", builder.vars[1])
+	} else if v, _ := valuer.Value(); v != int64(1) {
+		t.Errorf("expected status value 1, got: %v", v)
+	}
+}
+
+// TestQuery_BuildInsertStructBatch 测试以结构体切片批量插入及 Omit/Only
+func TestQuery_BuildInsertStructBatch(t *testing.T) {
+	users := []testUser{
+		{ID: 1, Name: "John", Email: "john@x.com", Nickname: sql.NullString{String: "J", Valid: true}},
+		{ID: 2, Name: "Jane", testAudit: testAudit{CreatedBy: "admin"}},
+	}
+
+	q := Table("users").Insert(users).Omit("status", "age")
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL := "INSERT INTO `users` (`created_by`, `email`, `id`, `nickname`, `user_name`) VALUES ($1, $2, $3, $4, $5), ($6, $7, $8, $9, $10)"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	q = Table("users").Insert(&users[0], map[string]any{"user_name": "Bob", "age": 20}).Only("user_name")
+	builder = &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL = "INSERT INTO `users` (`user_name`) VALUES ($1), ($2)"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+	if fmt.Sprint(builder.vars) != "[John Bob]" {
+		t.Errorf("unexpected vars: %v", builder.vars)
+	}
+}
+
+// TestQuery_BuildInsertInvalidRow 测试不支持的行类型
+func TestQuery_BuildInsertInvalidRow(t *testing.T) {
+	for _, q := range []*InsertQuery{
+		Table("users").Insert(map[string]any{"name": "John"}, 1),
+		Table("users").Insert([]int{1, 2}),
+		Table("users").Insert((*testUser)(nil)),
+		Table("users").Insert(time.Now()),
+	} {
+		if q.Error != ErrInvalidInsertValues {
+			t.Errorf("expected ErrInvalidInsertValues, got: %v", q.Error)
+		}
+	}
+}
+
+// TestQuery_BuildUpdateStruct 测试以结构体更新及 Omit
+func TestQuery_BuildUpdateStruct(t *testing.T) {
+	q := Table("users").Eq("id", 1).Update(testUser{ID: 1, Name: "John"}).Omit("id", "status")
+	builder := &mockBuilder{}
+	q.Build(builder)
+
+	expectedSQL := "UPDATE `users` SET `age` = $1, `user_name` = $2 WHERE `id` = $3"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	invalid := Table("users").Update(42)
+	if invalid.Error != ErrInvalidUpdateValues {
+		t.Errorf("expected ErrInvalidUpdateValues, got: %v", invalid.Error)
+	}
+}
+
+// TestQuery_TableAndValuesExpr 测试表名和写入值的访问方法
+func TestQuery_TableAndValuesExpr(t *testing.T) {
+	if got := Table("users AS u").Select().TableExpr(); got != (clause.Table{Name: "users", Alias: "u"}) {
+		t.Errorf("expected users AS u, got: %+v", got)
+	}
+	if got := Table("users").Delete().TableExpr(); got != (clause.Table{Name: "users"}) {
+		t.Errorf("expected users, got: %+v", got)
+	}
+
+	insert := Table("users").Insert(map[string]any{"id": 1, "name": "John"}, map[string]any{"name": "Jane"}).Omit("id")
+	expectedRows := []map[string]any{{"name": "John"}, {"name": "Jane"}}
+	if got := insert.ValuesExpr(); !reflect.DeepEqual(got, expectedRows) {
+		t.Errorf("expected rows: %v, got: %v", expectedRows, got)
+	}
+
+	update := Table("users").Update(map[string]any{"id": 1, "name": "John", "age": 30}).Only("name")
+	expectedValues := map[string]any{"name": "John"}
+	if got := update.ValuesExpr(); !reflect.DeepEqual(got, expectedValues) {
+		t.Errorf("expected values: %v, got: %v", expectedValues, got)
+	}
+}
+
+// TestQuery_UpdateDeleteTable 测试 UPDATE/DELETE 的表别名及 JOIN
+func TestQuery_UpdateDeleteTable(t *testing.T) {
+	update := Table("users u").Eq("u.id", 1).Update("name", "John")
+	builder := &mockBuilder{}
+	update.Build(builder)
+
+	expectedSQL := "UPDATE `users` AS `u` SET `name` = $1 WHERE `u.id` = $2"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	del := Table("users AS u").Eq("u.id", 1).Delete()
+	builder = &mockBuilder{}
+	del.Build(builder)
+
+	expectedSQL = "DELETE FROM `users` AS `u` WHERE `u.id` = $1"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	joined := Table("users u").Join("orders o", On("o.user_id", "u.id")).Eq("o.status", "unpaid")
+	if q := joined.Update("status", "banned"); q.Error != ErrJoinNotAllowed {
+		t.Errorf("expected ErrJoinNotAllowed, got: %v", q.Error)
+	}
+	if q := joined.Delete(); q.Error != ErrJoinNotAllowed {
+		t.Errorf("expected ErrJoinNotAllowed, got: %v", q.Error)
+	}
+}
+
+// TestQuery_Keyset 测试键集（游标）分页
+func TestQuery_Keyset(t *testing.T) {
+	q := Table("users").Eq("status", "active").Desc("age").Asc("id").Limit(10)
+
+	cursor, err := q.Cursor(testUser{ID: 100, Name: "John", Age: 18})
+	if err != nil {
+		t.Fatalf("cursor: %v", err)
+	}
+
+	sq := q.After(cursor).Select("id", "name")
+	builder := &mockBuilder{}
+	sq.Build(builder)
+
+	expectedSQL := "SELECT `id`, `name` FROM `users` WHERE `status` = $1 AND (`age` < $2 OR (`age` = $3 AND `id` > $4)) ORDER BY `age` DESC, `id` ASC LIMIT $5"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	expectedVars := []interface{}{"active", int64(18), int64(18), int64(100), 10}
+	if !reflect.DeepEqual(builder.vars, expectedVars) {
+		t.Errorf("expected vars: %v, got: %v", expectedVars, builder.vars)
+	}
+
+	// 构建不应修改查询本身的 WHERE 条件
+	if len(sq.WhereExpr().Exprs) != 1 {
+		t.Errorf("expected where to be unchanged, got: %v", sq.WhereExpr().Exprs)
+	}
+
+	compound := Table("users").Asc("users.age").Asc("id").Select().AfterValues(18, 100)
+	builder = &mockBuilder{}
+	compound.Build(builder)
+
+	expectedSQL = "SELECT * FROM `users` WHERE (`users.age`, `id`) > ($1, $2) ORDER BY `users.age` ASC, `id` ASC"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	if _, err := compound.Cursor(map[string]any{"age": 18, "id": 100}); err != nil {
+		t.Errorf("expected qualified column to match unqualified key, got: %v", err)
+	}
+
+	if _, err := compound.Cursor(map[string]any{"id": 100}); !errors.Is(err, clause.ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor for missing column, got: %v", err)
+	}
+
+	// omitempty 的排序键为零值时同样应写入游标
+	type scoredUser struct {
+		ID    int `json:"id"`
+		Score int `json:"score,omitempty"`
+	}
+	zero := Table("users").Desc("score").Asc("id")
+	cursor, err = zero.Cursor(scoredUser{ID: 7})
+	if err != nil {
+		t.Fatalf("cursor with zero omitempty key: %v", err)
+	}
+	builder = &mockBuilder{}
+	zero.After(cursor).Select().Build(builder)
+
+	expectedVars = []interface{}{int64(0), int64(0), int64(7)}
+	if !reflect.DeepEqual(builder.vars, expectedVars) {
+		t.Errorf("expected vars: %v, got: %v", expectedVars, builder.vars)
+	}
+
+	invalid := Table("users").Asc("id").After("not a cursor")
+	if !errors.Is(invalid.Error, clause.ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor, got: %v", invalid.Error)
+	}
+}
+
+// TestSchema 测试字段白名单、操作符、类型校验及列名改写
+func TestSchema(t *testing.T) {
+	schema := NewSchema(
+		Field{Name: "name", Type: reflect.TypeOf(""), Sortable: true},
+		Field{Name: "age", Column: "user_age", Type: reflect.TypeOf(0),
+			Operators: []clause.Operator{clause.OpEQ, clause.OpGT, clause.OpIN}},
+		Field{Name: "deleted_at", Type: reflect.TypeOf(&time.Time{})},
+		Field{Name: "email"},
+	)
+
+	q := Table("users").
+		Eq("name", "John").
+		Where(clause.Or(clause.Gt{Col: "age", Val: int64(18)}, clause.Eq{Col: "age", Val: 2.5})).
+		IsNull("deleted_at").
+		Desc("name")
+	if err := schema.Apply(q); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	builder := &mockBuilder{}
+	q.Select("id").Build(builder)
+
+	expectedSQL := "SELECT `id` FROM `users` WHERE `name` = $1 AND (`user_age` > $2 OR `user_age` = $3) AND `deleted_at` IS NULL ORDER BY `name` DESC"
+	if builder.String() != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, builder.String())
+	}
+
+	tests := []struct {
+		name  string
+		query *Query
+		field string
+		err   error
+	}{
+		{"unknown field", Table("users").Eq("password", "x"), "password", ErrUnknownField},
+		{"nested unknown field", Table("users").Not(Eq("name", "x").Eq("role", "admin")), "role", ErrUnknownField},
+		{"operator", Table("users").Lt("age", 18), "age", ErrOperatorNotAllowed},
+		{"type", Table("users").Where("age", ">", "18"), "age", ErrInvalidFieldValue},
+		{"type in slice", Table("users").In("age", 1, "2"), "age", ErrInvalidFieldValue},
+		{"not sortable", Table("users").Asc("age"), "age", ErrFieldNotSortable},
+		{"unknown sort field", Table("users").Asc("password"), "password", ErrUnknownField},
+		{"column value", Table("users").Eq("email", clause.Column{Name: "password_hash"}), "email", ErrExpressionNotAllowed},
+		{"column in slice", Table("users").In("email", "a@x.com", clause.Column{Name: "password_hash"}), "email", ErrExpressionNotAllowed},
+		{"sub query", Table("users").In("age", Table("secrets").Select("age")), "age", ErrExpressionNotAllowed},
+	}
+
+	for _, tt := range tests {
+		err := schema.Apply(tt.query)
+
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) || fieldErr.Field != tt.field || !errors.Is(err, tt.err) {
+			t.Errorf("%s: expected %v on field %q, got: %v", tt.name, tt.err, tt.field, err)
+		}
+	}
+
+	raw := Table("users").Where(clause.Raw("1 = 1"))
+	if err := schema.Apply(raw); !errors.Is(err, ErrExpressionNotAllowed) {
+		t.Errorf("expected ErrExpressionNotAllowed, got: %v", err)
+	}
+
+	schema.AllowExpr(func(e clause.Expression) bool {
+		_, ok := e.(clause.Expr)
+		return ok
+	})
+	if err := schema.Apply(raw); err != nil {
+		t.Errorf("expected raw expression to be allowed, got: %v", err)
+	}
+	// 放行的表达式值不按字段类型校验
+	if err := schema.Apply(Table("users").Eq("age", clause.Raw("18"))); err != nil {
+		t.Errorf("expected raw value to be allowed, got: %v", err)
+	}
+}
+
+// TestLimits 测试查询复杂度限制
+func TestLimits(t *testing.T) {
+	limits := Limits{MaxDepth: 2, MaxPredicates: 4, MaxInValues: 3, MaxLeadingWildcards: 1, MaxPageSize: 50, MaxOrderBys: 2}
+
+	ok := Table("users").
+		Eq("status", "active").
+		Where(clause.Or(clause.Eq{Col: "role", Val: "admin"}, clause.IN{Col: "id", Vals: []any{1, 2, 3}})).
+		Contains("name", "jo").
+		Asc("name").
+		Limit(50)
+	if err := limits.Check(ok); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query *Query
+		limit string
+	}{
+		{"depth", Table("users").Where(clause.Or(clause.Eq{Col: "a", Val: 1}, clause.And(clause.Eq{Col: "b", Val: 1}, clause.Eq{Col: "c", Val: 1}))), LimitDepth},
+		{"predicates", Table("users").Eq("a", 1).Eq("b", 1).Eq("c", 1).Eq("d", 1).Eq("e", 1), LimitPredicates},
+		{"in values", Table("users").In("id", 1, 2, 3, 4), LimitInValues},
+		{"eq slice", Table("users").Eq("id", []int{1, 2, 3, 4}), LimitInValues},
+		{"leading wildcards", Table("users").Like("name", "%jo").EndsWith("email", "@x.com"), LimitLeadingWildcards},
+		{"page size", Table("users").Limit(51), LimitPageSize},
+		{"order bys", Table("users").Asc("a").Asc("b").Desc("c"), LimitOrderBys},
+	}
+
+	for _, tt := range tests {
+		err := limits.Check(tt.query)
+
+		var limitErr *LimitError
+		if !errors.As(err, &limitErr) || limitErr.Limit != tt.limit || !errors.Is(err, ErrLimitExceeded) {
+			t.Errorf("%s: expected %s limit error, got: %v", tt.name, tt.limit, err)
+		}
+	}
+
+	if err := (Limits{}).Check(Table("users").Like("a", "%x").Contains("b", "y").Limit(1000)); err != nil {
+		t.Errorf("expected zero limits to be unlimited, got: %v", err)
+	}
+}
+
+// TestQuery_Fingerprint 测试查询的规范文本和指纹
+func TestQuery_Fingerprint(t *testing.T) {
+	q1 := Table("users").Eq("status", "active").Gte("age", 18).Desc("created_at").Limit(10)
+	q2 := Table("users").Gte("age", 18).Eq("status", "active").Desc("created_at").Limit(10)
+
+	expected := `FROM users WHERE age >= 18 AND status = "active" ORDER BY created_at DESC LIMIT 10`
+	if got := q1.Canonical(); got != expected {
+		t.Errorf("expected canonical: %s, got: %s", expected, got)
+	}
+	if q1.Fingerprint() != q2.Fingerprint() {
+		t.Errorf("expected equal fingerprints:\n%s\n%s", q1.Canonical(), q2.Canonical())
+	}
+
+	// 排序和分页参与指纹
+	if q1.Fingerprint() == Table("users").Eq("status", "active").Gte("age", 18).Asc("created_at").Limit(10).Fingerprint() {
+		t.Errorf("expected order direction to change the fingerprint")
+	}
+
+	// 结构指纹与参数值无关
+	q3 := Table("users").Eq("status", "banned").Gte("age", 21).Desc("created_at").Limit(50)
+	if q1.Fingerprint() == q3.Fingerprint() || q1.ShapeFingerprint() != q3.ShapeFingerprint() {
+		t.Errorf("expected equal shapes only:\n%s\n%s", q1.Shape(), q3.Shape())
+	}
+	expectedShape := "FROM users WHERE age >= ? AND status = ? ORDER BY created_at DESC LIMIT ?"
+	if got := q3.Shape(); got != expectedShape {
+		t.Errorf("expected shape: %s, got: %s", expectedShape, got)
+	}
+}
+
+// TestSelectQuery_Fingerprint 测试 SELECT 查询的规范文本
+func TestSelectQuery_Fingerprint(t *testing.T) {
+	q := Table("users u").
+		LeftJoin("orders o", On("o.user_id", "u.id"), clause.Gt{Col: "o.amount", Val: 0}).
+		In("u.id", 3, 1, 2).
+		Desc("u.created_at").
+		Asc("u.id").
+		AfterValues("2024-01-01", 42).
+		Limit(20).
+		Select("u.city").
+		SelectExpr(clause.As(clause.Count("*"), "cnt")).
+		GroupBy("u.city").
+		Having("cnt", ">", 5)
+
+	expected := `SELECT u.city, COUNT(*) AS cnt FROM users AS u LEFT JOIN orders AS o ON o.amount > 0 AND o.user_id = u.id` +
+		` WHERE (u.created_at < "2024-01-01" OR (u.created_at = "2024-01-01" AND u.id > 42)) AND u.id IN (1, 2, 3)` +
+		` GROUP BY u.city HAVING cnt > 5 ORDER BY u.created_at DESC, u.id LIMIT 20`
+	if got := q.Canonical(); got != expected {
+		t.Errorf("expected canonical:\n%s\ngot:\n%s", expected, got)
+	}
+
+	same := Table("users u").
+		LeftJoin("orders o", clause.Gt{Col: "o.amount", Val: 0}, On("o.user_id", "u.id")).
+		In("u.id", 2, 3, 1).
+		Desc("u.created_at").
+		Asc("u.id").
+		AfterValues("2024-01-01", 42).
+		Limit(20).
+		Select("u.city").
+		SelectExpr(clause.As(clause.Count("*"), "cnt")).
+		GroupBy("u.city").
+		Having("cnt", ">", 5)
+	if q.Fingerprint() != same.Fingerprint() {
+		t.Errorf("expected equal fingerprints:\n%s\n%s", q.Canonical(), same.Canonical())
+	}
+
+	if got := Table("users").Select().Canonical(); got != "SELECT * FROM users" {
+		t.Errorf("expected SELECT * FROM users, got: %s", got)
+	}
+}
+
+func TestQuery_String(t *testing.T) {
+	q := Table("users u").
+		LeftJoin("orders o", On("o.user_id", "u.id")).
+		Eq("u.name", "O'Brien").
+		Where(clause.Or(clause.Gt{Col: "u.age", Val: 18}, clause.Eq{Col: "u.role", Val: "admin"})).
+		Desc("u.id").
+		Limit(10)
+
+	expected := `SELECT * FROM users AS u LEFT JOIN orders AS o ON o.user_id = u.id` +
+		` WHERE u.name = 'O''Brien' AND (u.age > 18 OR u.role = 'admin') ORDER BY u.id DESC LIMIT 10`
+	if got := q.String(); got != expected {
+		t.Errorf("expected string:\n%s\ngot:\n%s", expected, got)
+	}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"insert", Table("users").Insert(map[string]any{"name": "John", "email": nil}).String(), `INSERT INTO users (email, name) VALUES (NULL, 'John')`},
+		{"update", Table("users").Eq("id", 1).Update("name", "John").String(), `UPDATE users SET name = 'John' WHERE id = 1`},
+		{"delete", Table("users").In("id", 1, 2).Delete().String(), `DELETE FROM users WHERE id IN (1, 2)`},
+		{"error", Table("users").Where("name", "~", "x").String(), `SELECT * FROM users /* error: invalid operator */`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, tt.got)
+			}
+		})
+	}
+}
+
+func TestQuery_Explain(t *testing.T) {
+	q := Table("users").
+		Eq("status", "active").
+		Where(clause.Or(clause.Gt{Col: "age", Val: 18}, clause.Eq{Col: "role", Val: "admin"})).
+		Limit(10)
+
+	expected := `SELECT * FROM users
+WHERE
+  status = 'active'
+  AND (
+    age > 18
+    OR role = 'admin'
+  )
+LIMIT 10`
+	if got := q.Explain(); got != expected {
+		t.Errorf("expected explain:\n%s\ngot:\n%s", expected, got)
+	}
+
+	expected = `DELETE FROM users
+WHERE
+  id = 1
+RETURNING id`
+	if got := Table("users").Eq("id", 1).Delete().Returning("id").Explain(); got != expected {
+		t.Errorf("expected explain:\n%s\ngot:\n%s", expected, got)
+	}
+
+	sq := Table("orders").
+		Select("city").
+		SelectExpr(clause.As(clause.Count("*"), "cnt")).
+		GroupBy("city").
+		Having("cnt", ">", 5).
+		Having(clause.Or(clause.Eq{Col: "city", Val: "Paris"}, clause.Eq{Col: "cnt", Val: 1})).
+		OrderBy("city")
+
+	expected = `SELECT city, COUNT(*) AS cnt FROM orders GROUP BY city
+HAVING
+  COUNT(*) > 5
+  AND (
+    city = 'Paris'
+    OR COUNT(*) = 1
+  )
+ORDER BY city ASC`
+	if got := sq.Explain(); got != expected {
+		t.Errorf("expected explain:\n%s\ngot:\n%s", expected, got)
+	}
+}
@@ -3,21 +3,27 @@ package query
 import "github.com/epkgs/query/clause"
 
 // UpdateQuery 是 UPDATE 查询结构体。
-// 包含表名、更新字段值、WHERE 条件和分页参数，
+// 包含表名、更新字段值、WHERE 条件、分页参数和 RETURNING 子句，
 // 通过 Build 方法将完整的 UPDATE 语句写入 Builder。
 type UpdateQuery struct {
 	table string
 	errorRecord
 	*where[*UpdateQuery]
 	*pagination[*UpdateQuery]
+	*returning[*UpdateQuery]
+	*columnFilter[*UpdateQuery]
 
 	values map[string]interface{}
 }
 
 // Update 设置UPDATE查询的字段值
-// 支持两种调用方式：
+// 支持三种调用方式：
 // 1. Update("field", value) - 设置单个字段值
 // 2. Update(map[string]interface{}) - 设置多个字段值
+// 3. Update(user) / Update(&user) - 以结构体设置字段值，列名按 db、gorm（column:）、json 标签映射
+//
+// 结构体中声明了 omitempty 的字段为空值时会被忽略，其余字段（包括零值）均会被更新，
+// 可通过 Omit/Only 排除主键等字段；不支持的类型会记录 ErrInvalidUpdateValues 错误。
 func (q *UpdateQuery) Update(column interface{}, value ...interface{}) *UpdateQuery {
 	switch v := column.(type) {
 	case string:
@@ -25,9 +31,14 @@ func (q *UpdateQuery) Update(column interface{}, value ...interface{}) *UpdateQu
 		if len(value) > 0 {
 			q.values[v] = value[0]
 		}
-	case map[string]interface{}:
-		// Set(map[string]interface{}) 形式
-		for k, val := range v {
+	default:
+		// Set(map[string]interface{}) 或结构体形式
+		values, ok := rowValues(v)
+		if !ok {
+			q.Error = ErrInvalidUpdateValues
+			break
+		}
+		for k, val := range values {
 			q.values[k] = val
 		}
 	}
@@ -35,20 +46,42 @@ func (q *UpdateQuery) Update(column interface{}, value ...interface{}) *UpdateQu
 	return q
 }
 
+// TableExpr 返回当前的表名表达式
+func (q *UpdateQuery) TableExpr() clause.Table {
+	return parseTable(q.table)
+}
+
+// ValuesExpr 返回要更新的字段值，已按 Omit/Only 过滤
+func (q *UpdateQuery) ValuesExpr() map[string]any {
+	values := make(map[string]any, len(q.values))
+	for field, value := range q.values {
+		if q.columnFilter.allowed(field) {
+			values[field] = value
+		}
+	}
+	return values
+}
+
 // Build 构建UPDATE查询的SQL语句
 func (q *UpdateQuery) Build(builder clause.Builder) {
+	if q.Error != nil {
+		builder.AddError(q.Error)
+	}
+
 	// 构建 UPDATE 部分
 	builder.WriteString("UPDATE ")
-	builder.WriteQuoted(q.table)
+	q.TableExpr().BuildTarget(builder)
 
 	// 构建 SET 部分
-	if len(q.values) > 0 {
-		builder.WriteString(" SET ")
-		// 对字段进行排序，确保生成的SQL有固定的字段顺序
-		var fields []string
-		for field := range q.values {
+	// 对字段进行排序，确保生成的SQL有固定的字段顺序
+	var fields []string
+	for field := range q.values {
+		if q.columnFilter.allowed(field) {
 			fields = append(fields, field)
 		}
+	}
+	if len(fields) > 0 {
+		builder.WriteString(" SET ")
 		// 简单排序（按字典序）
 		for i := 0; i < len(fields)-1; i++ {
 			for j := i + 1; j < len(fields); j++ {
@@ -68,12 +101,15 @@ func (q *UpdateQuery) Build(builder clause.Builder) {
 		}
 	}
 
+	// 构建 OUTPUT 部分（SQL Server）
+	q.returning.Output(builder)
+
 	// 构建 WHERE 部分
 	q.where.Build(builder)
 
-	// 构建分页部分
-	if q.pagination.Value.Limit != nil && *q.pagination.Value.Limit > 0 {
-		builder.WriteString(" LIMIT ")
-		builder.AddVar(builder, *q.pagination.Value.Limit)
-	}
+	// 构建行数限制部分，只有部分方言支持
+	q.pagination.Value.BuildRowLimit(builder)
+
+	// 构建 RETURNING 部分
+	q.returning.Build(builder)
 }
@@ -0,0 +1,313 @@
+package query
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/epkgs/query/clause"
+)
+
+var (
+	// ErrUnknownField 表示字段未在 Schema 中声明。
+	ErrUnknownField = errors.New("unknown field")
+	// ErrOperatorNotAllowed 表示字段不允许使用该操作符。
+	ErrOperatorNotAllowed = errors.New("operator not allowed")
+	// ErrInvalidFieldValue 表示值的类型与字段类型不匹配。
+	ErrInvalidFieldValue = errors.New("invalid field value")
+	// ErrFieldNotSortable 表示字段不允许排序。
+	ErrFieldNotSortable = errors.New("field not sortable")
+	// ErrExpressionNotAllowed 表示条件或排序中包含未放行的表达式（如原生 SQL、子查询）。
+	ErrExpressionNotAllowed = errors.New("expression not allowed")
+)
+
+// FieldError 表示字段未通过 Schema 校验，Err 为 ErrUnknownField 等错误。
+type FieldError struct {
+	Field    string          // 客户端传入的字段名
+	Operator clause.Operator // 比较操作符，排序校验时为空
+	Err      error
+}
+
+func (e *FieldError) Error() string {
+	if e.Operator != "" {
+		return fmt.Sprintf("field %q (%s): %v", e.Field, e.Operator, e.Err)
+	}
+	return fmt.Sprintf("field %q: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Field 描述一个对外暴露的查询字段。
+type Field struct {
+	// Name 为客户端使用的字段名（如 AIP 过滤中的 "author.name"）
+	Name string
+	// Column 为实际的列名，为空时与 Name 相同
+	Column string
+	// Type 为字段的 Go 类型，用于校验比较值，为 nil 时不校验。
+	// 数值类型之间可以互相比较，指针类型按其指向的类型处理。
+	Type reflect.Type
+	// Operators 为允许的比较操作符，为空时允许全部操作符
+	Operators []clause.Operator
+	// Sortable 表示字段是否允许排序
+	Sortable bool
+}
+
+// Schema 描述对外暴露的字段及其策略，用于校验并改写来自客户端的查询条件和排序：
+// 未声明的字段、不允许的操作符、类型不匹配的值和不可排序的字段都会返回 *FieldError，
+// 通过校验的字段名改写为对应的列名。
+//
+// Schema 与条件的来源无关，可用于 AIP 过滤、URL 参数或手动构建的查询。
+//
+// 示例:
+//
+//	schema := query.NewSchema(
+//	    query.Field{Name: "name", Type: reflect.TypeOf(""), Sortable: true},
+//	    query.Field{Name: "age", Column: "user_age", Type: reflect.TypeOf(0),
+//	        Operators: []clause.Operator{clause.OpEQ, clause.OpGT, clause.OpLT}},
+//	)
+//
+//	where, err := schema.Where(whereClause)
+//	orderBys, err := schema.OrderBys(orderBys)
+//
+//	var fieldErr *query.FieldError
+//	if errors.As(err, &fieldErr) {
+//	    // fieldErr.Field 为出错的字段
+//	}
+type Schema struct {
+	fields    map[string]Field
+	allowExpr func(e clause.Expression) bool
+}
+
+// NewSchema 创建包含指定字段的 Schema，同名字段后者覆盖前者。
+func NewSchema(fields ...Field) *Schema {
+	s := &Schema{fields: make(map[string]Field, len(fields))}
+	for _, f := range fields {
+		if f.Column == "" {
+			f.Column = f.Name
+		}
+		s.fields[f.Name] = f
+	}
+	return s
+}
+
+// AllowExpr 设置非比较表达式（如原生 SQL、子查询、按表达式排序）的放行规则，
+// 默认全部拒绝。适用于放行由服务端生成的表达式，如 AIP 自定义函数返回的原生 SQL。
+func (s *Schema) AllowExpr(allow func(e clause.Expression) bool) *Schema {
+	s.allowExpr = allow
+	return s
+}
+
+// Field 根据字段名查找字段
+func (s *Schema) Field(name string) (Field, bool) {
+	f, ok := s.fields[name]
+	return f, ok
+}
+
+// Where 校验 WHERE 条件，并将字段名改写为列名。
+// 校验失败时返回 *FieldError，或包装 ErrExpressionNotAllowed 的错误。
+func (s *Schema) Where(where clause.Where) (clause.Where, error) {
+	var err error
+	checked := where.Map(func(e clause.Expression) clause.Expression {
+		if err != nil {
+			return e
+		}
+
+		switch e := e.(type) {
+		case clause.ComparisonExpression:
+			var column string
+			column, err = s.checkComparison(e)
+			if err != nil {
+				return e
+			}
+			return clause.WithColumn(e, column)
+		case clause.LogicalExpression:
+			return e
+		default:
+			if !s.allowed(e) {
+				err = fmt.Errorf("%w: %T", ErrExpressionNotAllowed, e)
+			}
+			return e
+		}
+	})
+	if err != nil {
+		return clause.Where{}, err
+	}
+	return checked, nil
+}
+
+// OrderBys 校验排序条件，并将字段名改写为列名。
+// 校验失败时返回 *FieldError，或包装 ErrExpressionNotAllowed 的错误。
+func (s *Schema) OrderBys(orders clause.OrderBys) (clause.OrderBys, error) {
+	var err error
+	checked := orders.Map(func(o clause.OrderBy) *clause.OrderBy {
+		if err != nil {
+			return &o
+		}
+
+		if o.Expr != nil {
+			if !s.allowed(o.Expr) {
+				err = fmt.Errorf("%w: order by %T", ErrExpressionNotAllowed, o.Expr)
+			}
+			return &o
+		}
+
+		f, ok := s.fields[o.Column]
+		switch {
+		case !ok:
+			err = &FieldError{Field: o.Column, Err: ErrUnknownField}
+		case !f.Sortable:
+			err = &FieldError{Field: o.Column, Err: ErrFieldNotSortable}
+		default:
+			o.Column = f.Column
+		}
+		return &o
+	})
+	if err != nil {
+		return nil, err
+	}
+	return checked, nil
+}
+
+// Apply 校验并改写 Query 的 WHERE 条件和排序
+func (s *Schema) Apply(q *Query) error {
+	where, err := s.Where(q.where.Value)
+	if err != nil {
+		return err
+	}
+	orders, err := s.OrderBys(q.orderbys.Value)
+	if err != nil {
+		return err
+	}
+	q.where.Value = where
+	q.orderbys.Value = orders
+	return nil
+}
+
+func (s *Schema) allowed(e clause.Expression) bool {
+	return s.allowExpr != nil && s.allowExpr(e)
+}
+
+// checkComparison 校验比较表达式的字段、操作符和值，返回字段对应的列名
+func (s *Schema) checkComparison(e clause.ComparisonExpression) (string, error) {
+	f, ok := s.fields[e.Column()]
+	if !ok {
+		return "", &FieldError{Field: e.Column(), Operator: e.Operator(), Err: ErrUnknownField}
+	}
+
+	if len(f.Operators) > 0 && !containsOperator(f.Operators, e.Operator()) {
+		return "", &FieldError{Field: f.Name, Operator: e.Operator(), Err: ErrOperatorNotAllowed}
+	}
+
+	// 值为表达式（如 clause.Column、子查询）时可能引用任意列或表，与非比较表达式一样按 AllowExpr 放行
+	if v, ok := s.deniedValue(e.Value()); ok {
+		return "", &FieldError{Field: f.Name, Operator: e.Operator(), Err: fmt.Errorf("%w: %T", ErrExpressionNotAllowed, v)}
+	}
+
+	if f.Type != nil {
+		if err := checkFieldValue(f.Type, e.Value()); err != nil {
+			return "", &FieldError{Field: f.Name, Operator: e.Operator(), Err: err}
+		}
+	}
+
+	return f.Column, nil
+}
+
+func containsOperator(operators []clause.Operator, op clause.Operator) bool {
+	for _, o := range operators {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// deniedValue 返回值中未被 AllowExpr 放行的表达式：值本身为表达式时检查该值，
+// 切片（IN、BETWEEN 的值）逐个检查元素。
+func (s *Schema) deniedValue(value any) (clause.Expression, bool) {
+	if e, ok := value.(clause.Expression); ok {
+		return e, !s.allowed(e)
+	}
+
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type().Elem().Kind() != reflect.Uint8 {
+		for i := 0; i < rv.Len(); i++ {
+			if e, ok := s.deniedValue(rv.Index(i).Interface()); ok {
+				return e, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// checkFieldValue 检查值能否与类型为 t 的字段比较。
+// nil 总是允许；表达式已由 AllowExpr 放行，不检查类型；切片（IN、BETWEEN 的值）逐个检查元素。
+func checkFieldValue(t reflect.Type, value any) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if _, ok := value.(clause.Expression); ok {
+		return nil
+	}
+
+	if valuer, ok := value.(driver.Valuer); ok && !reflect.TypeOf(value).AssignableTo(t) {
+		v, err := valuer.Value()
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFieldValue, err)
+		}
+		value = v
+	}
+
+	if value == nil {
+		return nil
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	vt := rv.Type()
+
+	if vt.AssignableTo(t) || valueClass(vt) != classOther && valueClass(vt) == valueClass(t) {
+		return nil
+	}
+
+	if (vt.Kind() == reflect.Slice || vt.Kind() == reflect.Array) && vt.Elem().Kind() != reflect.Uint8 {
+		for i := 0; i < rv.Len(); i++ {
+			if err := checkFieldValue(t, rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: expected %s, got %s", ErrInvalidFieldValue, t, vt)
+}
+
+const (
+	classOther = iota
+	classBool
+	classNumber
+	classString
+)
+
+// valueClass 返回类型的比较类别，同类别的类型可以互相比较
+func valueClass(t reflect.Type) int {
+	switch t.Kind() {
+	case reflect.Bool:
+		return classBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return classNumber
+	case reflect.String:
+		return classString
+	}
+	return classOther
+}
@@ -0,0 +1,142 @@
+package sqlexec
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/epkgs/query/internal/fieldmap"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+	mapType     = reflect.TypeOf(map[string]any{})
+)
+
+// ScanOne 将 rows 的第一行扫描到 dest 中，没有结果时返回 sql.ErrNoRows。
+// dest 的类型要求同 Executor.Get。
+func ScanOne(rows *sql.Rows, dest any) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("%w: expected non-nil pointer, got %T", ErrInvalidDest, dest)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+
+	if err := scanRow(rows, columns, v.Elem()); err != nil {
+		return err
+	}
+
+	return rows.Err()
+}
+
+// ScanAll 将 rows 的所有行追加到 dest 指向的切片中。
+// dest 的类型要求同 Executor.All。
+func ScanAll(rows *sql.Rows, dest any) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%w: expected pointer to slice, got %T", ErrInvalidDest, dest)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	for rows.Next() {
+		elem := reflect.New(elemType)
+		if err := scanRow(rows, columns, elem.Elem()); err != nil {
+			return err
+		}
+
+		if isPtr {
+			slice = reflect.Append(slice, elem)
+		} else {
+			slice = reflect.Append(slice, elem.Elem())
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	v.Elem().Set(slice)
+	return nil
+}
+
+// scanRow 将当前行扫描到 dest 中，dest 必须是可设置的值。
+func scanRow(rows *sql.Rows, columns []string, dest reflect.Value) error {
+	switch {
+	case isScalar(dest.Type()):
+		if len(columns) != 1 {
+			return fmt.Errorf("%w: scanning %d columns into %s", ErrInvalidDest, len(columns), dest.Type())
+		}
+		return rows.Scan(dest.Addr().Interface())
+
+	case dest.Type() == mapType:
+		values := make([]any, len(columns))
+		ptrs := make([]any, len(columns))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return err
+		}
+
+		m := make(map[string]any, len(columns))
+		for i, column := range columns {
+			m[column] = values[i]
+		}
+		dest.Set(reflect.ValueOf(m))
+		return nil
+
+	case dest.Kind() == reflect.Struct:
+		st := fieldmap.Of(dest.Type())
+		ptrs := make([]any, len(columns))
+		for i, column := range columns {
+			f, ok := st.Lookup(column)
+			if !ok {
+				// 结构体中不存在的列直接丢弃
+				ptrs[i] = new(any)
+				continue
+			}
+			ptrs[i] = dest.FieldByIndex(f.Index).Addr().Interface()
+		}
+		return rows.Scan(ptrs...)
+	}
+
+	return fmt.Errorf("%w: unsupported type %s", ErrInvalidDest, dest.Type())
+}
+
+// isScalar 判断类型是否应作为单列值扫描。
+// 非结构体、非 map 的类型，以及 time.Time、实现了 sql.Scanner 的结构体都视为单列值。
+func isScalar(t reflect.Type) bool {
+	if t == timeType || reflect.PtrTo(t).Implements(scannerType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+// Package sqlexec 提供了基于 database/sql 的查询执行层。
+//
+// Executor 通过 dialect.Builder 将 SelectQuery、InsertQuery、UpdateQuery、
+// DeleteQuery 等查询构建为 SQL 语句，交由 *sql.DB、*sql.Tx 或 *sql.Conn 执行，
+// 并将结果行扫描到结构体、map 或基本类型中。
+//
+// 结构体字段与列名的映射规则依次为 db、gorm（column:）、json 标签，
+// 未设置标签时使用字段名的蛇形命名（如 CreatedAt -> created_at）。
+//
+// 使用方式：
+//
+//	db, _ := sql.Open("sqlite3", "file::memory:")
+//	exec := sqlexec.New(db, dialect.SQLite)
+//
+//	var users []User
+//	err := exec.All(ctx, query.Table("users").Gte("age", 18).Select(), &users)
+//
+//	var user User
+//	err = exec.Get(ctx, query.Table("users").Eq("id", 1).Select(), &user)
+//
+//	res, err := exec.Exec(ctx, query.Table("users").Eq("id", 1).Delete())
+package sqlexec
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/epkgs/query/clause"
+	"github.com/epkgs/query/dialect"
+)
+
+var (
+	ErrInvalidDest = errors.New("invalid scan destination")
+)
+
+// DB 是执行 SQL 所需的最小接口，*sql.DB、*sql.Tx 和 *sql.Conn 均实现了此接口。
+type DB interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Executor 使用指定方言构建并执行查询。
+type Executor struct {
+	db      DB
+	dialect dialect.Dialect
+}
+
+// New 创建一个执行器。
+func New(db DB, d dialect.Dialect) *Executor {
+	return &Executor{db: db, dialect: d}
+}
+
+// Dialect 返回执行器使用的方言。
+func (e *Executor) Dialect() dialect.Dialect {
+	return e.dialect
+}
+
+// WithDB 返回使用另一个 DB（通常是事务 *sql.Tx）的执行器，方言保持不变。
+//
+// 示例：
+//
+//	tx, _ := db.BeginTx(ctx, nil)
+//	err := exec.WithDB(tx).Exec(ctx, q)
+func (e *Executor) WithDB(db DB) *Executor {
+	return &Executor{db: db, dialect: e.dialect}
+}
+
+// Build 构建查询，返回 SQL 语句和参数列表。
+func (e *Executor) Build(stmt clause.Expression) (string, []any, error) {
+	return dialect.Build(e.dialect, stmt)
+}
+
+// Exec 执行不返回结果行的语句（INSERT、UPDATE、DELETE 等）。
+func (e *Executor) Exec(ctx context.Context, stmt clause.Expression) (sql.Result, error) {
+	query, args, err := e.Build(stmt)
+	if err != nil {
+		return nil, err
+	}
+	return e.db.ExecContext(ctx, query, args...)
+}
+
+// Query 执行查询并返回原始的 *sql.Rows，调用方负责关闭。
+func (e *Executor) Query(ctx context.Context, stmt clause.Expression) (*sql.Rows, error) {
+	query, args, err := e.Build(stmt)
+	if err != nil {
+		return nil, err
+	}
+	return e.db.QueryContext(ctx, query, args...)
+}
+
+// Get 执行查询并将第一行扫描到 dest 中，没有结果时返回 sql.ErrNoRows。
+//
+// dest 必须是非 nil 指针，支持以下类型：
+//   - *struct：按列名映射到结构体字段；
+//   - *map[string]any：以列名为键；
+//   - 其它类型（如 *int、*string、*time.Time）：扫描单列结果。
+func (e *Executor) Get(ctx context.Context, stmt clause.Expression, dest any) error {
+	rows, err := e.Query(ctx, stmt)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return ScanOne(rows, dest)
+}
+
+// All 执行查询并将所有行扫描到 dest 指向的切片中。
+//
+// dest 必须是指向切片的指针，元素类型可以是结构体、结构体指针、
+// map[string]any 或基本类型，规则同 Get。
+func (e *Executor) All(ctx context.Context, stmt clause.Expression, dest any) error {
+	rows, err := e.Query(ctx, stmt)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return ScanAll(rows, dest)
+}
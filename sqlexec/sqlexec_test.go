@@ -0,0 +1,269 @@
+package sqlexec
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/epkgs/query"
+	"github.com/epkgs/query/dialect"
+)
+
+// fakeConn 是用于测试的 database/sql 驱动连接
+// 它记录执行的 SQL 与参数，并返回预设的结果行
+type fakeConn struct {
+	query   string
+	args    []any
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (c *fakeConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c *fakeConn) Driver() driver.Driver                        { return nil }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c *fakeConn) record(query string, args []driver.NamedValue) {
+	c.query = query
+	c.args = make([]any, len(args))
+	for i, arg := range args {
+		c.args[i] = arg.Value
+	}
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.record(query, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.record(query, args)
+	return &fakeRows{columns: c.columns, rows: c.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	pos     int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+func newTestExecutor(conn *fakeConn) *Executor {
+	return New(sql.OpenDB(conn), dialect.SQLite)
+}
+
+type Base struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+type User struct {
+	Base
+	Name     string `db:"name"`
+	Age      int    `gorm:"column:user_age"`
+	Email    string `json:"email,omitempty"`
+	Ignored  string `db:"-"`
+	internal string
+}
+
+// TestExecutor_Exec 测试执行不返回结果行的语句
+func TestExecutor_Exec(t *testing.T) {
+	conn := &fakeConn{}
+	exec := newTestExecutor(conn)
+
+	res, err := exec.Exec(context.Background(), query.Table("users").Eq("id", 1).Update("name", "John"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedSQL := `UPDATE "users" SET "name" = ? WHERE "id" = ?`
+	if conn.query != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, conn.query)
+	}
+	if !reflect.DeepEqual(conn.args, []any{"John", int64(1)}) {
+		t.Errorf("expected args: [John 1], got: %v", conn.args)
+	}
+
+	if n, _ := res.RowsAffected(); n != 1 {
+		t.Errorf("expected 1 row affected, got %d", n)
+	}
+}
+
+// TestExecutor_BuildError 测试查询构建错误不会被执行
+func TestExecutor_BuildError(t *testing.T) {
+	conn := &fakeConn{}
+	exec := newTestExecutor(conn)
+
+	_, err := exec.Exec(context.Background(), query.Table("users").Where("id", "~", 1).Delete())
+	if !errors.Is(err, query.ErrInvalidOperator) {
+		t.Errorf("expected ErrInvalidOperator, got: %v", err)
+	}
+	if conn.query != "" {
+		t.Errorf("expected no statement to be executed, got: %s", conn.query)
+	}
+}
+
+// TestExecutor_AllStructs 测试扫描多行到结构体切片
+func TestExecutor_AllStructs(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	conn := &fakeConn{
+		columns: []string{"id", "created_at", "name", "user_age", "email", "unknown"},
+		rows: [][]driver.Value{
+			{int64(1), now, "John", int64(30), "john@example.com", "x"},
+			{int64(2), now, "Jane", int64(25), "jane@example.com", "y"},
+		},
+	}
+	exec := newTestExecutor(conn)
+
+	var users []User
+	err := exec.All(context.Background(), query.Table("users").Gte("age", 18).Asc("id").Select(), &users)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedSQL := `SELECT * FROM "users" WHERE "age" >= ? ORDER BY "id" ASC`
+	if conn.query != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, conn.query)
+	}
+
+	expected := []User{
+		{Base: Base{ID: 1, CreatedAt: now}, Name: "John", Age: 30, Email: "john@example.com"},
+		{Base: Base{ID: 2, CreatedAt: now}, Name: "Jane", Age: 25, Email: "jane@example.com"},
+	}
+	if !reflect.DeepEqual(users, expected) {
+		t.Errorf("expected users: %+v, got: %+v", expected, users)
+	}
+}
+
+// TestExecutor_AllPointersAndMaps 测试扫描到结构体指针切片和 map 切片
+func TestExecutor_AllPointersAndMaps(t *testing.T) {
+	conn := &fakeConn{
+		columns: []string{"id", "name"},
+		rows: [][]driver.Value{
+			{int64(1), "John"},
+			{int64(2), "Jane"},
+		},
+	}
+	exec := newTestExecutor(conn)
+
+	var users []*User
+	if err := exec.All(context.Background(), query.Table("users").Select("id", "name"), &users); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 2 || users[0].ID != 1 || users[1].Name != "Jane" {
+		t.Errorf("unexpected users: %+v", users)
+	}
+
+	var rows []map[string]any
+	if err := exec.All(context.Background(), query.Table("users").Select("id", "name"), &rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []map[string]any{
+		{"id": int64(1), "name": "John"},
+		{"id": int64(2), "name": "Jane"},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected rows: %v, got: %v", expected, rows)
+	}
+}
+
+// TestExecutor_Get 测试扫描单行
+func TestExecutor_Get(t *testing.T) {
+	conn := &fakeConn{
+		columns: []string{"id", "name"},
+		rows:    [][]driver.Value{{int64(1), "John"}},
+	}
+	exec := newTestExecutor(conn)
+
+	var user User
+	if err := exec.Get(context.Background(), query.Table("users").Eq("id", 1).Limit(1).Select(), &user); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.ID != 1 || user.Name != "John" {
+		t.Errorf("unexpected user: %+v", user)
+	}
+
+	expectedSQL := `SELECT * FROM "users" WHERE "id" = ? LIMIT ?`
+	if conn.query != expectedSQL {
+		t.Errorf("expected SQL: %s, got: %s", expectedSQL, conn.query)
+	}
+}
+
+// TestExecutor_GetScalar 测试扫描单列值
+func TestExecutor_GetScalar(t *testing.T) {
+	conn := &fakeConn{
+		columns: []string{"name"},
+		rows:    [][]driver.Value{{"John"}},
+	}
+	exec := newTestExecutor(conn)
+
+	var name string
+	if err := exec.Get(context.Background(), query.Table("users").Select("name"), &name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "John" {
+		t.Errorf("expected John, got: %s", name)
+	}
+
+	var names []sql.NullString
+	if err := exec.All(context.Background(), query.Table("users").Select("name"), &names); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(names) != 1 || names[0].String != "John" {
+		t.Errorf("unexpected names: %v", names)
+	}
+}
+
+// TestExecutor_GetNoRows 测试没有结果时返回 sql.ErrNoRows
+func TestExecutor_GetNoRows(t *testing.T) {
+	conn := &fakeConn{columns: []string{"id"}}
+	exec := newTestExecutor(conn)
+
+	var user User
+	err := exec.Get(context.Background(), query.Table("users").Eq("id", 1).Select(), &user)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got: %v", err)
+	}
+}
+
+// TestExecutor_InvalidDest 测试无效的扫描目标
+func TestExecutor_InvalidDest(t *testing.T) {
+	conn := &fakeConn{
+		columns: []string{"id", "name"},
+		rows:    [][]driver.Value{{int64(1), "John"}},
+	}
+	exec := newTestExecutor(conn)
+	q := query.Table("users").Select()
+
+	var user User
+	if err := exec.Get(context.Background(), q, user); !errors.Is(err, ErrInvalidDest) {
+		t.Errorf("expected ErrInvalidDest for non-pointer, got: %v", err)
+	}
+
+	if err := exec.All(context.Background(), q, &user); !errors.Is(err, ErrInvalidDest) {
+		t.Errorf("expected ErrInvalidDest for non-slice, got: %v", err)
+	}
+
+	var id int64
+	if err := exec.Get(context.Background(), q, &id); !errors.Is(err, ErrInvalidDest) {
+		t.Errorf("expected ErrInvalidDest for multi-column scalar, got: %v", err)
+	}
+}
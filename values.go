@@ -0,0 +1,114 @@
+package query
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"time"
+
+	"github.com/epkgs/query/internal/fieldmap"
+)
+
+var (
+	valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	timeType   = reflect.TypeOf(time.Time{})
+)
+
+// rowValues 将插入/更新的行转换为列名到值的映射。
+// 支持 map[string]any、结构体和结构体指针，结构体字段按 db、gorm（column:）、json 标签映射列名；
+// 声明了 omitempty 的字段为空值时会被忽略。不支持的类型返回 false。
+func rowValues(row any) (map[string]any, bool) {
+	if m, ok := row.(map[string]any); ok {
+		return m, true
+	}
+
+	rv, ok := structValue(row)
+	if !ok {
+		return nil, false
+	}
+
+	st := fieldmap.Of(rv.Type())
+	values := make(map[string]any, len(st.Fields))
+	for _, f := range st.Fields {
+		fv := rv.FieldByIndex(f.Index)
+		if f.OmitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		values[f.Column] = fieldValue(fv)
+	}
+	return values, true
+}
+
+// rowLookup 返回按列名从行中取值的函数，行的类型同 rowValues。
+// 与 rowValues 不同，omitempty 字段为空值时同样可以取到（如游标中值为 0 的排序键）。
+func rowLookup(row any) (func(column string) (any, bool), bool) {
+	if m, ok := row.(map[string]any); ok {
+		return func(column string) (any, bool) {
+			val, ok := m[column]
+			return val, ok
+		}, true
+	}
+
+	rv, ok := structValue(row)
+	if !ok {
+		return nil, false
+	}
+
+	st := fieldmap.Of(rv.Type())
+	return func(column string) (any, bool) {
+		f, ok := st.Lookup(column)
+		if !ok {
+			return nil, false
+		}
+		return fieldValue(rv.FieldByIndex(f.Index)), true
+	}, true
+}
+
+// structValue 解引用结构体指针，返回结构体的值；非结构体或应作为单个值处理的结构体返回 false
+func structValue(row any) (reflect.Value, bool) {
+	rv := reflect.ValueOf(row)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return reflect.Value{}, false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || isValue(rv.Type()) {
+		return reflect.Value{}, false
+	}
+	return rv, true
+}
+
+// fieldValue 返回字段的值。
+// 以指针接收者实现 driver.Valuer 的字段会转换为指针，以便驱动调用其 Value 方法。
+func fieldValue(v reflect.Value) any {
+	if v.Type().Implements(valuerType) || !reflect.PtrTo(v.Type()).Implements(valuerType) {
+		return v.Interface()
+	}
+	if v.CanAddr() {
+		return v.Addr().Interface()
+	}
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	return ptr.Interface()
+}
+
+// isValue 判断结构体类型是否应作为单个值处理（time.Time、实现了 driver.Valuer 的类型）
+func isValue(t reflect.Type) bool {
+	return t == timeType || t.Implements(valuerType) || reflect.PtrTo(t).Implements(valuerType)
+}
+
+// isEmptyValue 判断字段是否为空值。
+// 实现了 driver.Valuer 的字段以 Value() 返回 nil 视为空值（如 sql.NullString{Valid: false}），
+// 其他字段以零值视为空值。
+func isEmptyValue(v reflect.Value) bool {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return true
+	}
+
+	if valuer, ok := fieldValue(v).(driver.Valuer); ok {
+		val, err := valuer.Value()
+		return err == nil && val == nil
+	}
+
+	return v.IsZero()
+}